@@ -1,36 +1,82 @@
 module github.com/enaldo1709/budget-manager/app
 
-go 1.20
+go 1.21.1
 
 replace github.com/enaldo1709/budget-manager/domain/model => ../domain/model
 
+replace github.com/enaldo1709/budget-manager/domain/usecase => ../domain/usecase
+
+replace github.com/enaldo1709/budget-manager/helpers/errorutil => ../helpers/errorutil
+
+replace github.com/enaldo1709/budget-manager/infrastructure/adapters/memory-adapter => ../infrastructure/adapters/memory-adapter
+
+replace github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter => ../infrastructure/adapters/postgresql-adapter
+
+replace github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter => ../infrastructure/adapters/sqlite-adapter
+
+replace github.com/enaldo1709/budget-manager/infrastructure/entry-points/rest-api => ../infrastructure/entry-points/rest-api
+
+replace github.com/enaldo1709/budget-manager/infrastructure/helpers/configutil => ../infrastructure/helpers/configutil
+
 require (
 	github.com/enaldo1709/budget-manager/domain/model v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/domain/usecase v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/infrastructure/adapters/memory-adapter v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/infrastructure/entry-points/rest-api v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/infrastructure/helpers/configutil v0.0.0-00010101000000-000000000000
 	github.com/gin-gonic/gin v1.9.0
 )
 
 require (
 	github.com/bytedance/sonic v1.8.3 // indirect
 	github.com/chenzhuoyu/base64x v0.0.0-20221115062448-fe3a3abad311 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/enaldo1709/budget-manager/helpers/errorutil v0.0.0-00010101000000-000000000000 // indirect
+	github.com/fatih/color v1.15.0 // indirect
 	github.com/gin-contrib/sse v0.1.0 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.11.2 // indirect
-	github.com/goccy/go-json v0.10.0 // indirect
+	github.com/goccy/go-json v0.10.2 // indirect
+	github.com/goccy/go-yaml v1.11.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/gookit/color v1.5.4 // indirect
+	github.com/gookit/config/v2 v2.2.3 // indirect
+	github.com/gookit/goutil v0.6.12 // indirect
+	github.com/hashicorp/golang-lru/v2 v2.0.7 // indirect
+	github.com/imdario/mergo v0.3.16 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.4 // indirect
 	github.com/leodido/go-urn v1.2.2 // indirect
-	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/lib/pq v1.10.9 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
 	github.com/pelletier/go-toml/v2 v2.0.7 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/ugorji/go/codec v1.2.10 // indirect
+	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	golang.org/x/arch v0.3.0 // indirect
 	golang.org/x/crypto v0.7.0 // indirect
 	golang.org/x/net v0.8.0 // indirect
-	golang.org/x/sys v0.6.0 // indirect
-	golang.org/x/text v0.8.0 // indirect
+	golang.org/x/sync v0.3.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+	golang.org/x/term v0.12.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 	google.golang.org/protobuf v1.28.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
+	modernc.org/gc/v3 v3.0.0-20240107210532-573471604cb6 // indirect
+	modernc.org/libc v1.55.3 // indirect
+	modernc.org/mathutil v1.6.0 // indirect
+	modernc.org/memory v1.8.0 // indirect
+	modernc.org/sqlite v1.33.1 // indirect
+	modernc.org/strutil v1.2.0 // indirect
+	modernc.org/token v1.1.0 // indirect
 )
@@ -1,17 +1,39 @@
 package main
 
 import (
-	"net/http"
+	"log"
+	"os"
+	"strings"
 
-	"github.com/gin-gonic/gin"
+	"github.com/enaldo1709/budget-manager/app/src/bootstrap"
 )
 
 func main() {
-	app := gin.Default()
+	if args := commandArgs(); len(args) > 0 && args[0] == bootstrap.MigrateCommand {
+		if err := bootstrap.Migrate(args[1:]); err != nil {
+			log.Fatal("error: migration failed... ", err)
+		}
+		return
+	}
 
-	app.GET("/health", func(ctx *gin.Context) {
-		ctx.JSON(http.StatusOK, gin.H{"status": "UP"})
-	})
+	application, err := bootstrap.New()
+	if err != nil {
+		log.Fatal("error: error starting application... ", err)
+	}
 
-	app.Run()
+	if err := application.Run(); err != nil {
+		log.Fatal("error: application stopped with errors... ", err)
+	}
+}
+
+// commandArgs returns the positional arguments, skipping flags such as
+// --profiles that are read by the configuration loader.
+func commandArgs() []string {
+	args := []string{}
+	for _, arg := range os.Args[1:] {
+		if !strings.HasPrefix(arg, "--") {
+			args = append(args, arg)
+		}
+	}
+	return args
 }
@@ -0,0 +1,172 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+// Application is the composition root of the service: it owns every
+// long-lived resource and releases them on shutdown.
+type Application struct {
+	server          *http.Server
+	repositories    *repositories
+	trashPurger     trashPurger
+	scheduler       recurringScheduler
+	shutdownTimeout time.Duration
+}
+
+func New() (*Application, error) {
+	props, err := loadProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := newRepositories(props)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := usecase.CurrencyConverter{
+		Rates:    repos.rates,
+		Currency: props.Currency.Reporting,
+	}
+	uc := useCases{
+		expenses: usecase.ExpenseUseCase{
+			Repository:   repos.expenses,
+			Categories:   repos.categories,
+			Accounts:     repos.accounts,
+			Transactions: repos.transactions,
+			Audit:        repos.audit,
+			Currency:     props.Currency.Reporting,
+		},
+		incomes: usecase.IncomeUseCase{
+			Repository:   repos.incomes,
+			Accounts:     repos.accounts,
+			Transactions: repos.transactions,
+			Audit:        repos.audit,
+			Currency:     props.Currency.Reporting,
+		},
+		balance: usecase.BalanceUseCase{
+			Repository: repos.balance,
+			Converter:  converter,
+		},
+		categories: usecase.CategoryUseCase{
+			Repository:   repos.categories,
+			Transactions: repos.transactions,
+		},
+		budgets: usecase.BudgetUseCase{
+			Repository: repos.budgets,
+			Categories: repos.categories,
+			Converter:  converter,
+		},
+		imports: usecase.ImportUseCase{
+			Expenses:     repos.expenses,
+			Incomes:      repos.incomes,
+			Categories:   repos.categories,
+			Accounts:     repos.accounts,
+			Imports:      repos.imports,
+			Transactions: repos.transactions,
+			Audit:        repos.audit,
+			Currency:     props.Currency.Reporting,
+		},
+		accounts: usecase.AccountUseCase{
+			Repository: repos.accounts,
+			Transfers:  repos.transfers,
+		},
+		transfers: usecase.TransferUseCase{
+			Repository:   repos.transfers,
+			Accounts:     repos.accounts,
+			Transactions: repos.transactions,
+		},
+		rates: usecase.ExchangeRateUseCase{
+			Repository: repos.rates,
+		},
+		recurring: usecase.RecurringUseCase{
+			Repository:   repos.recurring,
+			Expenses:     repos.expenses,
+			Incomes:      repos.incomes,
+			Categories:   repos.categories,
+			Accounts:     repos.accounts,
+			Transactions: repos.transactions,
+			Audit:        repos.audit,
+			Currency:     props.Currency.Reporting,
+		},
+	}
+
+	return &Application{
+		server: &http.Server{
+			Addr:    fmt.Sprintf(":%d", props.Server.Port),
+			Handler: newRouter(uc),
+		},
+		repositories: repos,
+		trashPurger: trashPurger{
+			expenses:  uc.expenses,
+			retention: props.Trash.Retention,
+			interval:  props.Trash.PurgeInterval,
+		},
+		scheduler: recurringScheduler{
+			recurring: uc.recurring,
+			interval:  props.Scheduler.Interval,
+		},
+		shutdownTimeout: props.Server.ShutdownTimeout,
+	}, nil
+}
+
+// Run serves HTTP requests, purges the expenses trash and creates the due
+// occurrences of recurring rules until the process receives SIGINT or
+// SIGTERM, then drains in-flight requests and closes the repositories.
+func (a *Application) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	purgerDone := a.trashPurger.start(ctx)
+	schedulerDone := a.scheduler.start(ctx)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("info: listening on %s\n", a.server.Addr)
+		if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		stop()
+		<-purgerDone
+		<-schedulerDone
+		return errors.Join(err, a.repositories.close())
+	case <-ctx.Done():
+		log.Println("info: shutdown signal received... ")
+	}
+	<-purgerDone
+	<-schedulerDone
+
+	return a.shutdown()
+}
+
+func (a *Application) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := a.server.Shutdown(ctx); err != nil {
+		log.Println("error: error shutting down http server... ", err)
+		errs = append(errs, err)
+	}
+	if err := a.repositories.close(); err != nil {
+		log.Println("error: error closing database connection... ", err)
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+	"github.com/enaldo1709/budget-manager/infrastructure/helpers/configutil/src/configutil"
+)
+
+const (
+	serverPropertiesKey = "server"
+	databaseKey         = "db"
+	dbPropertiesKey     = "db.properties"
+	sqlitePropertiesKey = "db.sqlite"
+	migrationsKey       = "db.migrations"
+	trashKey            = "expenses.trash"
+	currencyKey         = "currency"
+	recurringKey        = "recurring.scheduler"
+
+	defaultPort              = 8080
+	defaultShutdownTimeout   = 10 * time.Second
+	defaultTrashRetention    = 30 * 24 * time.Hour
+	defaultPurgeInterval     = time.Hour
+	defaultCurrency          = "USD"
+	defaultSchedulerInterval = time.Hour
+)
+
+type ServerProperties struct {
+	Port            int           `yaml:"port"`
+	ShutdownTimeout time.Duration `yaml:"shutdown-timeout"`
+}
+
+// DatabaseProperties selects the adapters backing the repositories:
+// "postgres", the default, "sqlite" or "memory".
+type DatabaseProperties struct {
+	Driver string `yaml:"driver"`
+}
+
+// MigrationProperties decides whether pending schema migrations are applied
+// when the service starts; otherwise they run through the migrate command.
+type MigrationProperties struct {
+	Auto bool `yaml:"auto"`
+}
+
+// TrashProperties sets how long deleted expenses stay in the trash and how
+// often the trash is purged. A zero purge interval disables the purge.
+type TrashProperties struct {
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge-interval"`
+}
+
+// CurrencyProperties sets the ISO 4217 currency balances and budgets are
+// reported in; amounts in other currencies are converted into it. Like every
+// currency, it must have cents.
+type CurrencyProperties struct {
+	Reporting string `yaml:"reporting"`
+}
+
+// SchedulerProperties sets how often the occurrences of recurring rules
+// that are due get created. A zero interval disables the scheduler.
+type SchedulerProperties struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+type Properties struct {
+	Server     ServerProperties
+	Database   DatabaseProperties
+	DB         postgresconfig.PostgreSqlConnectionProperties
+	Sqlite     sqliteconfig.SqliteConnectionProperties
+	Migrations MigrationProperties
+	Trash      TrashProperties
+	Currency   CurrencyProperties
+	Scheduler  SchedulerProperties
+}
+
+func loadProperties() (*Properties, error) {
+	if err := configutil.LoadConfig(); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error loading configuration files... "), err)
+	}
+
+	props := &Properties{
+		Server: ServerProperties{
+			Port:            defaultPort,
+			ShutdownTimeout: defaultShutdownTimeout,
+		},
+		Database: DatabaseProperties{Driver: postgresDriver},
+		Trash: TrashProperties{
+			Retention:     defaultTrashRetention,
+			PurgeInterval: defaultPurgeInterval,
+		},
+		Currency:  CurrencyProperties{Reporting: defaultCurrency},
+		Scheduler: SchedulerProperties{Interval: defaultSchedulerInterval},
+	}
+	if err := configutil.BindProperties(serverPropertiesKey, &props.Server); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading server properties... "), err)
+	}
+	if err := configutil.BindProperties(databaseKey, &props.Database); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading database properties... "), err)
+	}
+	if err := configutil.BindProperties(dbPropertiesKey, &props.DB); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading database properties... "), err)
+	}
+	if err := configutil.BindProperties(sqlitePropertiesKey, &props.Sqlite); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading sqlite properties... "), err)
+	}
+	if err := configutil.BindProperties(migrationsKey, &props.Migrations); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading migrations properties... "), err)
+	}
+	if err := configutil.BindProperties(trashKey, &props.Trash); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading trash properties... "), err)
+	}
+	if err := configutil.BindProperties(currencyKey, &props.Currency); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading currency properties... "), err)
+	}
+	if err := configutil.BindProperties(recurringKey, &props.Scheduler); err != nil {
+		return nil, errors.Join(fmt.Errorf("error: error reading recurring scheduler properties... "), err)
+	}
+	props.Currency.Reporting = strings.ToUpper(props.Currency.Reporting)
+	if !model.SupportsCurrency(props.Currency.Reporting) {
+		return nil, fmt.Errorf("error: reporting currency %s is not supported, amounts must have %d decimal places",
+			props.Currency.Reporting, model.MoneyScale)
+	}
+
+	return props, nil
+}
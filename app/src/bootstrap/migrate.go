@@ -0,0 +1,113 @@
+package bootstrap
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/migrations"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	sqlitemigrations "github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/migrations"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	MigrateCommand = "migrate"
+
+	migrateUpAction      = "up"
+	migrateDownAction    = "down"
+	migrateVersionAction = "version"
+)
+
+// Migrate runs the migrate command. "up", the default, applies every pending
+// migration, "down [steps]" reverts the last steps migrations, one by
+// default, and "version" prints the current schema version.
+func Migrate(args []string) error {
+	props, err := loadProperties()
+	if err != nil {
+		return err
+	}
+
+	var db *sql.DB
+	switch props.Database.Driver {
+	case postgresDriver:
+		db = postgresconfig.CreateSqlConnection(props.DB)
+	case sqliteDriver:
+		db = sqliteconfig.CreateSqlConnection(props.Sqlite)
+	default:
+		return fmt.Errorf("error: the %s driver has no schema to migrate... ", props.Database.Driver)
+	}
+	defer db.Close()
+
+	migrator, err := newSchemaMigrator(props, db)
+	if err != nil {
+		return errors.Join(fmt.Errorf("error: error loading migrations... "), err)
+	}
+
+	action := migrateUpAction
+	if len(args) > 0 {
+		action = args[0]
+	}
+	switch action {
+	case migrateUpAction:
+		applied, err := migrator.Up()
+		log.Printf("info: %d migrations applied\n", applied)
+		return err
+	case migrateDownAction:
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("error: steps must be a positive integer, got %s... ", args[1])
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		log.Printf("info: %d migrations reverted\n", reverted)
+		return err
+	case migrateVersionAction:
+		version, err := migrator.Version()
+		if err != nil {
+			return err
+		}
+		log.Printf("info: schema version %d\n", version)
+		return nil
+	default:
+		return fmt.Errorf("error: unknown migrate action %s, expected up, down or version... ", action)
+	}
+}
+
+// schemaMigrator applies the migrations embedded by a database adapter.
+type schemaMigrator interface {
+	Up() (int, error)
+	Down(steps int) (int, error)
+	Version() (int, error)
+}
+
+func newSchemaMigrator(props *Properties, db *sql.DB) (schemaMigrator, error) {
+	if props.Database.Driver == sqliteDriver {
+		migrator, err := sqlitemigrations.NewMigrator(db, props.Currency.Reporting)
+		if err != nil {
+			return nil, err
+		}
+		return migrator, nil
+	}
+	migrator, err := migrations.NewMigrator(props.DB, db, props.Currency.Reporting)
+	if err != nil {
+		return nil, err
+	}
+	return migrator, nil
+}
+
+func migrateUp(props *Properties, db *sql.DB) error {
+	migrator, err := newSchemaMigrator(props, db)
+	if err != nil {
+		return errors.Join(fmt.Errorf("error: error loading migrations... "), err)
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		return errors.Join(fmt.Errorf("error: error migrating database schema... "), err)
+	}
+	log.Printf("info: %d migrations applied\n", applied)
+	return nil
+}
@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+// recurringScheduler creates the expenses and incomes of the recurring rule
+// occurrences that are due, once on start and then every interval, until
+// its context is done. Occurrences missed while the service was down are
+// created on start.
+type recurringScheduler struct {
+	recurring usecase.RecurringUseCase
+	interval  time.Duration
+}
+
+// start runs the scheduler in its own goroutine. The returned channel is
+// closed once it has stopped, so the repositories are not closed under it.
+func (s recurringScheduler) start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	if s.interval <= 0 {
+		log.Println("info: recurring transactions scheduler is disabled")
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.materialize(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+func (s recurringScheduler) materialize(ctx context.Context) {
+	created, err := s.recurring.Materialize(ctx, time.Now().UTC())
+	if err != nil {
+		log.Println("error: error creating recurring occurrences... ", err)
+	}
+	if created > 0 {
+		log.Printf("info: %d recurring occurrences created\n", created)
+	}
+}
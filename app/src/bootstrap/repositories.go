@@ -0,0 +1,125 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/memory-adapter/src/memory"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	postgresDriver = "postgres"
+	sqliteDriver   = "sqlite"
+	memoryDriver   = "memory"
+)
+
+// repositories holds the adapters of the configured driver and releases
+// whatever they hold open.
+type repositories struct {
+	expenses   port.ExpenseRepository
+	incomes    port.IncomeRepository
+	balance    port.BalanceRepository
+	categories port.CategoryRepository
+	budgets    port.BudgetRepository
+	audit      port.AuditRepository
+	imports    port.ImportedTransactionRepository
+	accounts   port.AccountRepository
+	transfers  port.TransferRepository
+	rates      port.ExchangeRateRepository
+	recurring  port.RecurringRuleRepository
+	// transactions runs calls on the repositories above atomically.
+	transactions port.UnitOfWork
+	close        func() error
+}
+
+func newRepositories(props *Properties) (*repositories, error) {
+	switch props.Database.Driver {
+	case postgresDriver:
+		return newPostgresRepositories(props)
+	case sqliteDriver:
+		return newSqliteRepositories(props)
+	case memoryDriver:
+		return newMemoryRepositories(), nil
+	default:
+		return nil, fmt.Errorf("error: unknown database driver %s, expected %s, %s or %s... ",
+			props.Database.Driver, postgresDriver, sqliteDriver, memoryDriver)
+	}
+}
+
+func newPostgresRepositories(props *Properties) (*repositories, error) {
+	db := postgresconfig.CreateSqlConnection(props.DB)
+	if props.Migrations.Auto {
+		if err := migrateUp(props, db); err != nil {
+			return nil, errors.Join(err, db.Close())
+		}
+	}
+
+	return &repositories{
+		expenses:     postgresql.NewExpensePostgresAdapter(props.DB, db),
+		incomes:      postgresql.NewIncomePostgresAdapter(props.DB, db),
+		balance:      postgresql.NewBalancePostgresAdapter(props.DB, db),
+		categories:   postgresql.NewCategoryPostgresAdapter(props.DB, db),
+		budgets:      postgresql.NewBudgetPostgresAdapter(props.DB, db),
+		audit:        postgresql.NewAuditPostgresAdapter(props.DB, db),
+		imports:      postgresql.NewImportedTransactionPostgresAdapter(props.DB, db),
+		accounts:     postgresql.NewAccountPostgresAdapter(props.DB, db),
+		transfers:    postgresql.NewTransferPostgresAdapter(props.DB, db),
+		rates:        postgresql.NewExchangeRatePostgresAdapter(props.DB, db),
+		recurring:    postgresql.NewRecurringRulePostgresAdapter(props.DB, db),
+		transactions: postgresql.NewPostgresUnitOfWork(props.DB, db),
+		close:        db.Close,
+	}, nil
+}
+
+// newSqliteRepositories keeps every record in a single database file, so a
+// self-hosted deployment needs nothing but the binary.
+func newSqliteRepositories(props *Properties) (*repositories, error) {
+	db := sqliteconfig.CreateSqlConnection(props.Sqlite)
+	if props.Migrations.Auto {
+		if err := migrateUp(props, db); err != nil {
+			return nil, errors.Join(err, db.Close())
+		}
+	}
+
+	return &repositories{
+		expenses:     sqlite.NewExpenseSqliteAdapter(props.Sqlite, db),
+		incomes:      sqlite.NewIncomeSqliteAdapter(props.Sqlite, db),
+		balance:      sqlite.NewBalanceSqliteAdapter(props.Sqlite, db),
+		categories:   sqlite.NewCategorySqliteAdapter(props.Sqlite, db),
+		budgets:      sqlite.NewBudgetSqliteAdapter(props.Sqlite, db),
+		audit:        sqlite.NewAuditSqliteAdapter(props.Sqlite, db),
+		imports:      sqlite.NewImportedTransactionSqliteAdapter(props.Sqlite, db),
+		accounts:     sqlite.NewAccountSqliteAdapter(props.Sqlite, db),
+		transfers:    sqlite.NewTransferSqliteAdapter(props.Sqlite, db),
+		rates:        sqlite.NewExchangeRateSqliteAdapter(props.Sqlite, db),
+		recurring:    sqlite.NewRecurringRuleSqliteAdapter(props.Sqlite, db),
+		transactions: sqlite.NewSqliteUnitOfWork(props.Sqlite, db),
+		close:        db.Close,
+	}, nil
+}
+
+// newMemoryRepositories keeps every record in process memory, so the
+// service runs without a database and starts empty on every run.
+func newMemoryRepositories() *repositories {
+	store := memory.NewStore()
+	return &repositories{
+		expenses:     memory.NewExpenseMemoryAdapter(store),
+		incomes:      memory.NewIncomeMemoryAdapter(store),
+		balance:      memory.NewBalanceMemoryAdapter(store),
+		categories:   memory.NewCategoryMemoryAdapter(store),
+		budgets:      memory.NewBudgetMemoryAdapter(store),
+		audit:        memory.NewAuditMemoryAdapter(store),
+		imports:      memory.NewImportedTransactionMemoryAdapter(store),
+		accounts:     memory.NewAccountMemoryAdapter(store),
+		transfers:    memory.NewTransferMemoryAdapter(store),
+		rates:        memory.NewExchangeRateMemoryAdapter(store),
+		recurring:    memory.NewRecurringRuleMemoryAdapter(store),
+		transactions: memory.NewMemoryUnitOfWork(store),
+		close:        func() error { return nil },
+	}
+}
@@ -0,0 +1,49 @@
+package bootstrap
+
+import (
+	"net/http"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/enaldo1709/budget-manager/infrastructure/entry-points/rest-api/src/restapi"
+	"github.com/gin-gonic/gin"
+)
+
+const apiBasePath = "/api/v1"
+
+type useCases struct {
+	expenses   usecase.ExpenseUseCase
+	incomes    usecase.IncomeUseCase
+	balance    usecase.BalanceUseCase
+	categories usecase.CategoryUseCase
+	budgets    usecase.BudgetUseCase
+	imports    usecase.ImportUseCase
+	accounts   usecase.AccountUseCase
+	transfers  usecase.TransferUseCase
+	rates      usecase.ExchangeRateUseCase
+	recurring  usecase.RecurringUseCase
+}
+
+func newRouter(uc useCases) *gin.Engine {
+	restapi.ConfigureValidator()
+	router := gin.Default()
+	router.Use(restapi.ErrorHandler(), restapi.ActorHandler())
+
+	router.GET("/health", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, model.Health{Status: "UP"})
+	})
+
+	api := router.Group(apiBasePath)
+	restapi.NewExpenseHandler(uc.expenses).Register(api)
+	restapi.NewIncomeHandler(uc.incomes).Register(api)
+	restapi.NewBalanceHandler(uc.balance).Register(api)
+	restapi.NewCategoryHandler(uc.categories).Register(api)
+	restapi.NewBudgetHandler(uc.budgets).Register(api)
+	restapi.NewImportHandler(uc.imports).Register(api)
+	restapi.NewAccountHandler(uc.accounts).Register(api)
+	restapi.NewTransferHandler(uc.transfers).Register(api)
+	restapi.NewExchangeRateHandler(uc.rates).Register(api)
+	restapi.NewRecurringHandler(uc.recurring).Register(api)
+
+	return router
+}
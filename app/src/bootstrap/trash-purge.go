@@ -0,0 +1,54 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+// trashPurger removes the expenses that have outlived the trash retention,
+// once on start and then every interval, until its context is done.
+type trashPurger struct {
+	expenses  usecase.ExpenseUseCase
+	retention time.Duration
+	interval  time.Duration
+}
+
+// start runs the purger in its own goroutine. The returned channel is closed
+// once it has stopped, so the repositories are not closed under it.
+func (p trashPurger) start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	if p.interval <= 0 {
+		log.Println("info: expenses trash purge is disabled")
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			p.purge(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+func (p trashPurger) purge(ctx context.Context) {
+	purged, err := p.expenses.PurgeDeleted(ctx, p.retention)
+	if err != nil {
+		log.Println("error: error purging the expenses trash... ", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("info: %d expenses purged from the trash\n", purged)
+	}
+}
@@ -1,4 +1,7 @@
 #!/bin/bash
+# Creates the application role, database and schema. Tables are created by
+# the versioned migrations embedded in the service, which run on startup or
+# through the migrate command.
 set -e
 export PGPASSWORD=$POSTGRES_PASSWORD;
 psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-EOSQL
@@ -9,16 +12,6 @@ CREATE USER $APP_DB_USER WITH PASSWORD '$APP_DB_PASS';
   
   \connect $APP_DB_NAME $POSTGRES_USER
   BEGIN;
-    CREATE SCHEMA $APP_DB_SCHEMA;
-    GRANT USAGE ON SCHEMA $APP_DB_SCHEMA TO $APP_DB_USER;
-
-    CREATE TABLE IF NOT EXISTS $APP_DB_SCHEMA.expenses (
-	    id SERIAL PRIMARY KEY NOT NULL,
-	    amount FLOAT NOT NULL,
-      created TIMESTAMP NOT NULL
-    );
-
-    GRANT SELECT , INSERT , UPDATE , DELETE ON TABLE $APP_DB_SCHEMA.expenses TO $APP_DB_USER;
-    GRANT USAGE , SELECT ON SEQUENCE $APP_DB_SCHEMA.expenses_id_seq to $APP_DB_USER;
+    CREATE SCHEMA $APP_DB_SCHEMA AUTHORIZATION $APP_DB_USER;
   COMMIT;
 EOSQL
\ No newline at end of file
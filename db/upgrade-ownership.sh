@@ -0,0 +1,23 @@
+#!/bin/bash
+# Hands the schema, tables and sequences created by init.sh before the
+# embedded migrations over to the application role, so the migrator can
+# create schema_migrations and alter them. Run it once, as the database
+# owner, on a database created by an earlier version of init.sh.
+set -e
+export PGPASSWORD=$POSTGRES_PASSWORD;
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$APP_DB_NAME" <<-EOSQL
+
+  BEGIN;
+    ALTER SCHEMA $APP_DB_SCHEMA OWNER TO $APP_DB_USER;
+    DO \$\$
+    DECLARE
+      t RECORD;
+    BEGIN
+      -- Sequences owned by a serial column follow their table.
+      FOR t IN SELECT tablename FROM pg_tables WHERE schemaname = '$APP_DB_SCHEMA' LOOP
+        EXECUTE format('ALTER TABLE %I.%I OWNER TO %I', '$APP_DB_SCHEMA', t.tablename, '$APP_DB_USER');
+      END LOOP;
+    END
+    \$\$;
+  COMMIT;
+EOSQL
@@ -0,0 +1,38 @@
+package model
+
+// AccountType tells what kind of wallet an account is.
+type AccountType string
+
+const (
+	AccountCash AccountType = "cash"
+	AccountBank AccountType = "bank"
+	AccountCard AccountType = "card"
+)
+
+// Account is a wallet money is spent from or paid into, like a checking
+// account or a credit card. Expenses and incomes with an AccountId belong to
+// it.
+type Account struct {
+	Id   int         `json:"id" validate:"integer"`
+	Name string      `json:"name" validate:"required"`
+	Type AccountType `json:"type" validate:"required"`
+	// Currency is the ISO 4217 code of the account, e.g. USD.
+	Currency       string `json:"currency" validate:"required"`
+	OpeningBalance Money  `json:"openingBalance"`
+	// Closed accounts keep their history but take no new expenses or
+	// incomes.
+	Closed bool `json:"closed"`
+}
+
+// AccountBalance is what an account holds: its opening balance plus the
+// incomes and transfers in, minus the expenses and transfers out.
+type AccountBalance struct {
+	AccountId      int    `json:"accountId"`
+	Currency       string `json:"currency"`
+	OpeningBalance Money  `json:"openingBalance"`
+	TotalIncome    Money  `json:"totalIncome"`
+	TotalExpenses  Money  `json:"totalExpenses"`
+	TransfersIn    Money  `json:"transfersIn"`
+	TransfersOut   Money  `json:"transfersOut"`
+	Balance        Money  `json:"balance"`
+}
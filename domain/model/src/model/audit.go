@@ -0,0 +1,51 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction is the kind of change an audit entry records.
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+// UnknownActor is recorded for changes made by a request that didn't say
+// who made it.
+const UnknownActor = "anonymous"
+
+// AuditEntry records one change to a financial record: who made it, when,
+// and JSON snapshots of the record before and after it. Before is empty for
+// a creation and After for a deletion.
+type AuditEntry struct {
+	Id       int             `json:"id"`
+	Entity   string          `json:"entity"`
+	EntityId int             `json:"entityId"`
+	Action   AuditAction     `json:"action"`
+	Actor    string          `json:"actor"`
+	Before   json.RawMessage `json:"before,omitempty"`
+	After    json.RawMessage `json:"after,omitempty"`
+	Recorded time.Time       `json:"recorded"`
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that carries the actor of the changes made
+// with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, or UnknownActor.
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return UnknownActor
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// Balance summarises incomes and expenses for the days between From and To,
+// both inclusive, in the reporting Currency.
+type Balance struct {
+	From           time.Time      `json:"from"`
+	To             time.Time      `json:"to"`
+	Currency       string         `json:"currency,omitempty"`
+	OpeningBalance Money          `json:"openingBalance"`
+	TotalIncome    Money          `json:"totalIncome"`
+	TotalExpenses  Money          `json:"totalExpenses"`
+	ClosingBalance Money          `json:"closingBalance"`
+	Daily          []DailyBalance `json:"daily"`
+}
+
+type DailyBalance struct {
+	Date     time.Time `json:"date"`
+	Income   Money     `json:"income"`
+	Expenses Money     `json:"expenses"`
+	Balance  Money     `json:"balance"`
+}
+
+// BalanceTotals holds the aggregated amounts of a period.
+type BalanceTotals struct {
+	Income   Money
+	Expenses Money
+}
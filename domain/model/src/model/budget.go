@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// BudgetPeriodLayout is the layout of Budget.Period. Budgets are monthly, so
+// a period such as "2023-04" covers every expense created in April 2023.
+const BudgetPeriodLayout = "2006-01"
+
+// Budget caps how much can be spent in a category, including its
+// subcategories, during one period.
+type Budget struct {
+	Id         int    `json:"id" validate:"integer"`
+	CategoryId int    `json:"categoryId" validate:"required,integer"`
+	Period     string `json:"period" validate:"required"`
+	Limit      Money  `json:"limit" validate:"required,number"`
+}
+
+// BudgetStatus is the state of a budget computed from the expenses of its
+// period. Remaining is negative once the limit is exceeded.
+type BudgetStatus struct {
+	Budget
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Spent      Money     `json:"spent"`
+	Remaining  Money     `json:"remaining"`
+	Percentage float64   `json:"percentage"`
+}
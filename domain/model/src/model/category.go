@@ -0,0 +1,9 @@
+package model
+
+// Category classifies expenses. A category with a ParentId is a
+// subcategory of it (groceries → food); zero means a top-level category.
+type Category struct {
+	Id       int    `json:"id" validate:"integer"`
+	Name     string `json:"name" validate:"required"`
+	ParentId int    `json:"parentId,omitempty" validate:"integer"`
+}
@@ -0,0 +1,17 @@
+package errors
+
+import "fmt"
+
+// ConcurrentModificationError reports a write based on a version of an item
+// that another request has changed since it was read.
+type ConcurrentModificationError struct {
+	message string
+}
+
+func NewConcurrentModificationError(item string) error {
+	return &ConcurrentModificationError{message: fmt.Sprintf("%s was modified by another request", item)}
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return e.message
+}
@@ -7,15 +7,21 @@ import (
 
 type InvalidItemError struct {
 	message string
+	details []string
 }
 
 func NewInvalidItemError(item string, details ...string) error {
 	return &InvalidItemError{
 		message: strings.
 			Join(append([]string{fmt.Sprintf("%s is invalid", item)}, details...), ","),
+		details: details,
 	}
 }
 
 func (e *InvalidItemError) Error() string {
 	return e.message
 }
+
+func (e *InvalidItemError) Details() []string {
+	return e.details
+}
@@ -7,7 +7,7 @@ type UpdateItemError struct {
 }
 
 func NewUpdateItemError(item string) error {
-	return &SaveItemError{message: fmt.Sprintf("error updating %s", item)}
+	return &UpdateItemError{message: fmt.Sprintf("error updating %s", item)}
 }
 
 func (e *UpdateItemError) Error() string {
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// ExchangeRate is the price of one unit of Base in Quote, as published on
+// Date; both are ISO 4217 codes. A rate stays in effect until a later one is
+// published.
+type ExchangeRate struct {
+	Date  time.Time `json:"date"`
+	Base  string    `json:"base"`
+	Quote string    `json:"quote"`
+	Rate  Rate      `json:"rate"`
+}
+
+// ExchangeRateImport counts the rates stored by an import.
+type ExchangeRateImport struct {
+	Imported int `json:"imported"`
+}
+
+// CurrencyTotals holds the incomes and expenses of one day in one currency.
+// An empty Currency stands for the reporting currency.
+type CurrencyTotals struct {
+	Date     time.Time
+	Currency string
+	Income   Money
+	Expenses Money
+}
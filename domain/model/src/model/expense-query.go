@@ -0,0 +1,90 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+type ExpenseSortField string
+
+const (
+	SortByCreated ExpenseSortField = "created"
+	SortByAmount  ExpenseSortField = "amount"
+	SortById      ExpenseSortField = "id"
+)
+
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// ExpenseQuery filters, sorts and pages the expenses returned by FindAll.
+// Zero values mean "no filter"; Created is matched in the half-open range
+// [From, To). Results are always sorted by SortBy and then by Id, so pages
+// are stable even when several expenses share the sorted value.
+type ExpenseQuery struct {
+	From       time.Time
+	To         time.Time
+	MinAmount  *Money
+	MaxAmount  *Money
+	CategoryId int
+	AccountId  int
+	SortBy     ExpenseSortField
+	Direction  SortDirection
+	Limit      int
+	// After continues a listing right after the expense the cursor points to.
+	After *ExpenseCursor
+}
+
+// ExpenseCursor identifies the last expense of a page by its sorted value
+// and id.
+type ExpenseCursor struct {
+	SortBy ExpenseSortField `json:"s"`
+	Value  string           `json:"v"`
+	Id     int              `json:"i"`
+}
+
+// ExpensePage is one page of a listing. NextCursor is empty on the last page.
+type ExpensePage struct {
+	Items      []Expense `json:"items"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+// NewExpenseCursor returns the cursor that continues a listing sorted by
+// field right after e.
+func NewExpenseCursor(e Expense, field ExpenseSortField) ExpenseCursor {
+	cursor := ExpenseCursor{SortBy: field, Id: e.Id}
+	switch field {
+	case SortByCreated:
+		cursor.Value = e.Created.UTC().Format(time.RFC3339)
+	case SortByAmount:
+		cursor.Value = e.Amount.String()
+	default:
+		cursor.Value = strconv.Itoa(e.Id)
+	}
+	return cursor
+}
+
+// Encode returns the opaque form of the cursor handed to clients.
+func (c ExpenseCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeExpenseCursor parses a cursor returned by Encode.
+func DecodeExpenseCursor(encoded string) (*ExpenseCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("cursor is not valid")
+	}
+	cursor := &ExpenseCursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil || cursor.Id <= 0 {
+		return nil, errors.New("cursor is not valid")
+	}
+	return cursor, nil
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpenseCursor(t *testing.T) {
+	expense := Expense{Id: 7, Amount: 2530, Created: time.Date(2023, 4, 15, 10, 30, 0, 0, time.UTC)}
+	tests := []struct {
+		name  string
+		field ExpenseSortField
+		want  ExpenseCursor
+	}{
+		{
+			name:  "given a listing sorted by created, then keep the creation time",
+			field: SortByCreated,
+			want:  ExpenseCursor{SortBy: SortByCreated, Value: "2023-04-15T10:30:00Z", Id: 7},
+		},
+		{
+			name:  "given a listing sorted by amount, then keep the exact amount",
+			field: SortByAmount,
+			want:  ExpenseCursor{SortBy: SortByAmount, Value: "25.30", Id: 7},
+		},
+		{
+			name:  "given a listing sorted by id, then keep the id",
+			field: SortById,
+			want:  ExpenseCursor{SortBy: SortById, Value: "7", Id: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := NewExpenseCursor(expense, tt.field)
+			if !reflect.DeepEqual(cursor, tt.want) {
+				t.Errorf("NewExpenseCursor() = %v, want %v", cursor, tt.want)
+			}
+			got, err := DecodeExpenseCursor(cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeExpenseCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("DecodeExpenseCursor() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeExpenseCursorInvalid(t *testing.T) {
+	for _, encoded := range []string{"not a cursor", "bm90IGpzb24", "eyJpIjowfQ"} {
+		if _, err := DecodeExpenseCursor(encoded); err == nil {
+			t.Errorf("DecodeExpenseCursor(%q) error = nil, want error", encoded)
+		}
+	}
+}
@@ -5,7 +5,32 @@ import (
 )
 
 type Expense struct {
-	Id      int       `json:"id" validate:"integer"`
-	Amount  float64   `json:"amount" validate:"required,number"`
-	Created time.Time `json:"created" validate:"required"`
+	Id     int   `json:"id" validate:"integer"`
+	Amount Money `json:"amount" validate:"required,number"`
+	// Currency is the ISO 4217 code of Amount. Sent empty, it's the one of
+	// the account, or else the reporting one on a create and the stored one
+	// on an update.
+	Currency string    `json:"currency,omitempty"`
+	Created  time.Time `json:"created" validate:"required"`
+	// CategoryId is left empty on split expenses, whose lines carry the
+	// categories instead.
+	CategoryId int `json:"categoryId,omitempty" validate:"integer"`
+	AccountId  int `json:"accountId,omitempty" validate:"integer"`
+	// Splits break Amount down across categories, like a supermarket receipt
+	// covering groceries and household; their amounts add up to Amount.
+	Splits []ExpenseSplit `json:"splits,omitempty" validate:"dive"`
+	// Version counts the changes made to the expense, starting at 1 when it
+	// is saved. An update must carry the version it was based on and fails
+	// if the expense has changed since.
+	Version int `json:"version,omitempty" validate:"integer"`
+	// Deleted is set while the expense is in the trash.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// ExpenseSplit is the part of an expense spent in one category. A zero
+// CategoryId leaves the part uncategorized.
+type ExpenseSplit struct {
+	CategoryId int    `json:"categoryId,omitempty" validate:"integer"`
+	Amount     Money  `json:"amount" validate:"required,number"`
+	Note       string `json:"note,omitempty"`
 }
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"time"
+)
+
+// TransactionKind tells whether an imported bank transaction became an
+// expense or an income.
+type TransactionKind string
+
+const (
+	KindExpense TransactionKind = "expense"
+	KindIncome  TransactionKind = "income"
+)
+
+// SignConvention tells how the sign of a single amount column separates
+// expenses from incomes.
+type SignConvention string
+
+const (
+	// NegativeExpenses reads negative amounts as expenses, like most bank
+	// account statements.
+	NegativeExpenses SignConvention = "negative-expenses"
+	// PositiveExpenses reads positive amounts as expenses, like most credit
+	// card statements.
+	PositiveExpenses SignConvention = "positive-expenses"
+)
+
+// CSVMapping describes the layout of a bank CSV export. Columns are named by
+// the header row. A file holds either a signed AmountColumn or separate
+// DebitColumn and CreditColumn, and optionally a CurrencyColumn with the ISO
+// 4217 code of each amount.
+type CSVMapping struct {
+	Delimiter rune
+	// DateFormat spells the dates of DateColumn with the YYYY, MM and DD
+	// placeholders, e.g. DD/MM/YYYY.
+	DateColumn       string
+	DateFormat       string
+	DecimalSeparator string
+	Sign             SignConvention
+	AmountColumn     string
+	DebitColumn      string
+	CreditColumn     string
+	CurrencyColumn   string
+}
+
+// ImportTarget files the records of an import: under an account, zero for
+// none, and the expenses under a category, zero for none.
+type ImportTarget struct {
+	AccountId  int
+	CategoryId int
+}
+
+// ImportedTransaction is one bank transaction of an import and the record it
+// maps to. Amount is always positive; Kind carries the direction.
+type ImportedTransaction struct {
+	// Line is the line of a CSV row, or the position of an OFX transaction
+	// in its statement file.
+	Line   int             `json:"line"`
+	Kind   TransactionKind `json:"kind"`
+	Amount Money           `json:"amount"`
+	// Currency is the ISO 4217 code of Amount when the file tells it, like
+	// the CURDEF of an OFX statement; otherwise the record takes the one of
+	// its account, or the reporting currency without an account.
+	Currency string    `json:"currency,omitempty"`
+	Created  time.Time `json:"created"`
+	// Account and ExternalId identify a transaction the bank gave an id,
+	// like the FITID of OFX files, so importing it again can be detected.
+	Account    string `json:"account,omitempty"`
+	ExternalId string `json:"externalId,omitempty"`
+	// Duplicate is set when the transaction had already been imported and
+	// was skipped.
+	Duplicate bool `json:"duplicate,omitempty"`
+	// Id is the expense or income created for the transaction, unset in a
+	// dry run and for duplicates.
+	Id int `json:"id,omitempty"`
+}
+
+// ImportResult reports what an import created, or would create in a dry
+// run. Expenses and Incomes count the created records, and Skipped the
+// duplicates left out.
+type ImportResult struct {
+	DryRun       bool                  `json:"dryRun"`
+	Created      int                   `json:"created"`
+	Skipped      int                   `json:"skipped"`
+	Expenses     int                   `json:"expenses"`
+	Incomes      int                   `json:"incomes"`
+	Transactions []ImportedTransaction `json:"transactions"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+type Income struct {
+	Id     int   `json:"id" validate:"integer"`
+	Amount Money `json:"amount" validate:"required,number"`
+	// Currency is the ISO 4217 code of Amount. Sent empty, it's the one of
+	// the account, or else the reporting one on a create and the stored one
+	// on an update.
+	Currency string    `json:"currency,omitempty"`
+	Created  time.Time `json:"created" validate:"required"`
+	// AccountId is the account the income was paid into, zero for none.
+	AccountId int `json:"accountId,omitempty" validate:"integer"`
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MoneyScale is the number of decimal places kept for every amount.
+	MoneyScale = 2
+
+	minorUnitsPerMajor = 100
+)
+
+// otherScaleCurrencies are the ISO 4217 currencies whose minor unit isn't a
+// hundredth of the major one, like JPY with none or KWD with three decimal
+// places. Their amounts don't fit MoneyScale, so they aren't supported.
+var otherScaleCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "ISK": true, "JPY": true,
+	"KMF": true, "KRW": true, "PYG": true, "RWF": true, "UGX": true, "UYI": true,
+	"VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+	"BHD": true, "IQD": true, "JOD": true, "KWD": true, "LYD": true, "OMR": true,
+	"TND": true, "CLF": true, "UYW": true,
+}
+
+// SupportsCurrency tells whether amounts in the currency with the ISO 4217
+// code can be kept as Money, which holds MoneyScale decimal places.
+func SupportsCurrency(code string) bool {
+	return !otherScaleCurrencies[code]
+}
+
+// Money is an exact monetary amount expressed in minor units (cents), so
+// sums never drift the way binary floating point does. It is written to JSON
+// as a plain decimal number such as 25.30.
+type Money int64
+
+// ParseMoney reads a decimal amount like "25.3", "-1200" or "0.05". Amounts
+// with more than MoneyScale decimal places are rejected instead of rounded.
+func ParseMoney(value string) (Money, error) {
+	units, err := parseFixed(value, MoneyScale, "money amount")
+	return Money(units), err
+}
+
+// parseFixed reads a decimal number as an integer count of its scale-th
+// decimal places. What is named in the errors.
+func parseFixed(value string, scale int, what string) (int64, error) {
+	s := strings.TrimSpace(value)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if (whole == "" && fraction == "") || (hasFraction && fraction == "") {
+		return 0, fmt.Errorf("invalid %s %q", what, value)
+	}
+	if len(fraction) > scale {
+		return 0, fmt.Errorf("%s %q has more than %d decimal places", what, value, scale)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	fraction += strings.Repeat("0", scale-len(fraction))
+
+	if !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid %s %q", what, value)
+	}
+	units, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", what, value, err)
+	}
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// String formats the amount with exactly MoneyScale decimal places.
+func (m Money) String() string {
+	units := int64(m)
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, units/minorUnitsPerMajor, MoneyScale, units%minorUnitsPerMajor)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount either as a JSON number or as a string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	parsed, err := ParseMoney(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
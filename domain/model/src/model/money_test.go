@@ -0,0 +1,88 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "given an integer amount, then get its minor units", value: "1200", want: 120000},
+		{name: "given one decimal place, then pad the cents", value: "25.3", want: 2530},
+		{name: "given two decimal places, then keep them", value: "0.05", want: 5},
+		{name: "given a negative amount, then keep the sign", value: "-10.50", want: -1050},
+		{name: "given an amount without whole part, then parse it", value: ".75", want: 75},
+		{name: "given too many decimal places, then get error", value: "1.005", wantErr: true},
+		{name: "given an exponent, then get error", value: "1e2", wantErr: true},
+		{name: "given an empty amount, then get error", value: "", wantErr: true},
+		{name: "given a trailing dot, then get error", value: "3.", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Money
+		json  string
+	}{
+		{name: "given a number, then keep it exact", input: "0.1", want: 10, json: "0.10"},
+		{name: "given a string, then parse it", input: `"-7.25"`, want: -725, json: "-7.25"},
+		{name: "given a large amount, then keep every cent", input: "90071992547409.93",
+			want: 9007199254740993, json: "90071992547409.93"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("json.Unmarshal() = %d, want %d", got, tt.want)
+			}
+			out, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(out) != tt.json {
+				t.Errorf("json.Marshal() = %s, want %s", out, tt.json)
+			}
+		})
+	}
+}
+
+func TestSupportsCurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "given a currency with cents, then support it", code: "USD", want: true},
+		{name: "given a currency without minor unit, then reject it", code: "JPY", want: false},
+		{name: "given a currency with three decimal places, then reject it", code: "KWD", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SupportsCurrency(tt.code); got != tt.want {
+				t.Errorf("SupportsCurrency(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
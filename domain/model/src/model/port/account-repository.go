@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// AccountRepository stores accounts. Implementations must stop working on a
+// call as soon as its ctx is done.
+type AccountRepository interface {
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.Account, error)
+	// FindAll returns every account, closed ones included, ordered by id.
+	FindAll(ctx context.Context) ([]model.Account, error)
+	Save(context.Context, *model.Account) (*model.Account, error)
+	Update(context.Context, *model.Account) (*model.Account, error)
+	// Delete removes the account; its expenses and incomes are kept without
+	// an account.
+	Delete(ctx context.Context, id int) error
+	// Totals aggregates the incomes and the expenses of the account, leaving
+	// out the expenses in the trash.
+	Totals(ctx context.Context, id int) (*model.BalanceTotals, error)
+	// HasRecords tells whether any expense, trashed or not, income, transfer
+	// or recurring rule belongs to the account.
+	HasRecords(ctx context.Context, id int) (bool, error)
+}
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// AuditRepository stores the audit trail. Entries are only ever appended.
+type AuditRepository interface {
+	// Record stores the entry with a new id.
+	Record(ctx context.Context, entry *model.AuditEntry) error
+	// FindByEntity returns the entries of one record, the oldest first.
+	FindByEntity(ctx context.Context, entity string, id int) ([]model.AuditEntry, error)
+}
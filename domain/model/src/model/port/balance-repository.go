@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// BalanceRepository aggregates incomes and expenses per day and currency,
+// so each group can be converted with the exchange rate of its day.
+type BalanceRepository interface {
+	// TotalsBefore aggregates every income and expense created before date.
+	// Those in currency need no conversion and are summed into a single row,
+	// dated on the latest of their days.
+	TotalsBefore(ctx context.Context, date time.Time, currency string) ([]model.CurrencyTotals, error)
+	// DailyTotals aggregates incomes and expenses created in [from, to).
+	// Days without movements are omitted.
+	DailyTotals(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error)
+}
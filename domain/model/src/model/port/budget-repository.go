@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type BudgetRepository interface {
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.Budget, error)
+	FindAll(ctx context.Context) ([]model.Budget, error)
+	// FindByPeriod returns the budgets of period, formatted with
+	// model.BudgetPeriodLayout.
+	FindByPeriod(ctx context.Context, period string) ([]model.Budget, error)
+	Save(context.Context, *model.Budget) (*model.Budget, error)
+	Update(context.Context, *model.Budget) (*model.Budget, error)
+	Delete(ctx context.Context, id int) error
+	// Spent sums the expenses created in [from, to) in the category and
+	// every one of its subcategories, per day and currency. Split expenses
+	// count the amount of their lines in those categories.
+	Spent(ctx context.Context, categoryId int, from, to time.Time) ([]model.CurrencyTotals, error)
+}
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type CategoryRepository interface {
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.Category, error)
+	FindAll(ctx context.Context) ([]model.Category, error)
+	Save(context.Context, *model.Category) (*model.Category, error)
+	Update(context.Context, *model.Category) (*model.Category, error)
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// ExchangeRateRepository stores exchange rates. Implementations must stop
+// working on a call as soon as its ctx is done.
+type ExchangeRateRepository interface {
+	// Save stores the rates, replacing any with the same date, base and
+	// quote.
+	Save(ctx context.Context, rates []model.ExchangeRate) error
+	// FindEffective returns the rate of base in quote in effect on date: the
+	// latest one published on or before that day. It fails with
+	// ItemNotFound when there is none.
+	FindEffective(ctx context.Context, base, quote string, date time.Time) (*model.ExchangeRate, error)
+	// FindInEffect returns the rates of base in quote in effect some day
+	// from from to to, oldest first: the one in effect on from, if any, and
+	// every later one published up to to.
+	FindInEffect(ctx context.Context, base, quote string, from, to time.Time) ([]model.ExchangeRate, error)
+}
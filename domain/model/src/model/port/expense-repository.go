@@ -1,14 +1,39 @@
 package port
 
 import (
+	"context"
+	"time"
+
 	"github.com/enaldo1709/budget-manager/domain/model/src/model"
 )
 
+// ExpenseRepository stores expenses. Implementations must stop working on a
+// call as soon as its ctx is done. Expenses in the trash are left out of
+// every method except FindDeleted, Restore and Purge. The splits of an
+// expense are stored and found along with it, in their order.
 type ExpenseRepository interface {
-	Exists(id int) (bool, error)
-	FindByID(id int) (*model.Expense, error)
-	FindAll() ([]model.Expense, error)
-	Save(*model.Expense) (*model.Expense, error)
-	Update(*model.Expense) (*model.Expense, error)
-	Delete(id int) error
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.Expense, error)
+	// FindAll returns up to query.Limit expenses matching query, in its
+	// sort order. A query.CategoryId also matches the split expenses with a
+	// line in the category.
+	FindAll(ctx context.Context, query model.ExpenseQuery) ([]model.Expense, error)
+	// Save stores the expense with a new id and Version 1.
+	Save(context.Context, *model.Expense) (*model.Expense, error)
+	// Update stores the expense and increases its Version, only if the
+	// stored Version is still the one given; otherwise it fails with a
+	// ConcurrentModificationError. The splits given replace the stored ones.
+	Update(context.Context, *model.Expense) (*model.Expense, error)
+	// Delete moves the expense to the trash, stamping it with the deletion
+	// time.
+	Delete(ctx context.Context, id int) error
+	// FindDeleted returns the expenses in the trash, the most recently
+	// deleted first.
+	FindDeleted(ctx context.Context) ([]model.Expense, error)
+	// Restore takes the expense out of the trash, or fails with an
+	// ItemNotFound error if it is not there.
+	Restore(ctx context.Context, id int) error
+	// Purge removes for good the expenses deleted before the given time and
+	// returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// ImportedTransactionRepository remembers the bank transactions already
+// imported, by the account and id the bank gave them.
+type ImportedTransactionRepository interface {
+	Exists(ctx context.Context, account, externalId string) (bool, error)
+	// Save links the transaction to the record created for it. Saving an
+	// account and id pair twice fails.
+	Save(ctx context.Context, t *model.ImportedTransaction) error
+}
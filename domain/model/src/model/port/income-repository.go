@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type IncomeRepository interface {
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.Income, error)
+	FindAll(ctx context.Context) ([]model.Income, error)
+	Save(context.Context, *model.Income) (*model.Income, error)
+	Update(context.Context, *model.Income) (*model.Income, error)
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type AccountRepositoryMock struct {
+	ExistsFn   func(context.Context, int) (bool, error)
+	FindByIDFn func(context.Context, int) (*model.Account, error)
+	FindAllFn  func(context.Context) ([]model.Account, error)
+	SaveFn     func(context.Context, *model.Account) (*model.Account, error)
+	UpdateFn   func(context.Context, *model.Account) (*model.Account, error)
+	DeleteFn   func(context.Context, int) error
+	TotalsFn   func(context.Context, int) (*model.BalanceTotals, error)
+	// HasRecordsFn defaults to an account without records.
+	HasRecordsFn func(context.Context, int) (bool, error)
+}
+
+func (m *AccountRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *AccountRepositoryMock) FindByID(ctx context.Context, id int) (*model.Account, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *AccountRepositoryMock) FindAll(ctx context.Context) ([]model.Account, error) {
+	return m.FindAllFn(ctx)
+}
+
+func (m *AccountRepositoryMock) Save(ctx context.Context, a *model.Account) (*model.Account, error) {
+	return m.SaveFn(ctx, a)
+}
+
+func (m *AccountRepositoryMock) Update(ctx context.Context, a *model.Account) (*model.Account, error) {
+	return m.UpdateFn(ctx, a)
+}
+
+func (m *AccountRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
+}
+
+func (m *AccountRepositoryMock) Totals(ctx context.Context, id int) (*model.BalanceTotals, error) {
+	return m.TotalsFn(ctx, id)
+}
+
+func (m *AccountRepositoryMock) HasRecords(ctx context.Context, id int) (bool, error) {
+	if m.HasRecordsFn == nil {
+		return false, nil
+	}
+	return m.HasRecordsFn(ctx, id)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type AuditRepositoryMock struct {
+	RecordFn       func(context.Context, *model.AuditEntry) error
+	FindByEntityFn func(context.Context, string, int) ([]model.AuditEntry, error)
+}
+
+func (m *AuditRepositoryMock) Record(ctx context.Context, entry *model.AuditEntry) error {
+	return m.RecordFn(ctx, entry)
+}
+
+func (m *AuditRepositoryMock) FindByEntity(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+	return m.FindByEntityFn(ctx, entity, id)
+}
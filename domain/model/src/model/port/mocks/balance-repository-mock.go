@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type BalanceRepositoryMock struct {
+	TotalsBeforeFn func(context.Context, time.Time, string) ([]model.CurrencyTotals, error)
+	DailyTotalsFn  func(context.Context, time.Time, time.Time) ([]model.CurrencyTotals, error)
+}
+
+func (m *BalanceRepositoryMock) TotalsBefore(ctx context.Context, date time.Time, currency string) ([]model.CurrencyTotals, error) {
+	return m.TotalsBeforeFn(ctx, date, currency)
+}
+
+func (m *BalanceRepositoryMock) DailyTotals(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error) {
+	return m.DailyTotalsFn(ctx, from, to)
+}
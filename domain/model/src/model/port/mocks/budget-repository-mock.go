@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type BudgetRepositoryMock struct {
+	ExistsFn       func(context.Context, int) (bool, error)
+	FindByIDFn     func(context.Context, int) (*model.Budget, error)
+	FindAllFn      func(context.Context) ([]model.Budget, error)
+	FindByPeriodFn func(context.Context, string) ([]model.Budget, error)
+	SaveFn         func(context.Context, *model.Budget) (*model.Budget, error)
+	UpdateFn       func(context.Context, *model.Budget) (*model.Budget, error)
+	DeleteFn       func(context.Context, int) error
+	SpentFn        func(context.Context, int, time.Time, time.Time) ([]model.CurrencyTotals, error)
+}
+
+func (m *BudgetRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *BudgetRepositoryMock) FindByID(ctx context.Context, id int) (*model.Budget, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *BudgetRepositoryMock) FindAll(ctx context.Context) ([]model.Budget, error) {
+	return m.FindAllFn(ctx)
+}
+
+func (m *BudgetRepositoryMock) FindByPeriod(ctx context.Context, period string) ([]model.Budget, error) {
+	return m.FindByPeriodFn(ctx, period)
+}
+
+func (m *BudgetRepositoryMock) Save(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	return m.SaveFn(ctx, b)
+}
+
+func (m *BudgetRepositoryMock) Update(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	return m.UpdateFn(ctx, b)
+}
+
+func (m *BudgetRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
+}
+
+func (m *BudgetRepositoryMock) Spent(ctx context.Context, categoryId int, from, to time.Time) ([]model.CurrencyTotals, error) {
+	return m.SpentFn(ctx, categoryId, from, to)
+}
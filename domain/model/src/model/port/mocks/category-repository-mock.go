@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type CategoryRepositoryMock struct {
+	ExistsFn   func(context.Context, int) (bool, error)
+	FindByIDFn func(context.Context, int) (*model.Category, error)
+	FindAllFn  func(context.Context) ([]model.Category, error)
+	SaveFn     func(context.Context, *model.Category) (*model.Category, error)
+	UpdateFn   func(context.Context, *model.Category) (*model.Category, error)
+	DeleteFn   func(context.Context, int) error
+}
+
+func (m *CategoryRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *CategoryRepositoryMock) FindByID(ctx context.Context, id int) (*model.Category, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *CategoryRepositoryMock) FindAll(ctx context.Context) ([]model.Category, error) {
+	return m.FindAllFn(ctx)
+}
+
+func (m *CategoryRepositoryMock) Save(ctx context.Context, e *model.Category) (*model.Category, error) {
+	return m.SaveFn(ctx, e)
+}
+
+func (m *CategoryRepositoryMock) Update(ctx context.Context, e *model.Category) (*model.Category, error) {
+	return m.UpdateFn(ctx, e)
+}
+
+func (m *CategoryRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type ExchangeRateRepositoryMock struct {
+	SaveFn          func(context.Context, []model.ExchangeRate) error
+	FindEffectiveFn func(context.Context, string, string, time.Time) (*model.ExchangeRate, error)
+	FindInEffectFn  func(context.Context, string, string, time.Time, time.Time) ([]model.ExchangeRate, error)
+}
+
+func (m *ExchangeRateRepositoryMock) Save(ctx context.Context, rates []model.ExchangeRate) error {
+	return m.SaveFn(ctx, rates)
+}
+
+func (m *ExchangeRateRepositoryMock) FindEffective(ctx context.Context, base, quote string,
+	date time.Time) (*model.ExchangeRate, error) {
+	return m.FindEffectiveFn(ctx, base, quote, date)
+}
+
+func (m *ExchangeRateRepositoryMock) FindInEffect(ctx context.Context, base, quote string,
+	from, to time.Time) ([]model.ExchangeRate, error) {
+	return m.FindInEffectFn(ctx, base, quote, from, to)
+}
@@ -1,36 +1,56 @@
 package mocks
 
-import "github.com/enaldo1709/budget-manager/domain/model/src/model"
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
 
 type ExpenseRepositoryMock struct {
-	ExistsFn   func(int) (bool, error)
-	FindByIDFn func(int) (*model.Expense, error)
-	FindAllFn  func() ([]model.Expense, error)
-	SaveFn     func(*model.Expense) (*model.Expense, error)
-	UpdateFn   func(*model.Expense) (*model.Expense, error)
-	DeleteFn   func(int) error
+	ExistsFn      func(context.Context, int) (bool, error)
+	FindByIDFn    func(context.Context, int) (*model.Expense, error)
+	FindAllFn     func(context.Context, model.ExpenseQuery) ([]model.Expense, error)
+	SaveFn        func(context.Context, *model.Expense) (*model.Expense, error)
+	UpdateFn      func(context.Context, *model.Expense) (*model.Expense, error)
+	DeleteFn      func(context.Context, int) error
+	FindDeletedFn func(context.Context) ([]model.Expense, error)
+	RestoreFn     func(context.Context, int) error
+	PurgeFn       func(context.Context, time.Time) (int, error)
+}
+
+func (m *ExpenseRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *ExpenseRepositoryMock) FindByID(ctx context.Context, id int) (*model.Expense, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *ExpenseRepositoryMock) FindAll(ctx context.Context, query model.ExpenseQuery) ([]model.Expense, error) {
+	return m.FindAllFn(ctx, query)
 }
 
-func (m *ExpenseRepositoryMock) Exists(id int) (bool, error) {
-	return m.ExistsFn(id)
+func (m *ExpenseRepositoryMock) Save(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	return m.SaveFn(ctx, e)
 }
 
-func (m *ExpenseRepositoryMock) FindByID(id int) (*model.Expense, error) {
-	return m.FindByIDFn(id)
+func (m *ExpenseRepositoryMock) Update(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	return m.UpdateFn(ctx, e)
 }
 
-func (m *ExpenseRepositoryMock) FindAll() ([]model.Expense, error) {
-	return m.FindAllFn()
+func (m *ExpenseRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
 }
 
-func (m *ExpenseRepositoryMock) Save(e *model.Expense) (*model.Expense, error) {
-	return m.SaveFn(e)
+func (m *ExpenseRepositoryMock) FindDeleted(ctx context.Context) ([]model.Expense, error) {
+	return m.FindDeletedFn(ctx)
 }
 
-func (m *ExpenseRepositoryMock) Update(e *model.Expense) (*model.Expense, error) {
-	return m.UpdateFn(e)
+func (m *ExpenseRepositoryMock) Restore(ctx context.Context, id int) error {
+	return m.RestoreFn(ctx, id)
 }
 
-func (m *ExpenseRepositoryMock) Delete(id int) error {
-	return m.DeleteFn(id)
+func (m *ExpenseRepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	return m.PurgeFn(ctx, before)
 }
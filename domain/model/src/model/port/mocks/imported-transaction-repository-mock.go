@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type ImportedTransactionRepositoryMock struct {
+	ExistsFn func(context.Context, string, string) (bool, error)
+	SaveFn   func(context.Context, *model.ImportedTransaction) error
+}
+
+func (m *ImportedTransactionRepositoryMock) Exists(ctx context.Context, account, externalId string) (bool, error) {
+	return m.ExistsFn(ctx, account, externalId)
+}
+
+func (m *ImportedTransactionRepositoryMock) Save(ctx context.Context, t *model.ImportedTransaction) error {
+	return m.SaveFn(ctx, t)
+}
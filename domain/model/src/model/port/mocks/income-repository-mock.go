@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type IncomeRepositoryMock struct {
+	ExistsFn   func(context.Context, int) (bool, error)
+	FindByIDFn func(context.Context, int) (*model.Income, error)
+	FindAllFn  func(context.Context) ([]model.Income, error)
+	SaveFn     func(context.Context, *model.Income) (*model.Income, error)
+	UpdateFn   func(context.Context, *model.Income) (*model.Income, error)
+	DeleteFn   func(context.Context, int) error
+}
+
+func (m *IncomeRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *IncomeRepositoryMock) FindByID(ctx context.Context, id int) (*model.Income, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *IncomeRepositoryMock) FindAll(ctx context.Context) ([]model.Income, error) {
+	return m.FindAllFn(ctx)
+}
+
+func (m *IncomeRepositoryMock) Save(ctx context.Context, e *model.Income) (*model.Income, error) {
+	return m.SaveFn(ctx, e)
+}
+
+func (m *IncomeRepositoryMock) Update(ctx context.Context, e *model.Income) (*model.Income, error) {
+	return m.UpdateFn(ctx, e)
+}
+
+func (m *IncomeRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type RecurringRuleRepositoryMock struct {
+	ExistsFn          func(context.Context, int) (bool, error)
+	FindByIDFn        func(context.Context, int) (*model.RecurringRule, error)
+	FindAllFn         func(context.Context) ([]model.RecurringRule, error)
+	SaveFn            func(context.Context, *model.RecurringRule) (*model.RecurringRule, error)
+	UpdateFn          func(context.Context, *model.RecurringRule) (*model.RecurringRule, error)
+	DeleteFn          func(context.Context, int) error
+	FindOccurrencesFn func(context.Context, int, time.Time, time.Time) ([]model.RecurringOccurrence, error)
+	SaveOccurrenceFn  func(context.Context, *model.RecurringOccurrence) (bool, error)
+	LastCreatedFn     func(context.Context, int) (time.Time, error)
+}
+
+func (m *RecurringRuleRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *RecurringRuleRepositoryMock) FindByID(ctx context.Context, id int) (*model.RecurringRule, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *RecurringRuleRepositoryMock) FindAll(ctx context.Context) ([]model.RecurringRule, error) {
+	return m.FindAllFn(ctx)
+}
+
+func (m *RecurringRuleRepositoryMock) Save(ctx context.Context, r *model.RecurringRule) (*model.RecurringRule, error) {
+	return m.SaveFn(ctx, r)
+}
+
+func (m *RecurringRuleRepositoryMock) Update(ctx context.Context, r *model.RecurringRule) (*model.RecurringRule, error) {
+	return m.UpdateFn(ctx, r)
+}
+
+func (m *RecurringRuleRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
+}
+
+func (m *RecurringRuleRepositoryMock) FindOccurrences(ctx context.Context, ruleId int,
+	from, to time.Time) ([]model.RecurringOccurrence, error) {
+	return m.FindOccurrencesFn(ctx, ruleId, from, to)
+}
+
+func (m *RecurringRuleRepositoryMock) SaveOccurrence(ctx context.Context, o *model.RecurringOccurrence) (bool, error) {
+	return m.SaveOccurrenceFn(ctx, o)
+}
+
+func (m *RecurringRuleRepositoryMock) LastCreated(ctx context.Context, ruleId int) (time.Time, error) {
+	return m.LastCreatedFn(ctx, ruleId)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+type TransferRepositoryMock struct {
+	ExistsFn   func(context.Context, int) (bool, error)
+	FindByIDFn func(context.Context, int) (*model.Transfer, error)
+	FindAllFn  func(context.Context, int) ([]model.Transfer, error)
+	SaveFn     func(context.Context, *model.Transfer) (*model.Transfer, error)
+	DeleteFn   func(context.Context, int) error
+	TotalsFn   func(context.Context, int) (*model.TransferTotals, error)
+}
+
+func (m *TransferRepositoryMock) Exists(ctx context.Context, id int) (bool, error) {
+	return m.ExistsFn(ctx, id)
+}
+
+func (m *TransferRepositoryMock) FindByID(ctx context.Context, id int) (*model.Transfer, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *TransferRepositoryMock) FindAll(ctx context.Context, accountId int) ([]model.Transfer, error) {
+	return m.FindAllFn(ctx, accountId)
+}
+
+func (m *TransferRepositoryMock) Save(ctx context.Context, t *model.Transfer) (*model.Transfer, error) {
+	return m.SaveFn(ctx, t)
+}
+
+func (m *TransferRepositoryMock) Delete(ctx context.Context, id int) error {
+	return m.DeleteFn(ctx, id)
+}
+
+func (m *TransferRepositoryMock) Totals(ctx context.Context, accountId int) (*model.TransferTotals, error) {
+	return m.TotalsFn(ctx, accountId)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// UnitOfWorkMock runs every function with Repositories. RunFn, when set,
+// replaces that behaviour, e.g. to fail the commit.
+type UnitOfWorkMock struct {
+	Repositories port.Repositories
+	RunFn        func(context.Context, func(port.Repositories) error) error
+}
+
+func (m *UnitOfWorkMock) Run(ctx context.Context, fn func(port.Repositories) error) error {
+	if m.RunFn != nil {
+		return m.RunFn(ctx, fn)
+	}
+	return fn(m.Repositories)
+}
@@ -0,0 +1,151 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// TestAccountRepository checks the behaviour of an AccountRepository.
+// newRepository must return an empty repository on every call; each subtest
+// asks for its own.
+func TestAccountRepository(t *testing.T, newRepository func(t *testing.T) port.AccountRepository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.AccountRepository)
+	}{
+		{name: "given an account, when saved, then it gets a new id and can be found", test: testAccountSaveAndFind},
+		{name: "given a missing id, then find returns item not found", test: testAccountNotFound},
+		{name: "given a saved account, when updated, then find returns the changes", test: testAccountUpdate},
+		{name: "given a saved account, when deleted, then it no longer exists", test: testAccountDelete},
+		{name: "given an account without movements, then its totals are zero", test: testAccountEmptyTotals},
+		{name: "given a cancelled context, then every call fails", test: testAccountCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testAccountSaveAndFind(t *testing.T, r port.AccountRepository) {
+	ctx := context.Background()
+	checking := saveAccount(t, r, "Checking", model.AccountBank, 150000)
+	card := saveAccount(t, r, "Visa", model.AccountCard, -2530)
+	if checking.Id <= 0 || card.Id <= 0 || checking.Id == card.Id {
+		t.Fatalf("Save() ids = %d and %d, want distinct positive ids", checking.Id, card.Id)
+	}
+
+	got, err := r.FindByID(ctx, card.Id)
+	if err != nil || *got != card {
+		t.Errorf("FindByID() = %+v, %v, want %+v", got, err, card)
+	}
+	exists, err := r.Exists(ctx, checking.Id)
+	if err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+	all, err := r.FindAll(ctx)
+	if err != nil || len(all) != 2 || all[0] != checking || all[1] != card {
+		t.Errorf("FindAll() = %+v, %v, want %+v", all, err, []model.Account{checking, card})
+	}
+}
+
+func testAccountNotFound(t *testing.T, r port.AccountRepository) {
+	ctx := context.Background()
+	saved := saveAccount(t, r, "Wallet", model.AccountCash, 0)
+	missing := saved.Id + 1000
+
+	_, err := r.FindByID(ctx, missing)
+	var notFound *customErrors.ItemNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindByID() of a missing id error = %v, want ItemNotFound", err)
+	}
+	exists, err := r.Exists(ctx, missing)
+	if err != nil || exists {
+		t.Errorf("Exists() of a missing id = %v, %v, want false", exists, err)
+	}
+}
+
+func testAccountUpdate(t *testing.T, r port.AccountRepository) {
+	ctx := context.Background()
+	saved := saveAccount(t, r, "Checking", model.AccountBank, 0)
+
+	changed := saved
+	changed.Name, changed.OpeningBalance, changed.Closed = "Old checking", 1000, true
+	if _, err := r.Update(ctx, &changed); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil || *got != changed {
+		t.Errorf("FindByID() after update = %+v, %v, want %+v", got, err, changed)
+	}
+
+	missing := changed
+	missing.Id += 1000
+	if _, err := r.Update(ctx, &missing); err == nil {
+		t.Errorf("Update() of a missing id error = nil, want error")
+	}
+}
+
+func testAccountDelete(t *testing.T, r port.AccountRepository) {
+	ctx := context.Background()
+	saved := saveAccount(t, r, "Wallet", model.AccountCash, 0)
+
+	if err := r.Delete(ctx, saved.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	exists, err := r.Exists(ctx, saved.Id)
+	if err != nil || exists {
+		t.Errorf("Exists() after delete = %v, %v, want false", exists, err)
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("Delete() of a deleted account error = nil, want error")
+	}
+}
+
+func testAccountEmptyTotals(t *testing.T, r port.AccountRepository) {
+	saved := saveAccount(t, r, "Wallet", model.AccountCash, 5000)
+
+	got, err := r.Totals(context.Background(), saved.Id)
+	if err != nil || *got != (model.BalanceTotals{}) {
+		t.Errorf("Totals() = %+v, %v, want zero totals", got, err)
+	}
+}
+
+func testAccountCancelledContext(t *testing.T, r port.AccountRepository) {
+	saved := saveAccount(t, r, "Wallet", model.AccountCash, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := r.Save(ctx, &model.Account{Name: "Visa", Type: model.AccountCard, Currency: "USD"}); err == nil {
+		t.Errorf("Save() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindAll(ctx); err == nil {
+		t.Errorf("FindAll() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.Exists(ctx, saved.Id); err == nil {
+		t.Errorf("Exists() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.Totals(ctx, saved.Id); err == nil {
+		t.Errorf("Totals() with a cancelled context error = nil, want error")
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("Delete() with a cancelled context error = nil, want error")
+	}
+}
+
+func saveAccount(t *testing.T, r port.AccountRepository, name string, kind model.AccountType,
+	openingBalance model.Money) model.Account {
+	t.Helper()
+	a, err := r.Save(context.Background(), &model.Account{
+		Name: name, Type: kind, Currency: "USD", OpeningBalance: openingBalance,
+	})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return *a
+}
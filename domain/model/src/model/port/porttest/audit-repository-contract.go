@@ -0,0 +1,134 @@
+package porttest
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// TestAuditRepository checks the behaviour of an AuditRepository.
+// newRepository must return an empty repository on every call; each subtest
+// asks for its own.
+func TestAuditRepository(t *testing.T, newRepository func(t *testing.T) port.AuditRepository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.AuditRepository)
+	}{
+		{name: "given recorded entries, then find returns those of the record, oldest first", test: testRecordAndFind},
+		{name: "given a record without entries, then find returns none", test: testNoEntries},
+		{name: "given a cancelled context, then every call fails", test: testAuditCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testRecordAndFind(t *testing.T, r port.AuditRepository) {
+	created := model.AuditEntry{
+		Entity: "expense", EntityId: 1, Action: model.AuditCreate, Actor: "ana",
+		After:    json.RawMessage(`{"id":1,"amount":10.00}`),
+		Recorded: contractDate,
+	}
+	updated := model.AuditEntry{
+		Entity: "expense", EntityId: 1, Action: model.AuditUpdate, Actor: "luis",
+		Before:   json.RawMessage(`{"id":1,"amount":10.00}`),
+		After:    json.RawMessage(`{"id":1,"amount":25.50}`),
+		Recorded: contractDate.Add(90 * time.Minute),
+	}
+	record(t, r, &created)
+	record(t, r, &model.AuditEntry{
+		Entity: "expense", EntityId: 2, Action: model.AuditCreate, Actor: "ana",
+		After: json.RawMessage(`{"id":2}`), Recorded: contractDate,
+	})
+	record(t, r, &model.AuditEntry{
+		Entity: "income", EntityId: 1, Action: model.AuditCreate, Actor: "ana",
+		After: json.RawMessage(`{"id":1}`), Recorded: contractDate,
+	})
+	record(t, r, &updated)
+	if created.Id <= 0 || updated.Id <= created.Id {
+		t.Errorf("Record() ids = %d and %d, want increasing positive ids", created.Id, updated.Id)
+	}
+
+	got, err := r.FindByEntity(context.Background(), "expense", 1)
+	if err != nil {
+		t.Fatalf("FindByEntity() error = %v", err)
+	}
+	want := []model.AuditEntry{created, updated}
+	if len(got) != len(want) {
+		t.Fatalf("FindByEntity() returned %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		assertAuditEntry(t, got[i], want[i])
+	}
+}
+
+func testNoEntries(t *testing.T, r port.AuditRepository) {
+	record(t, r, &model.AuditEntry{
+		Entity: "expense", EntityId: 1, Action: model.AuditCreate, Actor: "ana",
+		After: json.RawMessage(`{"id":1}`), Recorded: contractDate,
+	})
+
+	got, err := r.FindByEntity(context.Background(), "expense", 2)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("FindByEntity() = %v, %v, want an empty list", got, err)
+	}
+}
+
+func testAuditCancelledContext(t *testing.T, r port.AuditRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	entry := &model.AuditEntry{
+		Entity: "expense", EntityId: 1, Action: model.AuditCreate, Actor: "ana",
+		After: json.RawMessage(`{"id":1}`), Recorded: contractDate,
+	}
+	if err := r.Record(ctx, entry); err == nil {
+		t.Errorf("Record() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindByEntity(ctx, "expense", 1); err == nil {
+		t.Errorf("FindByEntity() with a cancelled context error = nil, want error")
+	}
+}
+
+func record(t *testing.T, r port.AuditRepository, entry *model.AuditEntry) {
+	t.Helper()
+	if err := r.Record(context.Background(), entry); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+}
+
+// assertAuditEntry compares snapshots as JSON values, since a database may
+// store them in a normalized form.
+func assertAuditEntry(t *testing.T, got, want model.AuditEntry) {
+	t.Helper()
+	if got.Id != want.Id || got.Entity != want.Entity || got.EntityId != want.EntityId ||
+		got.Action != want.Action || got.Actor != want.Actor || !got.Recorded.Equal(want.Recorded) ||
+		!sameJSON(got.Before, want.Before) || !sameJSON(got.After, want.After) {
+		t.Errorf("FindByEntity() entry = %+v, want %+v", describeEntry(got), describeEntry(want))
+	}
+}
+
+func sameJSON(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func describeEntry(e model.AuditEntry) map[string]any {
+	return map[string]any{
+		"id": e.Id, "entity": e.Entity, "entityId": e.EntityId, "action": e.Action, "actor": e.Actor,
+		"before": string(e.Before), "after": string(e.After), "recorded": e.Recorded,
+	}
+}
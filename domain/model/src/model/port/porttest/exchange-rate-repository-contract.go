@@ -0,0 +1,143 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+var rateDate = time.Date(2023, time.April, 14, 0, 0, 0, 0, time.UTC)
+
+// TestExchangeRateRepository checks the behaviour of an
+// ExchangeRateRepository. newRepository must return an empty repository on
+// every call; each subtest asks for its own.
+func TestExchangeRateRepository(t *testing.T, newRepository func(t *testing.T) port.ExchangeRateRepository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.ExchangeRateRepository)
+	}{
+		{name: "given rates, then find returns the one published that day", test: testRateSameDay},
+		{name: "given a day without rates, then find returns the latest one before it", test: testRateEarlierDay},
+		{name: "given a day before every rate, then find returns item not found", test: testRateNotFound},
+		{name: "given a saved rate, when saved again, then it is replaced", test: testRateReplace},
+		{name: "given a period, then find in effect returns its rates oldest first", test: testRatesInEffect},
+		{name: "given a cancelled context, then every call fails", test: testRateCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testRateSameDay(t *testing.T, r port.ExchangeRateRepository) {
+	saveRates(t, r,
+		model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000},
+		model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "GBP", Rate: 88380000},
+		model.ExchangeRate{Date: rateDate.AddDate(0, 0, -1), Base: "EUR", Quote: "USD", Rate: 110460000},
+	)
+
+	assertRate(t, r, "EUR", "USD", rateDate, model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000})
+	assertRate(t, r, "EUR", "GBP", rateDate.Add(15*time.Hour),
+		model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "GBP", Rate: 88380000})
+}
+
+func testRateEarlierDay(t *testing.T, r port.ExchangeRateRepository) {
+	friday := model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000}
+	saveRates(t, r,
+		model.ExchangeRate{Date: rateDate.AddDate(0, 0, -1), Base: "EUR", Quote: "USD", Rate: 110460000},
+		friday,
+		model.ExchangeRate{Date: rateDate.AddDate(0, 0, 3), Base: "EUR", Quote: "USD", Rate: 109280000},
+	)
+
+	assertRate(t, r, "EUR", "USD", rateDate.AddDate(0, 0, 2), friday)
+}
+
+func testRateNotFound(t *testing.T, r port.ExchangeRateRepository) {
+	saveRates(t, r, model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000})
+
+	var notFound *customErrors.ItemNotFound
+	_, err := r.FindEffective(context.Background(), "EUR", "USD", rateDate.AddDate(0, 0, -1))
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindEffective() before every rate error = %v, want ItemNotFound", err)
+	}
+	_, err = r.FindEffective(context.Background(), "USD", "EUR", rateDate)
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindEffective() of the inverse pair error = %v, want ItemNotFound", err)
+	}
+}
+
+func testRateReplace(t *testing.T, r port.ExchangeRateRepository) {
+	saveRates(t, r, model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000})
+	corrected := model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109750000}
+	saveRates(t, r, corrected)
+
+	assertRate(t, r, "EUR", "USD", rateDate, corrected)
+}
+
+func testRatesInEffect(t *testing.T, r port.ExchangeRateRepository) {
+	earlier := model.ExchangeRate{Date: rateDate.AddDate(0, 0, -7), Base: "EUR", Quote: "USD", Rate: 110460000}
+	effective := model.ExchangeRate{Date: rateDate.AddDate(0, 0, -3), Base: "EUR", Quote: "USD", Rate: 109280000}
+	within := model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000}
+	later := model.ExchangeRate{Date: rateDate.AddDate(0, 0, 3), Base: "EUR", Quote: "USD", Rate: 109750000}
+	saveRates(t, r, later, within, earlier, effective,
+		model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "GBP", Rate: 88380000})
+
+	got, err := r.FindInEffect(context.Background(), "EUR", "USD", rateDate.AddDate(0, 0, -1),
+		rateDate.AddDate(0, 0, 1).Add(12*time.Hour))
+	want := []model.ExchangeRate{effective, within}
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("FindInEffect() = %+v, %v, want %+v", got, err, want)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Base != want[i].Base || got[i].Quote != want[i].Quote ||
+			got[i].Rate != want[i].Rate {
+			t.Errorf("FindInEffect()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	got, err = r.FindInEffect(context.Background(), "USD", "EUR", rateDate, rateDate)
+	if err != nil || len(got) != 0 {
+		t.Errorf("FindInEffect() of a pair without rates = %+v, %v, want none", got, err)
+	}
+}
+
+func testRateCancelledContext(t *testing.T, r port.ExchangeRateRepository) {
+	saveRates(t, r, model.ExchangeRate{Date: rateDate, Base: "EUR", Quote: "USD", Rate: 109810000})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rates := []model.ExchangeRate{{Date: rateDate, Base: "EUR", Quote: "GBP", Rate: 88380000}}
+	if err := r.Save(ctx, rates); err == nil {
+		t.Errorf("Save() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindEffective(ctx, "EUR", "USD", rateDate); err == nil {
+		t.Errorf("FindEffective() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindInEffect(ctx, "EUR", "USD", rateDate, rateDate); err == nil {
+		t.Errorf("FindInEffect() with a cancelled context error = nil, want error")
+	}
+}
+
+func saveRates(t *testing.T, r port.ExchangeRateRepository, rates ...model.ExchangeRate) {
+	t.Helper()
+	if err := r.Save(context.Background(), rates); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+}
+
+func assertRate(t *testing.T, r port.ExchangeRateRepository, base, quote string, date time.Time,
+	want model.ExchangeRate) {
+	t.Helper()
+	got, err := r.FindEffective(context.Background(), base, quote, date)
+	if err != nil || !got.Date.Equal(want.Date) || got.Base != want.Base || got.Quote != want.Quote ||
+		got.Rate != want.Rate {
+		t.Errorf("FindEffective(%s, %s, %s) = %+v, %v, want %+v", base, quote, date.Format(time.DateOnly),
+			got, err, want)
+	}
+}
@@ -0,0 +1,497 @@
+// Package porttest holds the behaviour every implementation of a port must
+// share, written as tests any adapter can run against itself.
+package porttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// contractDate is whole seconds in UTC, the precision every implementation
+// must keep.
+var contractDate = time.Date(2023, time.October, 1, 10, 30, 0, 0, time.UTC)
+
+// TestExpenseRepository checks the behaviour of an ExpenseRepository.
+// newRepository must return an empty repository on every call; each subtest
+// asks for its own.
+func TestExpenseRepository(t *testing.T, newRepository func(t *testing.T) port.ExpenseRepository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.ExpenseRepository)
+	}{
+		{name: "given an expense, when saved, then it gets a new id and can be found", test: testSaveAndFind},
+		{name: "given a missing id, then find returns item not found", test: testNotFound},
+		{name: "given a saved expense, when updated, then find returns the changes", test: testUpdate},
+		{name: "given a stale version, then update fails with concurrent modification", test: testStaleUpdate},
+		{name: "given a saved expense, when deleted, then it moves to the trash", test: testDelete},
+		{name: "given a deleted expense, when restored, then it can be found again", test: testRestore},
+		{name: "given deleted expenses, when purged, then only the older ones are removed", test: testPurge},
+		{name: "given a created date, then it round-trips to the second", test: testTimestampRoundTrip},
+		{name: "given split lines, then they are kept in order through every change", test: testSplits},
+		{name: "given a query, then find all filters the expenses", test: testFilters},
+		{name: "given a sort, then find all orders by it and then by id", test: testOrdering},
+		{name: "given a cursor, then find all pages through every expense once", test: testPagination},
+		{name: "given concurrent writers, then every expense is stored with a distinct id", test: testConcurrentSaves},
+		{name: "given a cancelled context, then every call fails", test: testCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testSaveAndFind(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	first := save(t, r, 2530, contractDate)
+	second := save(t, r, 1000, contractDate)
+	if first.Id <= 0 || second.Id <= 0 || first.Id == second.Id {
+		t.Fatalf("Save() ids = %d and %d, want distinct positive ids", first.Id, second.Id)
+	}
+	if first.Version != 1 {
+		t.Errorf("Save() version = %d, want 1", first.Version)
+	}
+
+	got, err := r.FindByID(ctx, first.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID()", got, first)
+
+	exists, err := r.Exists(ctx, first.Id)
+	if err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+}
+
+func testNotFound(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	saved := save(t, r, 1000, contractDate)
+	missing := saved.Id + 1000
+
+	_, err := r.FindByID(ctx, missing)
+	var notFound *customErrors.ItemNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindByID() error = %v, want ItemNotFound", err)
+	}
+	exists, err := r.Exists(ctx, missing)
+	if err != nil || exists {
+		t.Errorf("Exists() = %v, %v, want false", exists, err)
+	}
+	if _, err := r.Update(ctx, &model.Expense{Id: missing, Amount: 1000, Currency: "USD", Created: contractDate, Version: 1}); err == nil {
+		t.Errorf("Update() of a missing expense error = nil, want error")
+	}
+	if err := r.Delete(ctx, missing); err == nil {
+		t.Errorf("Delete() of a missing expense error = nil, want error")
+	}
+}
+
+func testUpdate(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	saved := save(t, r, 1000, contractDate)
+	other := save(t, r, 3000, contractDate)
+
+	changed := model.Expense{Id: saved.Id, Amount: 4550, Currency: "USD", Created: contractDate.AddDate(0, 0, 1), Version: saved.Version}
+	updated, err := r.Update(ctx, &changed)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Version != saved.Version+1 {
+		t.Errorf("Update() version = %d, want %d", updated.Version, saved.Version+1)
+	}
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID() after Update()", got, changed)
+
+	got, err = r.FindByID(ctx, other.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID() of an untouched expense", got, other)
+}
+
+func testStaleUpdate(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	saved := save(t, r, 1000, contractDate)
+
+	first := saved
+	first.Amount = 2000
+	if _, err := r.Update(ctx, &first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	second := saved
+	second.Amount = 3000
+	_, err := r.Update(ctx, &second)
+	var concurrent *customErrors.ConcurrentModificationError
+	if !errors.As(err, &concurrent) {
+		t.Errorf("Update() with a stale version error = %v, want ConcurrentModificationError", err)
+	}
+
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID() after a stale Update()", got, first)
+}
+
+func testDelete(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	saved := save(t, r, 1000, contractDate)
+	if err := r.Delete(ctx, saved.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	exists, err := r.Exists(ctx, saved.Id)
+	if err != nil || exists {
+		t.Errorf("Exists() after Delete() = %v, %v, want false", exists, err)
+	}
+	var notFound *customErrors.ItemNotFound
+	if _, err := r.FindByID(ctx, saved.Id); !errors.As(err, &notFound) {
+		t.Errorf("FindByID() after Delete() error = %v, want ItemNotFound", err)
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("second Delete() error = nil, want error")
+	}
+	if got := findAll(t, r, model.ExpenseQuery{}); len(got) != 0 {
+		t.Errorf("FindAll() after Delete() = %v, want no expenses", ids(got))
+	}
+	if _, err := r.Update(ctx, &saved); err == nil {
+		t.Errorf("Update() after Delete() error = nil, want error")
+	}
+
+	trash, err := r.FindDeleted(ctx)
+	if err != nil {
+		t.Fatalf("FindDeleted() error = %v", err)
+	}
+	if len(trash) != 1 || trash[0].Id != saved.Id || trash[0].Deleted == nil {
+		t.Fatalf("FindDeleted() = %v, want expense %d with a deletion time", trash, saved.Id)
+	}
+	if since := time.Since(*trash[0].Deleted); since < -time.Minute || since > time.Minute {
+		t.Errorf("FindDeleted() deleted = %v, want about now", trash[0].Deleted)
+	}
+}
+
+func testRestore(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	saved := save(t, r, 1000, contractDate)
+
+	var notFound *customErrors.ItemNotFound
+	if err := r.Restore(ctx, saved.Id); !errors.As(err, &notFound) {
+		t.Errorf("Restore() of an expense out of the trash error = %v, want ItemNotFound", err)
+	}
+	if err := r.Delete(ctx, saved.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := r.Restore(ctx, saved.Id); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() after Restore() error = %v", err)
+	}
+	assertExpense(t, "FindByID() after Restore()", got, saved)
+	if got.Deleted != nil {
+		t.Errorf("FindByID() after Restore() deleted = %v, want nil", got.Deleted)
+	}
+	if trash, err := r.FindDeleted(ctx); err != nil || len(trash) != 0 {
+		t.Errorf("FindDeleted() after Restore() = %v, %v, want an empty trash", trash, err)
+	}
+}
+
+func testPurge(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	deleted := save(t, r, 1000, contractDate)
+	kept := save(t, r, 2000, contractDate)
+	if err := r.Delete(ctx, deleted.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if purged, err := r.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || purged != 0 {
+		t.Errorf("Purge() of an hour ago = %d, %v, want 0", purged, err)
+	}
+	if purged, err := r.Purge(ctx, time.Now().Add(time.Minute)); err != nil || purged != 1 {
+		t.Errorf("Purge() of now = %d, %v, want 1", purged, err)
+	}
+
+	if trash, err := r.FindDeleted(ctx); err != nil || len(trash) != 0 {
+		t.Errorf("FindDeleted() after Purge() = %v, %v, want an empty trash", trash, err)
+	}
+	if err := r.Restore(ctx, deleted.Id); err == nil {
+		t.Errorf("Restore() of a purged expense error = nil, want error")
+	}
+	got, err := r.FindByID(ctx, kept.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID() of an expense out of the trash", got, kept)
+}
+
+func testTimestampRoundTrip(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	created := time.Date(2023, time.December, 31, 23, 59, 58, 750_000_000, time.UTC)
+	saved := save(t, r, 1000, created)
+
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if want := created.Truncate(time.Second); !got.Created.Equal(want) {
+		t.Errorf("FindByID() created = %v, want %v", got.Created, want)
+	}
+}
+
+func testSplits(t *testing.T, r port.ExpenseRepository) {
+	ctx := context.Background()
+	split, err := r.Save(ctx, &model.Expense{Amount: 5000, Currency: "USD", Created: contractDate, Splits: []model.ExpenseSplit{
+		{Amount: 3500, Note: "groceries"},
+		{Amount: 1500, Note: "pharmacy"},
+	}})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	saved := *split
+	plain := save(t, r, 1000, contractDate)
+
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID()", got, saved)
+	got.Splits[0].Amount = 1
+	got, _ = r.FindByID(ctx, saved.Id)
+	assertExpense(t, "FindByID() after changing a found expense", got, saved)
+
+	changed := model.Expense{Id: saved.Id, Amount: 5000, Currency: "USD", Created: contractDate, Version: saved.Version,
+		Splits: []model.ExpenseSplit{{Amount: 1000}, {Amount: 2500, Note: "household"}, {Amount: 1500}}}
+	updated, err := r.Update(ctx, &changed)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	all := findAll(t, r, model.ExpenseQuery{SortBy: model.SortById, Direction: model.SortAscending})
+	if len(all) != 2 {
+		t.Fatalf("FindAll() = %v, want 2 expenses", ids(all))
+	}
+	assertExpense(t, "FindAll() after Update()", &all[0], *updated)
+	assertExpense(t, "FindAll() of an expense without splits", &all[1], plain)
+
+	if err := r.Delete(ctx, saved.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	deleted, err := r.FindDeleted(ctx)
+	if err != nil || len(deleted) != 1 {
+		t.Fatalf("FindDeleted() = %v, %v, want the split expense", ids(deleted), err)
+	}
+	assertExpense(t, "FindDeleted()", &deleted[0], *updated)
+	if err := r.Restore(ctx, saved.Id); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	unsplit := *updated
+	unsplit.Splits = nil
+	if _, err := r.Update(ctx, &unsplit); err != nil {
+		t.Fatalf("Update() without splits error = %v", err)
+	}
+	got, err = r.FindByID(ctx, saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID() after removing the splits", got, unsplit)
+}
+
+func testFilters(t *testing.T, r port.ExpenseRepository) {
+	e1 := save(t, r, 1000, contractDate)
+	e2 := save(t, r, 2000, contractDate.AddDate(0, 0, 1))
+	e3 := save(t, r, 3000, contractDate.AddDate(0, 0, 2))
+	save(t, r, 4000, contractDate.AddDate(0, 0, 3))
+
+	min, max := model.Money(1500), model.Money(3500)
+	tests := []struct {
+		name  string
+		query model.ExpenseQuery
+		want  []int
+	}{
+		{
+			name:  "date range",
+			query: model.ExpenseQuery{From: contractDate, To: contractDate.AddDate(0, 0, 2)},
+			want:  []int{e1.Id, e2.Id},
+		},
+		{
+			name:  "amount bounds",
+			query: model.ExpenseQuery{MinAmount: &min, MaxAmount: &max},
+			want:  []int{e2.Id, e3.Id},
+		},
+		{
+			name:  "limit",
+			query: model.ExpenseQuery{Limit: 1},
+			want:  []int{e1.Id},
+		},
+	}
+	for _, tt := range tests {
+		tt.query.SortBy, tt.query.Direction = model.SortByCreated, model.SortAscending
+		got := findAll(t, r, tt.query)
+		if !slices.Equal(ids(got), tt.want) {
+			t.Errorf("FindAll() with %s = %v, want %v", tt.name, ids(got), tt.want)
+		}
+	}
+}
+
+func testOrdering(t *testing.T, r port.ExpenseRepository) {
+	e1 := save(t, r, 2000, contractDate.AddDate(0, 0, 1))
+	e2 := save(t, r, 1000, contractDate)
+	e3 := save(t, r, 2000, contractDate.AddDate(0, 0, 2))
+	e4 := save(t, r, 3000, contractDate)
+
+	tests := []struct {
+		sortBy    model.ExpenseSortField
+		direction model.SortDirection
+		want      []int
+	}{
+		{model.SortByCreated, model.SortDescending, []int{e3.Id, e1.Id, e4.Id, e2.Id}},
+		{model.SortByCreated, model.SortAscending, []int{e2.Id, e4.Id, e1.Id, e3.Id}},
+		{model.SortByAmount, model.SortDescending, []int{e4.Id, e3.Id, e1.Id, e2.Id}},
+		{model.SortByAmount, model.SortAscending, []int{e2.Id, e1.Id, e3.Id, e4.Id}},
+		{model.SortById, model.SortAscending, []int{e1.Id, e2.Id, e3.Id, e4.Id}},
+		{model.SortById, model.SortDescending, []int{e4.Id, e3.Id, e2.Id, e1.Id}},
+	}
+	for _, tt := range tests {
+		got := findAll(t, r, model.ExpenseQuery{SortBy: tt.sortBy, Direction: tt.direction})
+		if !slices.Equal(ids(got), tt.want) {
+			t.Errorf("FindAll() sorted by %s %s = %v, want %v", tt.sortBy, tt.direction, ids(got), tt.want)
+		}
+	}
+}
+
+func testPagination(t *testing.T, r port.ExpenseRepository) {
+	want := []int{}
+	for i := 0; i < 7; i++ {
+		// Repeated amounts make the id tie-breaker decide the page limits.
+		want = append(want, save(t, r, model.Money(1000*(i%3)+1000), contractDate).Id)
+	}
+
+	for _, sortBy := range []model.ExpenseSortField{model.SortByCreated, model.SortByAmount, model.SortById} {
+		query := model.ExpenseQuery{SortBy: sortBy, Direction: model.SortDescending, Limit: 3}
+		seen := []int{}
+		for pages := 0; pages < len(want); pages++ {
+			page := findAll(t, r, query)
+			seen = append(seen, ids(page)...)
+			if len(page) < query.Limit {
+				break
+			}
+			cursor := model.NewExpenseCursor(page[len(page)-1], sortBy)
+			query.After = &cursor
+		}
+		slices.Sort(seen)
+		if !slices.Equal(seen, want) {
+			t.Errorf("FindAll() pages sorted by %s = %v, want every expense once: %v", sortBy, seen, want)
+		}
+	}
+}
+
+func testConcurrentSaves(t *testing.T, r port.ExpenseRepository) {
+	const writers = 20
+
+	var wg sync.WaitGroup
+	saved := make(chan int, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(amount model.Money) {
+			defer wg.Done()
+			e, err := r.Save(context.Background(), &model.Expense{Amount: amount, Currency: "USD", Created: contractDate})
+			if err != nil {
+				t.Errorf("Save() error = %v", err)
+				return
+			}
+			saved <- e.Id
+		}(model.Money(100 * (i + 1)))
+	}
+	wg.Wait()
+	close(saved)
+
+	unique := map[int]bool{}
+	for id := range saved {
+		unique[id] = true
+	}
+	got := findAll(t, r, model.ExpenseQuery{SortBy: model.SortById, Direction: model.SortAscending})
+	if len(unique) != writers || len(got) != writers {
+		t.Errorf("concurrent Save() stored %d expenses with %d distinct ids, want %d", len(got), len(unique), writers)
+	}
+}
+
+func testCancelledContext(t *testing.T, r port.ExpenseRepository) {
+	saved := save(t, r, 1000, contractDate)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]error{}
+	_, calls["Exists()"] = r.Exists(ctx, saved.Id)
+	_, calls["FindByID()"] = r.FindByID(ctx, saved.Id)
+	_, calls["FindAll()"] = r.FindAll(ctx, model.ExpenseQuery{SortBy: model.SortById, Direction: model.SortAscending})
+	_, calls["Save()"] = r.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: contractDate})
+	_, calls["Update()"] = r.Update(ctx, &model.Expense{Id: saved.Id, Amount: 2000, Currency: "USD", Created: contractDate, Version: saved.Version})
+	calls["Delete()"] = r.Delete(ctx, saved.Id)
+	_, calls["FindDeleted()"] = r.FindDeleted(ctx)
+	calls["Restore()"] = r.Restore(ctx, saved.Id)
+	_, calls["Purge()"] = r.Purge(ctx, time.Now())
+	for call, err := range calls {
+		if err == nil {
+			t.Errorf("%s with a cancelled context error = nil, want error", call)
+		}
+	}
+
+	got, err := r.FindByID(context.Background(), saved.Id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertExpense(t, "FindByID() after cancelled calls", got, saved)
+}
+
+func save(t *testing.T, r port.ExpenseRepository, amount model.Money, created time.Time) model.Expense {
+	t.Helper()
+	e, err := r.Save(context.Background(), &model.Expense{Amount: amount, Currency: "USD", Created: created})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return *e
+}
+
+func findAll(t *testing.T, r port.ExpenseRepository, query model.ExpenseQuery) []model.Expense {
+	t.Helper()
+	expenses, err := r.FindAll(context.Background(), query)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	return expenses
+}
+
+func assertExpense(t *testing.T, call string, got *model.Expense, want model.Expense) {
+	t.Helper()
+	if got.Id != want.Id || got.Amount != want.Amount || got.CategoryId != want.CategoryId ||
+		got.Version != want.Version || !got.Created.Equal(want.Created) || !slices.Equal(got.Splits, want.Splits) {
+		t.Errorf("%s = %s, want %s", call, describe(*got), describe(want))
+	}
+}
+
+func describe(e model.Expense) string {
+	return fmt.Sprintf("{id %d, amount %s, created %s, category %d, version %d, splits %v}",
+		e.Id, e.Amount, e.Created.Format(time.RFC3339), e.CategoryId, e.Version, e.Splits)
+}
+
+func ids(expenses []model.Expense) []int {
+	ids := []int{}
+	for _, e := range expenses {
+		ids = append(ids, e.Id)
+	}
+	return ids
+}
@@ -0,0 +1,83 @@
+package porttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// TestImportedTransactionRepository checks the behaviour of an
+// ImportedTransactionRepository. newRepository must return an empty
+// repository on every call; each subtest asks for its own.
+func TestImportedTransactionRepository(t *testing.T,
+	newRepository func(t *testing.T) port.ImportedTransactionRepository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.ImportedTransactionRepository)
+	}{
+		{name: "given a saved transaction, then it exists only for its account", test: testImportedExists},
+		{name: "given a transaction saved twice, then the second save fails", test: testImportedTwice},
+		{name: "given a cancelled context, then every call fails", test: testImportedCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func importedTransaction(account, externalId string) *model.ImportedTransaction {
+	return &model.ImportedTransaction{
+		Kind: model.KindExpense, Amount: 2530, Created: contractDate,
+		Account: account, ExternalId: externalId, Id: 1,
+	}
+}
+
+func testImportedExists(t *testing.T, r port.ImportedTransactionRepository) {
+	ctx := context.Background()
+	if err := r.Save(ctx, importedTransaction("1234", "20230401001")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		account, externalId string
+		want                bool
+	}{
+		{"1234", "20230401001", true},
+		{"1234", "20230401002", false},
+		{"5678", "20230401001", false},
+	}
+	for _, tt := range tests {
+		got, err := r.Exists(ctx, tt.account, tt.externalId)
+		if err != nil || got != tt.want {
+			t.Errorf("Exists(%s, %s) = %v, %v, want %v", tt.account, tt.externalId, got, err, tt.want)
+		}
+	}
+}
+
+func testImportedTwice(t *testing.T, r port.ImportedTransactionRepository) {
+	ctx := context.Background()
+	if err := r.Save(ctx, importedTransaction("1234", "20230401001")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := r.Save(ctx, importedTransaction("1234", "20230401001")); err == nil {
+		t.Errorf("Save() of a transaction already saved error = nil, want error")
+	}
+	if err := r.Save(ctx, importedTransaction("5678", "20230401001")); err != nil {
+		t.Errorf("Save() of the same id in another account error = %v", err)
+	}
+}
+
+func testImportedCancelledContext(t *testing.T, r port.ImportedTransactionRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := r.Save(ctx, importedTransaction("1234", "20230401001")); err == nil {
+		t.Errorf("Save() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.Exists(ctx, "1234", "20230401001"); err == nil {
+		t.Errorf("Exists() with a cancelled context error = nil, want error")
+	}
+}
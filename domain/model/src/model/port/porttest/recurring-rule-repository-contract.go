@@ -0,0 +1,285 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+var contractDay = time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// TestRecurringRuleRepository checks the behaviour of a
+// RecurringRuleRepository. newRepository must return an empty repository on
+// every call; each subtest asks for its own.
+func TestRecurringRuleRepository(t *testing.T, newRepository func(t *testing.T) port.RecurringRuleRepository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.RecurringRuleRepository)
+	}{
+		{name: "given a rule, when saved, then it gets a new id and can be found", test: testRecurringSaveAndFind},
+		{name: "given a missing id, then find returns item not found", test: testRecurringNotFound},
+		{name: "given a saved rule, when updated, then find returns the new values", test: testRecurringUpdate},
+		{name: "given occurrences, then find returns those of the rule between both days", test: testRecurringFindOccurrences},
+		{name: "given a created occurrence, then it can't be replaced", test: testRecurringCreatedOccurrence},
+		{name: "given occurrences, then last created is the latest created one before any overridden", test: testRecurringLastCreated},
+		{name: "given a saved rule, when deleted, then it and its occurrences are gone", test: testRecurringDelete},
+		{name: "given a cancelled context, then every call fails", test: testRecurringCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testRecurringSaveAndFind(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	end := contractDay.AddDate(1, 0, 0)
+	rent := saveRule(t, r, &model.RecurringRule{
+		Kind: model.KindExpense, Description: "rent", Amount: 120000, Currency: "USD",
+		Frequency: model.FrequencyMonthly, Interval: 1, DayOfMonth: 31, Start: contractDay, End: &end,
+	})
+	salary := saveRule(t, r, &model.RecurringRule{
+		Kind: model.KindIncome, Amount: 300000, Currency: "USD", Frequency: model.FrequencyWeekly, Interval: 2, Start: contractDay,
+	})
+	if rent.Id <= 0 || salary.Id <= 0 || rent.Id == salary.Id {
+		t.Fatalf("Save() ids = %d and %d, want distinct positive ids", rent.Id, salary.Id)
+	}
+
+	got, err := r.FindByID(ctx, rent.Id)
+	if err != nil || !reflect.DeepEqual(*got, rent) {
+		t.Errorf("FindByID() = %+v, %v, want %+v", got, err, rent)
+	}
+	exists, err := r.Exists(ctx, salary.Id)
+	if err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+	all, err := r.FindAll(ctx)
+	if err != nil || !reflect.DeepEqual(all, []model.RecurringRule{rent, salary}) {
+		t.Errorf("FindAll() = %+v, %v, want %+v", all, err, []model.RecurringRule{rent, salary})
+	}
+}
+
+func testRecurringNotFound(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	saved := saveRule(t, r, newContractRule())
+	missing := saved.Id + 1000
+
+	_, err := r.FindByID(ctx, missing)
+	var notFound *customErrors.ItemNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindByID() of a missing id error = %v, want ItemNotFound", err)
+	}
+	exists, err := r.Exists(ctx, missing)
+	if err != nil || exists {
+		t.Errorf("Exists() of a missing id = %v, %v, want false", exists, err)
+	}
+	all, err := r.FindAll(ctx)
+	if err != nil || len(all) != 1 {
+		t.Errorf("FindAll() = %+v, %v, want one rule", all, err)
+	}
+}
+
+func testRecurringUpdate(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	saved := saveRule(t, r, newContractRule())
+
+	end := contractDay.AddDate(0, 6, 0)
+	saved.Amount = 130000
+	saved.Description = "rent, new lease"
+	saved.DayOfMonth = 5
+	saved.End = &end
+	if _, err := r.Update(ctx, &saved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := r.FindByID(ctx, saved.Id)
+	if err != nil || !reflect.DeepEqual(*got, saved) {
+		t.Errorf("FindByID() after update = %+v, %v, want %+v", got, err, saved)
+	}
+
+	missing := saved
+	missing.Id += 1000
+	if _, err := r.Update(ctx, &missing); err == nil {
+		t.Errorf("Update() of a missing rule error = nil, want error")
+	}
+}
+
+func testRecurringFindOccurrences(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	rule := saveRule(t, r, newContractRule())
+	other := saveRule(t, r, newContractRule())
+
+	november := model.RecurringOccurrence{
+		RuleId: rule.Id, Date: contractDay.AddDate(0, 1, 0), Status: model.OccurrenceOverridden, Amount: 90000,
+	}
+	october := model.RecurringOccurrence{
+		RuleId: rule.Id, Date: contractDay, Status: model.OccurrenceSkipped, Amount: 120000,
+	}
+	january := model.RecurringOccurrence{
+		RuleId: rule.Id, Date: contractDay.AddDate(0, 3, 0), Status: model.OccurrenceSkipped, Amount: 120000,
+	}
+	for _, o := range []model.RecurringOccurrence{november, october, january, {
+		RuleId: other.Id, Date: contractDay, Status: model.OccurrenceSkipped, Amount: 120000,
+	}} {
+		saveOccurrence(t, r, o)
+	}
+
+	got, err := r.FindOccurrences(ctx, rule.Id, contractDay, contractDay.AddDate(0, 1, 0))
+	if want := []model.RecurringOccurrence{october, november}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOccurrences() = %+v, %v, want %+v", got, err, want)
+	}
+
+	november.Status = model.OccurrenceSkipped
+	saveOccurrence(t, r, november)
+	got, err = r.FindOccurrences(ctx, rule.Id, november.Date, november.Date)
+	if want := []model.RecurringOccurrence{november}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOccurrences() of a replaced occurrence = %+v, %v, want %+v", got, err, want)
+	}
+
+	got, err = r.FindOccurrences(ctx, rule.Id, contractDay.AddDate(1, 0, 0), contractDay.AddDate(2, 0, 0))
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("FindOccurrences() without occurrences = %v, %v, want an empty list", got, err)
+	}
+}
+
+func testRecurringCreatedOccurrence(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	rule := saveRule(t, r, newContractRule())
+	created := model.RecurringOccurrence{
+		RuleId: rule.Id, Date: contractDay, Status: model.OccurrenceCreated, Amount: 120000, RecordId: 7,
+	}
+	saveOccurrence(t, r, created)
+
+	again := created
+	again.RecordId = 8
+	saved, err := r.SaveOccurrence(ctx, &again)
+	if err != nil || saved {
+		t.Errorf("SaveOccurrence() of a created occurrence = %v, %v, want false", saved, err)
+	}
+	skipped := created
+	skipped.Status = model.OccurrenceSkipped
+	skipped.RecordId = 0
+	if saved, err := r.SaveOccurrence(ctx, &skipped); err != nil || saved {
+		t.Errorf("SaveOccurrence() skipping a created occurrence = %v, %v, want false", saved, err)
+	}
+
+	got, err := r.FindOccurrences(ctx, rule.Id, contractDay, contractDay)
+	if want := []model.RecurringOccurrence{created}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOccurrences() = %+v, %v, want %+v", got, err, want)
+	}
+}
+
+func testRecurringLastCreated(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	rule := saveRule(t, r, newContractRule())
+	other := saveRule(t, r, newContractRule())
+
+	got, err := r.LastCreated(ctx, rule.Id)
+	if err != nil || !got.IsZero() {
+		t.Errorf("LastCreated() without occurrences = %v, %v, want the zero time", got, err)
+	}
+
+	november := contractDay.AddDate(0, 1, 0)
+	for _, o := range []model.RecurringOccurrence{
+		{RuleId: rule.Id, Date: contractDay, Status: model.OccurrenceCreated, Amount: 120000, RecordId: 7},
+		{RuleId: rule.Id, Date: november, Status: model.OccurrenceCreated, Amount: 120000, RecordId: 8},
+		{RuleId: rule.Id, Date: contractDay.AddDate(0, 2, 0), Status: model.OccurrenceSkipped, Amount: 120000},
+		{RuleId: other.Id, Date: contractDay.AddDate(0, 3, 0), Status: model.OccurrenceCreated, Amount: 120000, RecordId: 9},
+	} {
+		saveOccurrence(t, r, o)
+	}
+	got, err = r.LastCreated(ctx, rule.Id)
+	if err != nil || !got.Equal(november) {
+		t.Errorf("LastCreated() = %v, %v, want %v", got, err, november)
+	}
+
+	saveOccurrence(t, r, model.RecurringOccurrence{
+		RuleId: rule.Id, Date: contractDay.AddDate(0, 0, 14), Status: model.OccurrenceOverridden, Amount: 90000,
+	})
+	got, err = r.LastCreated(ctx, rule.Id)
+	if err != nil || !got.Equal(contractDay) {
+		t.Errorf("LastCreated() with an overridden occurrence = %v, %v, want %v", got, err, contractDay)
+	}
+}
+
+func testRecurringDelete(t *testing.T, r port.RecurringRuleRepository) {
+	ctx := context.Background()
+	saved := saveRule(t, r, newContractRule())
+	saveOccurrence(t, r, model.RecurringOccurrence{
+		RuleId: saved.Id, Date: contractDay, Status: model.OccurrenceCreated, Amount: 120000, RecordId: 7,
+	})
+
+	if err := r.Delete(ctx, saved.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	exists, err := r.Exists(ctx, saved.Id)
+	if err != nil || exists {
+		t.Errorf("Exists() after delete = %v, %v, want false", exists, err)
+	}
+	got, err := r.FindOccurrences(ctx, saved.Id, contractDay, contractDay)
+	if err != nil || len(got) != 0 {
+		t.Errorf("FindOccurrences() after delete = %+v, %v, want an empty list", got, err)
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("Delete() of a deleted rule error = nil, want error")
+	}
+}
+
+func testRecurringCancelledContext(t *testing.T, r port.RecurringRuleRepository) {
+	saved := saveRule(t, r, newContractRule())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := r.Save(ctx, newContractRule()); err == nil {
+		t.Errorf("Save() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindAll(ctx); err == nil {
+		t.Errorf("FindAll() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.Exists(ctx, saved.Id); err == nil {
+		t.Errorf("Exists() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindOccurrences(ctx, saved.Id, contractDay, contractDay); err == nil {
+		t.Errorf("FindOccurrences() with a cancelled context error = nil, want error")
+	}
+	occurrence := &model.RecurringOccurrence{RuleId: saved.Id, Date: contractDay, Status: model.OccurrenceSkipped}
+	if _, err := r.SaveOccurrence(ctx, occurrence); err == nil {
+		t.Errorf("SaveOccurrence() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.LastCreated(ctx, saved.Id); err == nil {
+		t.Errorf("LastCreated() with a cancelled context error = nil, want error")
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("Delete() with a cancelled context error = nil, want error")
+	}
+}
+
+func newContractRule() *model.RecurringRule {
+	return &model.RecurringRule{
+		Kind: model.KindExpense, Description: "rent", Amount: 120000, Currency: "USD",
+		Frequency: model.FrequencyMonthly, Interval: 1, Start: contractDay,
+	}
+}
+
+func saveRule(t *testing.T, r port.RecurringRuleRepository, rule *model.RecurringRule) model.RecurringRule {
+	t.Helper()
+	saved, err := r.Save(context.Background(), rule)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return *saved
+}
+
+func saveOccurrence(t *testing.T, r port.RecurringRuleRepository, o model.RecurringOccurrence) {
+	t.Helper()
+	saved, err := r.SaveOccurrence(context.Background(), &o)
+	if err != nil || !saved {
+		t.Fatalf("SaveOccurrence() = %v, %v, want true", saved, err)
+	}
+}
@@ -0,0 +1,184 @@
+package porttest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// TestTransferRepository checks the behaviour of a TransferRepository.
+// newRepositories must return an empty transfer repository on every call,
+// along with an account repository on the same storage, since transfers
+// refer to accounts; each subtest asks for its own.
+func TestTransferRepository(t *testing.T,
+	newRepositories func(t *testing.T) (port.TransferRepository, port.AccountRepository)) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r port.TransferRepository, accounts port.AccountRepository)
+	}{
+		{name: "given a transfer, when saved, then it gets a new id and can be found", test: testTransferSaveAndFind},
+		{name: "given a missing id, then find returns item not found", test: testTransferNotFound},
+		{name: "given transfers, then find all returns those of the account, oldest first", test: testTransferFindAll},
+		{name: "given a saved transfer, when deleted, then it no longer exists", test: testTransferDelete},
+		{name: "given transfers, then totals add what went in and out of the account", test: testTransferTotals},
+		{name: "given a cancelled context, then every call fails", test: testTransferCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, accounts := newRepositories(t)
+			tt.test(t, r, accounts)
+		})
+	}
+}
+
+func testTransferSaveAndFind(t *testing.T, r port.TransferRepository, accounts port.AccountRepository) {
+	ctx := context.Background()
+	checking := saveAccount(t, accounts, "Checking", model.AccountBank, 0)
+	savings := saveAccount(t, accounts, "Savings", model.AccountBank, 0)
+
+	first := saveTransfer(t, r, checking.Id, savings.Id, 10000, contractDate, "monthly savings")
+	second := saveTransfer(t, r, savings.Id, checking.Id, 2550, contractDate, "")
+	if first.Id <= 0 || second.Id <= 0 || first.Id == second.Id {
+		t.Fatalf("Save() ids = %d and %d, want distinct positive ids", first.Id, second.Id)
+	}
+
+	got, err := r.FindByID(ctx, first.Id)
+	if err != nil || !reflect.DeepEqual(*got, first) {
+		t.Errorf("FindByID() = %+v, %v, want %+v", got, err, first)
+	}
+	exists, err := r.Exists(ctx, second.Id)
+	if err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+}
+
+func testTransferNotFound(t *testing.T, r port.TransferRepository, accounts port.AccountRepository) {
+	ctx := context.Background()
+	checking := saveAccount(t, accounts, "Checking", model.AccountBank, 0)
+	savings := saveAccount(t, accounts, "Savings", model.AccountBank, 0)
+	saved := saveTransfer(t, r, checking.Id, savings.Id, 10000, contractDate, "")
+	missing := saved.Id + 1000
+
+	_, err := r.FindByID(ctx, missing)
+	var notFound *customErrors.ItemNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("FindByID() of a missing id error = %v, want ItemNotFound", err)
+	}
+	exists, err := r.Exists(ctx, missing)
+	if err != nil || exists {
+		t.Errorf("Exists() of a missing id = %v, %v, want false", exists, err)
+	}
+}
+
+func testTransferFindAll(t *testing.T, r port.TransferRepository, accounts port.AccountRepository) {
+	ctx := context.Background()
+	checking := saveAccount(t, accounts, "Checking", model.AccountBank, 0)
+	savings := saveAccount(t, accounts, "Savings", model.AccountBank, 0)
+	wallet := saveAccount(t, accounts, "Wallet", model.AccountCash, 0)
+
+	later := saveTransfer(t, r, checking.Id, savings.Id, 10000, contractDate.Add(48*time.Hour), "")
+	earlier := saveTransfer(t, r, savings.Id, checking.Id, 2000, contractDate, "")
+	other := saveTransfer(t, r, wallet.Id, savings.Id, 500, contractDate.Add(24*time.Hour), "")
+
+	got, err := r.FindAll(ctx, checking.Id)
+	if err != nil || !reflect.DeepEqual(got, []model.Transfer{earlier, later}) {
+		t.Errorf("FindAll() of an account = %+v, %v, want %+v", got, err, []model.Transfer{earlier, later})
+	}
+	got, err = r.FindAll(ctx, 0)
+	if err != nil || !reflect.DeepEqual(got, []model.Transfer{earlier, other, later}) {
+		t.Errorf("FindAll() = %+v, %v, want %+v", got, err, []model.Transfer{earlier, other, later})
+	}
+	empty := saveAccount(t, accounts, "Visa", model.AccountCard, 0)
+	got, err = r.FindAll(ctx, empty.Id)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("FindAll() of an account without transfers = %v, %v, want an empty list", got, err)
+	}
+}
+
+func testTransferDelete(t *testing.T, r port.TransferRepository, accounts port.AccountRepository) {
+	ctx := context.Background()
+	checking := saveAccount(t, accounts, "Checking", model.AccountBank, 0)
+	savings := saveAccount(t, accounts, "Savings", model.AccountBank, 0)
+	saved := saveTransfer(t, r, checking.Id, savings.Id, 10000, contractDate, "")
+
+	if err := r.Delete(ctx, saved.Id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	exists, err := r.Exists(ctx, saved.Id)
+	if err != nil || exists {
+		t.Errorf("Exists() after delete = %v, %v, want false", exists, err)
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("Delete() of a deleted transfer error = nil, want error")
+	}
+}
+
+func testTransferTotals(t *testing.T, r port.TransferRepository, accounts port.AccountRepository) {
+	ctx := context.Background()
+	checking := saveAccount(t, accounts, "Checking", model.AccountBank, 0)
+	savings := saveAccount(t, accounts, "Savings", model.AccountBank, 0)
+	wallet := saveAccount(t, accounts, "Wallet", model.AccountCash, 0)
+	saveTransfer(t, r, checking.Id, savings.Id, 10000, contractDate, "")
+	saveTransfer(t, r, checking.Id, savings.Id, 2550, contractDate, "")
+	saveTransfer(t, r, savings.Id, checking.Id, 3000, contractDate, "")
+	saveTransfer(t, r, wallet.Id, savings.Id, 500, contractDate, "")
+
+	got, err := r.Totals(ctx, checking.Id)
+	if want := (model.TransferTotals{In: 3000, Out: 12550}); err != nil || *got != want {
+		t.Errorf("Totals() = %+v, %v, want %+v", got, err, want)
+	}
+	got, err = r.Totals(ctx, savings.Id)
+	if want := (model.TransferTotals{In: 13050, Out: 3000}); err != nil || *got != want {
+		t.Errorf("Totals() = %+v, %v, want %+v", got, err, want)
+	}
+	empty := saveAccount(t, accounts, "Visa", model.AccountCard, 0)
+	got, err = r.Totals(ctx, empty.Id)
+	if err != nil || *got != (model.TransferTotals{}) {
+		t.Errorf("Totals() of an account without transfers = %+v, %v, want zero totals", got, err)
+	}
+}
+
+func testTransferCancelledContext(t *testing.T, r port.TransferRepository, accounts port.AccountRepository) {
+	checking := saveAccount(t, accounts, "Checking", model.AccountBank, 0)
+	savings := saveAccount(t, accounts, "Savings", model.AccountBank, 0)
+	saved := saveTransfer(t, r, checking.Id, savings.Id, 10000, contractDate, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	transfer := &model.Transfer{FromAccountId: checking.Id, ToAccountId: savings.Id, Amount: 100, Currency: "USD",
+		Created: contractDate}
+	if _, err := r.Save(ctx, transfer); err == nil {
+		t.Errorf("Save() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.FindAll(ctx, 0); err == nil {
+		t.Errorf("FindAll() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.Exists(ctx, saved.Id); err == nil {
+		t.Errorf("Exists() with a cancelled context error = nil, want error")
+	}
+	if _, err := r.Totals(ctx, checking.Id); err == nil {
+		t.Errorf("Totals() with a cancelled context error = nil, want error")
+	}
+	if err := r.Delete(ctx, saved.Id); err == nil {
+		t.Errorf("Delete() with a cancelled context error = nil, want error")
+	}
+}
+
+func saveTransfer(t *testing.T, r port.TransferRepository, from, to int, amount model.Money,
+	created time.Time, description string) model.Transfer {
+	t.Helper()
+	transfer, err := r.Save(context.Background(), &model.Transfer{
+		FromAccountId: from, ToAccountId: to, Amount: amount, Currency: "USD", Created: created,
+		Description: description,
+	})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return *transfer
+}
@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// RecurringRuleRepository stores recurring rules and the occurrences that
+// were skipped, overridden or created. Implementations must stop working on
+// a call as soon as its ctx is done.
+type RecurringRuleRepository interface {
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.RecurringRule, error)
+	// FindAll returns every rule ordered by id.
+	FindAll(ctx context.Context) ([]model.RecurringRule, error)
+	Save(context.Context, *model.RecurringRule) (*model.RecurringRule, error)
+	Update(context.Context, *model.RecurringRule) (*model.RecurringRule, error)
+	// Delete removes the rule and its stored occurrences; the expenses and
+	// incomes it created are kept.
+	Delete(ctx context.Context, id int) error
+	// FindOccurrences returns the stored occurrences of the rule between from
+	// and to, both inclusive, ordered by date.
+	FindOccurrences(ctx context.Context, ruleId int, from, to time.Time) ([]model.RecurringOccurrence, error)
+	// SaveOccurrence stores the occurrence, replacing the one of the same
+	// rule and date unless that one was created. It returns false when it
+	// was, so two callers creating the same occurrence only succeed once.
+	SaveOccurrence(context.Context, *model.RecurringOccurrence) (bool, error)
+	// LastCreated returns the date of the latest created occurrence of the
+	// rule that no overridden one comes before, or the zero time when there
+	// is none.
+	LastCreated(ctx context.Context, ruleId int) (time.Time, error)
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// TransferRepository stores transfers between accounts. Implementations must
+// stop working on a call as soon as its ctx is done.
+type TransferRepository interface {
+	Exists(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*model.Transfer, error)
+	// FindAll returns the transfers from or to accountId, or every transfer
+	// when it is zero, ordered by date and id.
+	FindAll(ctx context.Context, accountId int) ([]model.Transfer, error)
+	Save(context.Context, *model.Transfer) (*model.Transfer, error)
+	Delete(ctx context.Context, id int) error
+	// Totals aggregates the money transferred into and out of the account.
+	Totals(ctx context.Context, accountId int) (*model.TransferTotals, error)
+}
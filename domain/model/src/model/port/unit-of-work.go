@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+)
+
+// Repositories are the repositories a unit of work hands to its function.
+// Every call made through them belongs to the same transaction.
+type Repositories struct {
+	Expenses   ExpenseRepository
+	Incomes    IncomeRepository
+	Categories CategoryRepository
+	Budgets    BudgetRepository
+	Audit      AuditRepository
+	Imports    ImportedTransactionRepository
+	Accounts   AccountRepository
+	Transfers  TransferRepository
+	Recurring  RecurringRuleRepository
+}
+
+// UnitOfWork runs several repository calls atomically. Run commits what fn
+// did when it returns nil and rolls it back when it returns an error, which
+// Run then returns.
+type UnitOfWork interface {
+	Run(ctx context.Context, fn func(repos Repositories) error) error
+}
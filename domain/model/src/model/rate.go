@@ -0,0 +1,78 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// RateScale is the number of decimal places kept for exchange rates,
+	// more than the ECB publishes.
+	RateScale = 8
+
+	rateUnitsPerOne = 100_000_000
+)
+
+// Rate is an exchange rate kept as a fixed-point decimal in units of
+// 10^-RateScale, so converting an amount gives the same cents everywhere. It
+// is written to JSON as a plain decimal number such as 1.1053.
+type Rate int64
+
+// ParseRate reads a decimal rate like "1.1053" or "4480.5". Rates with more
+// than RateScale decimal places are rejected instead of rounded, and so is
+// anything that isn't a plain decimal, like NaN or Inf.
+func ParseRate(value string) (Rate, error) {
+	units, err := parseFixed(value, RateScale, "rate")
+	return Rate(units), err
+}
+
+// Ratio returns the rate as an exact fraction.
+func (r Rate) Ratio() *big.Rat {
+	return big.NewRat(int64(r), rateUnitsPerOne)
+}
+
+// String formats the rate without trailing zeros.
+func (r Rate) String() string {
+	units := int64(r)
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	fraction := strings.TrimRight(fmt.Sprintf("%0*d", RateScale, units%rateUnitsPerOne), "0")
+	if fraction == "" {
+		return fmt.Sprintf("%s%d", sign, units/rateUnitsPerOne)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, units/rateUnitsPerOne, fraction)
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts the rate either as a JSON number or as a string.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	parsed, err := ParseRate(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Convert multiplies the amount by ratio, rounding half away from zero to
+// the cent.
+func (m Money) Convert(ratio *big.Rat) Money {
+	product := new(big.Rat).Mul(big.NewRat(int64(m), 1), ratio)
+	quotient, remainder := new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+	// the remainder takes the sign of the amount; round its magnitude
+	if new(big.Int).Abs(new(big.Int).Lsh(remainder, 1)).Cmp(product.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(remainder.Sign())))
+	}
+	return Money(quotient.Int64())
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Rate
+		wantErr bool
+	}{
+		{name: "given an ECB rate, then get its units", value: "1.1053", want: 110530000},
+		{name: "given eight decimal places, then keep them", value: "0.00012345", want: 12345},
+		{name: "given an integer rate, then scale it", value: "4480", want: 448000000000},
+		{name: "given too many decimal places, then get error", value: "1.000000001", wantErr: true},
+		{name: "given NaN, then get error", value: "NaN", wantErr: true},
+		{name: "given Inf, then get error", value: "+Inf", wantErr: true},
+		{name: "given an exponent, then get error", value: "1e3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateJSON(t *testing.T) {
+	var got Rate
+	if err := json.Unmarshal([]byte("1.10530"), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	out, err := json.Marshal(got)
+	if err != nil || string(out) != "1.1053" {
+		t.Errorf("json.Marshal() = %s, %v, want 1.1053", out, err)
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Money
+		ratio  *big.Rat
+		want   Money
+	}{
+		{name: "given an exact product, then keep it", amount: 1000, ratio: Rate(110000000).Ratio(), want: 1100},
+		{name: "given half a cent, then round up", amount: 5, ratio: big.NewRat(1, 2), want: 3},
+		{name: "given less than half a cent, then round down", amount: 1, ratio: big.NewRat(2, 5), want: 0},
+		{name: "given a negative amount, then round away from zero", amount: -5, ratio: big.NewRat(1, 2), want: -3},
+		{name: "given an inverse rate, then round the repeating decimal", amount: 100,
+			ratio: new(big.Rat).Inv(Rate(300000000).Ratio()), want: 33},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Convert(tt.ratio); got != tt.want {
+				t.Errorf("Money.Convert() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+)
+
+// Frequency is the unit a recurring rule repeats in, named like the FREQ
+// values of iCalendar (RFC 5545) recurrence rules.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// RecurringRule creates the same expense or income every Interval
+// Frequency units, counted from Start, until End when it has one. Rent,
+// subscriptions and salaries are typical rules.
+type RecurringRule struct {
+	Id          int             `json:"id" validate:"integer"`
+	Kind        TransactionKind `json:"kind" validate:"required"`
+	Description string          `json:"description,omitempty"`
+	Amount      Money           `json:"amount" validate:"required,number"`
+	// Currency is the ISO 4217 code of Amount; empty means the reporting
+	// currency.
+	Currency string `json:"currency,omitempty"`
+	// CategoryId only applies to expense rules.
+	CategoryId int       `json:"categoryId,omitempty" validate:"integer"`
+	AccountId  int       `json:"accountId,omitempty" validate:"integer"`
+	Frequency  Frequency `json:"frequency" validate:"required"`
+	Interval   int       `json:"interval,omitempty" validate:"integer"`
+	// DayOfMonth pins monthly and yearly occurrences to a day, or to the
+	// last day of shorter months; zero takes the day of Start.
+	DayOfMonth int       `json:"dayOfMonth,omitempty" validate:"integer"`
+	Start      time.Time `json:"start" validate:"required"`
+	// End is the last day an occurrence may fall on.
+	End *time.Time `json:"end,omitempty"`
+}
+
+// OccurrenceStatus tells what became of one occurrence of a rule.
+type OccurrenceStatus string
+
+const (
+	// OccurrencePending occurrences haven't been created yet. They are
+	// never stored.
+	OccurrencePending OccurrenceStatus = "pending"
+	// OccurrenceOverridden occurrences will be created with their own
+	// amount instead of the rule's.
+	OccurrenceOverridden OccurrenceStatus = "overridden"
+	OccurrenceSkipped    OccurrenceStatus = "skipped"
+	OccurrenceCreated    OccurrenceStatus = "created"
+)
+
+// RecurringOccurrence is the day a rule falls on and what became of it.
+type RecurringOccurrence struct {
+	RuleId int              `json:"ruleId"`
+	Date   time.Time        `json:"date"`
+	Status OccurrenceStatus `json:"status"`
+	Amount Money            `json:"amount"`
+	// RecordId is the expense or income created for the occurrence.
+	RecordId int `json:"recordId,omitempty"`
+}
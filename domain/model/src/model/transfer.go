@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// Transfer moves money from one account to another. It is neither an
+// expense nor an income: reports leave it out, and only the balances of the
+// two accounts see it.
+type Transfer struct {
+	Id            int   `json:"id" validate:"integer"`
+	FromAccountId int   `json:"fromAccountId" validate:"required"`
+	ToAccountId   int   `json:"toAccountId" validate:"required"`
+	Amount        Money `json:"amount" validate:"required,number"`
+	// Currency is the one both accounts share, set when the transfer is
+	// saved.
+	Currency    string    `json:"currency"`
+	Created     time.Time `json:"created" validate:"required"`
+	Description string    `json:"description,omitempty"`
+}
+
+// TransferTotals are the money transferred into and out of an account.
+type TransferTotals struct {
+	In  Money
+	Out Money
+}
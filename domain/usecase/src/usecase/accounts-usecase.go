@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	AccountName        = "account"
+	AccountIfExists    = "account if exists"
+	AccountBalanceName = "account balance"
+)
+
+type AccountUseCase struct {
+	Repository port.AccountRepository
+	// Transfers adds the transfers of an account to its balance, and keeps
+	// accounts with transfers from being deleted.
+	Transfers port.TransferRepository
+}
+
+func (uc AccountUseCase) FindByID(ctx context.Context, id int) (*model.Account, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(AccountName)
+	}
+	return uc.Repository.FindByID(ctx, id)
+}
+
+func (uc AccountUseCase) FindAll(ctx context.Context) ([]model.Account, error) {
+	accounts, err := uc.Repository.FindAll(ctx)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountName)
+	}
+	return accounts, nil
+}
+
+func (uc AccountUseCase) Save(ctx context.Context, account *model.Account) (*model.Account, error) {
+	if account.Id < 0 {
+		return nil, errors.NewInvalidItemError(AccountName, "field Id must be a positive integer")
+	}
+	if err := normalizeAccount(account); err != nil {
+		return nil, err
+	}
+	exists, err := uc.Repository.Exists(ctx, account.Id)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountIfExists)
+	}
+	if exists {
+		return nil, errors.NewItemAlreadyExistsError(AccountName)
+	}
+
+	result, err := uc.Repository.Save(ctx, account)
+	if err != nil {
+		return nil, errors.NewSaveItemError(AccountName)
+	}
+
+	return result, nil
+}
+
+// Update replaces the account; setting Closed closes it to new expenses and
+// incomes, and clearing it reopens it. The currency can only change while no
+// record belongs to the account.
+func (uc AccountUseCase) Update(ctx context.Context, account *model.Account) (*model.Account, error) {
+	if err := normalizeAccount(account); err != nil {
+		return nil, err
+	}
+	exists, err := uc.Repository.Exists(ctx, account.Id)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(AccountName)
+	}
+	current, err := uc.Repository.FindByID(ctx, account.Id)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountName)
+	}
+	if current.Currency != account.Currency {
+		// records keep the currency of their account
+		hasRecords, err := uc.Repository.HasRecords(ctx, account.Id)
+		if err != nil {
+			return nil, errors.NewFindItemError(AccountName)
+		}
+		if hasRecords {
+			return nil, errors.NewInvalidItemError(AccountName,
+				fmt.Sprintf("account %d has records in %s, its currency can't change", account.Id, current.Currency))
+		}
+	}
+
+	result, err := uc.Repository.Update(ctx, account)
+	if err != nil {
+		return nil, errors.NewUpdateItemError(AccountName)
+	}
+
+	return result, nil
+}
+
+func (uc AccountUseCase) Delete(ctx context.Context, id int) error {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return errors.NewFindItemError(AccountIfExists)
+	}
+	if !exists {
+		return errors.NewItemNotFoundError(AccountName)
+	}
+	if uc.Transfers != nil {
+		transfers, err := uc.Transfers.FindAll(ctx, id)
+		if err != nil {
+			return errors.NewFindItemError(TransferName)
+		}
+		if len(transfers) > 0 {
+			return errors.NewInvalidItemError(AccountName,
+				fmt.Sprintf("account %d has transfers, close it instead", id))
+		}
+	}
+
+	if err := uc.Repository.Delete(ctx, id); err != nil {
+		return errors.NewDeleteItemError(AccountName)
+	}
+
+	return nil
+}
+
+// Balance returns what the account holds, counting every income, every
+// expense not in the trash and every transfer that belongs to it.
+func (uc AccountUseCase) Balance(ctx context.Context, id int) (*model.AccountBalance, error) {
+	account, err := uc.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := uc.Repository.Totals(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountBalanceName)
+	}
+
+	transfers := &model.TransferTotals{}
+	if uc.Transfers != nil {
+		if transfers, err = uc.Transfers.Totals(ctx, id); err != nil {
+			return nil, errors.NewFindItemError(AccountBalanceName)
+		}
+	}
+
+	return &model.AccountBalance{
+		AccountId:      account.Id,
+		Currency:       account.Currency,
+		OpeningBalance: account.OpeningBalance,
+		TotalIncome:    totals.Income,
+		TotalExpenses:  totals.Expenses,
+		TransfersIn:    transfers.In,
+		TransfersOut:   transfers.Out,
+		Balance:        account.OpeningBalance + totals.Income - totals.Expenses + transfers.In - transfers.Out,
+	}, nil
+}
+
+// normalizeAccount trims the name and upper-cases the currency of account,
+// and rejects the values that can't be stored.
+func normalizeAccount(account *model.Account) error {
+	account.Name = strings.TrimSpace(account.Name)
+	account.Currency = strings.ToUpper(strings.TrimSpace(account.Currency))
+	details := []string{}
+
+	if account.Name == "" {
+		details = append(details, "field Name is required")
+	}
+	switch account.Type {
+	case model.AccountCash, model.AccountBank, model.AccountCard:
+	default:
+		details = append(details, fmt.Sprintf("field Type must be %s, %s or %s",
+			model.AccountCash, model.AccountBank, model.AccountCard))
+	}
+	if !isCurrencyCode(account.Currency) {
+		details = append(details, "field Currency must be an ISO 4217 code, e.g. USD")
+	} else if !model.SupportsCurrency(account.Currency) {
+		details = append(details, unsupportedCurrency(account.Currency))
+	}
+
+	if len(details) > 0 {
+		return errors.NewInvalidItemError(AccountName, details...)
+	}
+	return nil
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// unsupportedCurrency describes why a currency whose amounts don't have
+// MoneyScale decimal places is rejected.
+func unsupportedCurrency(code string) string {
+	return fmt.Sprintf("currency %s is not supported, amounts must have %d decimal places", code, model.MoneyScale)
+}
+
+// validateAccount checks that the account an expense or income of the named
+// item belongs to exists and is open. Zero means no account. previousId is
+// the account of the stored record, zero on create: a record that stays in
+// it may be edited even once the account is closed.
+func validateAccount(ctx context.Context, accounts port.AccountRepository, item string,
+	accountId, previousId int) (*model.Account, error) {
+	if accountId == 0 {
+		return nil, nil
+	}
+	if accountId < 0 {
+		return nil, errors.NewInvalidItemError(item, "field AccountId must be a positive integer")
+	}
+	if accountId != previousId {
+		return openAccount(ctx, accounts, item, accountId)
+	}
+	account, err := accounts.FindByID(ctx, accountId)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountName)
+	}
+	return account, nil
+}
+
+// validateCurrency upper-cases the currency of an expense or income of the
+// named item. Amounts of an account are in its currency: an empty currency
+// takes it and any other is rejected. Without an account, an empty currency
+// takes fallback: the reporting one for a new record, the stored one on an
+// update.
+func validateCurrency(item string, currency *string, account *model.Account, fallback string) error {
+	*currency = strings.ToUpper(strings.TrimSpace(*currency))
+	if *currency != "" && !isCurrencyCode(*currency) {
+		return errors.NewInvalidItemError(item, "field Currency must be an ISO 4217 code, e.g. USD")
+	}
+	if !model.SupportsCurrency(*currency) {
+		return errors.NewInvalidItemError(item, unsupportedCurrency(*currency))
+	}
+	if account == nil {
+		if *currency == "" {
+			*currency = fallback
+		}
+		return nil
+	}
+	if *currency == "" {
+		*currency = account.Currency
+	}
+	if *currency != account.Currency {
+		return errors.NewInvalidItemError(item, fmt.Sprintf("currency %s does not match the %s of account %d",
+			*currency, account.Currency, account.Id))
+	}
+	return nil
+}
+
+// openAccount returns the account with accountId, failing with an invalid
+// item error of the named item when it doesn't exist or is closed.
+func openAccount(ctx context.Context, accounts port.AccountRepository, item string,
+	accountId int) (*model.Account, error) {
+	exists, err := accounts.Exists(ctx, accountId)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountIfExists)
+	}
+	if !exists {
+		return nil, errors.NewInvalidItemError(item, fmt.Sprintf("account %d does not exist", accountId))
+	}
+	account, err := accounts.FindByID(ctx, accountId)
+	if err != nil {
+		return nil, errors.NewFindItemError(AccountName)
+	}
+	if account.Closed {
+		return nil, errors.NewInvalidItemError(item, fmt.Sprintf("account %d is closed", accountId))
+	}
+	return account, nil
+}
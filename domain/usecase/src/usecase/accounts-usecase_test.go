@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+// newAccountBook returns a mock backed by the given accounts, keyed by id.
+func newAccountBook(accounts ...model.Account) *mocks.AccountRepositoryMock {
+	byId := map[int]model.Account{}
+	for _, a := range accounts {
+		byId[a.Id] = a
+	}
+	return &mocks.AccountRepositoryMock{
+		ExistsFn: func(ctx context.Context, id int) (bool, error) {
+			_, ok := byId[id]
+			return ok, nil
+		},
+		FindByIDFn: func(ctx context.Context, id int) (*model.Account, error) {
+			a := byId[id]
+			return &a, nil
+		},
+		SaveFn: func(ctx context.Context, a *model.Account) (*model.Account, error) {
+			a.Id = len(byId) + 1
+			return a, nil
+		},
+		UpdateFn: func(ctx context.Context, a *model.Account) (*model.Account, error) {
+			return a, nil
+		},
+		DeleteFn: func(ctx context.Context, id int) error {
+			return nil
+		},
+		TotalsFn: func(ctx context.Context, id int) (*model.BalanceTotals, error) {
+			return &model.BalanceTotals{Income: 50000, Expenses: 12550}, nil
+		},
+	}
+}
+
+var checkingAccount = model.Account{
+	Id: 1, Name: "Checking", Type: model.AccountBank, Currency: "USD", OpeningBalance: 100000,
+}
+
+func TestAccountUseCase_Save(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     *model.Account
+		want        *model.Account
+		wantDetails []string
+	}{
+		{
+			name:    "given an account, then save it with its currency upper-cased",
+			account: &model.Account{Name: " Visa ", Type: model.AccountCard, Currency: "eur"},
+			want:    &model.Account{Id: 2, Name: "Visa", Type: model.AccountCard, Currency: "EUR"},
+		},
+		{
+			name:    "given an account without name, type and currency, then get one detail each",
+			account: &model.Account{Name: " ", Type: "savings", Currency: "EURO"},
+			wantDetails: []string{
+				"field Name is required",
+				"field Type must be cash, bank or card",
+				"field Currency must be an ISO 4217 code, e.g. USD",
+			},
+		},
+		{
+			name:        "given an account in a currency without cents, then get error",
+			account:     &model.Account{Name: "Tokyo", Type: model.AccountBank, Currency: "jpy"},
+			wantDetails: []string{"currency JPY is not supported, amounts must have 2 decimal places"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := AccountUseCase{Repository: newAccountBook(checkingAccount)}
+			got, err := uc.Save(context.Background(), tt.account)
+			if tt.wantDetails != nil {
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("AccountUseCase.Save() error = %v, want details %q", err, tt.wantDetails)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AccountUseCase.Save() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountUseCase_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository port.AccountRepository
+		account    *model.Account
+		wantErr    error
+	}{
+		{
+			name:       "given an account, then close it",
+			repository: newAccountBook(checkingAccount),
+			account:    &model.Account{Id: 1, Name: "Checking", Type: model.AccountBank, Currency: "USD", Closed: true},
+		},
+		{
+			name:       "given a missing account, then get not found",
+			repository: newAccountBook(),
+			account:    &model.Account{Id: 1, Name: "Checking", Type: model.AccountBank, Currency: "USD"},
+			wantErr:    &customErrors.ItemNotFound{},
+		},
+		{
+			name: "given a failing repository, then get update error",
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn: func(ctx context.Context, id int) (bool, error) {
+					return true, nil
+				},
+				FindByIDFn: func(ctx context.Context, id int) (*model.Account, error) {
+					return &checkingAccount, nil
+				},
+				UpdateFn: func(ctx context.Context, a *model.Account) (*model.Account, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			account: &model.Account{Id: 1, Name: "Checking", Type: model.AccountBank, Currency: "USD"},
+			wantErr: &customErrors.UpdateItemError{},
+		},
+		{
+			name:       "given a new currency for an account without records, then change it",
+			repository: newAccountBook(checkingAccount),
+			account:    &model.Account{Id: 1, Name: "Checking", Type: model.AccountBank, Currency: "EUR"},
+		},
+		{
+			name: "given a new currency for an account with records, then get error",
+			repository: func() port.AccountRepository {
+				book := newAccountBook(checkingAccount)
+				book.HasRecordsFn = func(ctx context.Context, id int) (bool, error) {
+					return true, nil
+				}
+				return book
+			}(),
+			account: &model.Account{Id: 1, Name: "Checking", Type: model.AccountBank, Currency: "EUR"},
+			wantErr: &customErrors.InvalidItemError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := AccountUseCase{Repository: tt.repository}
+			got, err := uc.Update(context.Background(), tt.account)
+			if tt.wantErr != nil {
+				if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+					t.Errorf("AccountUseCase.Update() error = %T, want %T", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.account) {
+				t.Errorf("AccountUseCase.Update() = %+v, %v, want %+v", got, err, tt.account)
+			}
+		})
+	}
+}
+
+func TestAccountUseCase_Delete(t *testing.T) {
+	uc := AccountUseCase{Repository: newAccountBook(checkingAccount)}
+	if err := uc.Delete(context.Background(), 1); err != nil {
+		t.Errorf("AccountUseCase.Delete() error = %v", err)
+	}
+	var notFound *customErrors.ItemNotFound
+	if err := uc.Delete(context.Background(), 2); !errors.As(err, &notFound) {
+		t.Errorf("AccountUseCase.Delete() of a missing account error = %v, want ItemNotFound", err)
+	}
+
+	uc.Transfers = &mocks.TransferRepositoryMock{
+		FindAllFn: func(ctx context.Context, accountId int) ([]model.Transfer, error) {
+			return []model.Transfer{{Id: 1, FromAccountId: 1, ToAccountId: 2, Amount: 100}}, nil
+		},
+	}
+	var invalid *customErrors.InvalidItemError
+	if err := uc.Delete(context.Background(), 1); !errors.As(err, &invalid) {
+		t.Errorf("AccountUseCase.Delete() of an account with transfers error = %v, want InvalidItemError", err)
+	}
+}
+
+func TestAccountUseCase_Balance(t *testing.T) {
+	uc := AccountUseCase{Repository: newAccountBook(checkingAccount)}
+	got, err := uc.Balance(context.Background(), 1)
+	want := &model.AccountBalance{
+		AccountId: 1, Currency: "USD", OpeningBalance: 100000,
+		TotalIncome: 50000, TotalExpenses: 12550, Balance: 137450,
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("AccountUseCase.Balance() = %+v, %v, want %+v", got, err, want)
+	}
+
+	uc.Transfers = &mocks.TransferRepositoryMock{
+		TotalsFn: func(ctx context.Context, accountId int) (*model.TransferTotals, error) {
+			return &model.TransferTotals{In: 20000, Out: 7450}, nil
+		},
+	}
+	got, err = uc.Balance(context.Background(), 1)
+	want = &model.AccountBalance{
+		AccountId: 1, Currency: "USD", OpeningBalance: 100000,
+		TotalIncome: 50000, TotalExpenses: 12550, TransfersIn: 20000, TransfersOut: 7450, Balance: 150000,
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("AccountUseCase.Balance() with transfers = %+v, %v, want %+v", got, err, want)
+	}
+	uc.Transfers = nil
+
+	var notFound *customErrors.ItemNotFound
+	if _, err := uc.Balance(context.Background(), 2); !errors.As(err, &notFound) {
+		t.Errorf("AccountUseCase.Balance() of a missing account error = %v, want ItemNotFound", err)
+	}
+
+	failing := newAccountBook(checkingAccount)
+	failing.TotalsFn = func(ctx context.Context, id int) (*model.BalanceTotals, error) {
+		return nil, errors.ErrUnsupported
+	}
+	uc.Repository = failing
+	if _, err := uc.Balance(context.Background(), 1); err == nil {
+		t.Errorf("AccountUseCase.Balance() error = nil, want error")
+	}
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	BalanceName    = "balance"
+	MaxBalanceDays = 3660
+)
+
+type BalanceUseCase struct {
+	Repository port.BalanceRepository
+	// Converter turns the amounts of every currency into the reporting one.
+	Converter CurrencyConverter
+}
+
+// Calculate returns the balance for the days between from and to, both
+// inclusive, with the running balance at the end of every day. Amounts are
+// converted into the reporting currency with the rate of their day.
+func (uc BalanceUseCase) Calculate(ctx context.Context, from, to time.Time) (*model.Balance, error) {
+	from = truncateDay(from)
+	to = truncateDay(to)
+	if to.Before(from) {
+		return nil, errors.NewInvalidItemError(BalanceName, "field from must not be after to")
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > MaxBalanceDays {
+		return nil, errors.NewInvalidItemError(BalanceName,
+			fmt.Sprintf("date range must not exceed %d days", MaxBalanceDays))
+	}
+	end := to.AddDate(0, 0, 1)
+
+	before, err := uc.Repository.TotalsBefore(ctx, from, uc.Converter.Currency)
+	if err != nil {
+		return nil, errors.NewFindItemError(BalanceName)
+	}
+	opening, err := uc.Converter.ConvertTotals(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := uc.Repository.DailyTotals(ctx, from, end)
+	if err != nil {
+		return nil, errors.NewFindItemError(BalanceName)
+	}
+	movements, err := uc.Converter.ConvertTotals(ctx, daily)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &model.Balance{
+		From:     from,
+		To:       to,
+		Currency: uc.Converter.Currency,
+	}
+	for _, t := range opening {
+		balance.OpeningBalance += t.Income - t.Expenses
+	}
+	for _, m := range movements {
+		balance.TotalIncome += m.Income
+		balance.TotalExpenses += m.Expenses
+	}
+	balance.ClosingBalance = balance.OpeningBalance + balance.TotalIncome - balance.TotalExpenses
+	balance.Daily = runningBalance(from, days, balance.OpeningBalance, movements)
+
+	return balance, nil
+}
+
+func runningBalance(from time.Time, days int, opening model.Money,
+	movements []model.CurrencyTotals) []model.DailyBalance {
+	byDay := make(map[time.Time]model.CurrencyTotals, len(movements))
+	for _, m := range movements {
+		byDay[truncateDay(m.Date)] = m
+	}
+
+	daily := make([]model.DailyBalance, 0, days)
+	running := opening
+	for i := 0; i < days; i++ {
+		day := from.AddDate(0, 0, i)
+		m := byDay[day]
+		running += m.Income - m.Expenses
+		daily = append(daily, model.DailyBalance{
+			Date:     day,
+			Income:   m.Income,
+			Expenses: m.Expenses,
+			Balance:  running,
+		})
+	}
+	return daily
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
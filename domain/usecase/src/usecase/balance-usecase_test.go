@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+func TestBalanceUseCaseCalculate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 4, d, 0, 0, 0, 0, time.UTC) }
+	rates := newRateTable(map[string]model.Rate{"EUR/USD": 110000000, "EUR/GBP": 80000000})
+	type fields struct {
+		repository port.BalanceRepository
+	}
+	type args struct {
+		from time.Time
+		to   time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *model.Balance
+		wantErr bool
+	}{
+		{
+			name: "given a date range, then get the balance with a running balance per day",
+			fields: fields{
+				repository: &mocks.BalanceRepositoryMock{
+					TotalsBeforeFn: func(ctx context.Context, date time.Time, currency string) ([]model.CurrencyTotals, error) {
+						if !date.Equal(day(1)) || currency != "USD" {
+							t.Errorf("TotalsBefore() = %v in %q, want %v in USD", date, currency, day(1))
+						}
+						return []model.CurrencyTotals{{Date: day(0), Income: 100000, Expenses: 40000}}, nil
+					},
+					DailyTotalsFn: func(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error) {
+						if !from.Equal(day(1)) || !to.Equal(day(4)) {
+							t.Errorf("DailyTotals() range = %v - %v, want %v - %v", from, to, day(1), day(4))
+						}
+						return []model.CurrencyTotals{
+							{Date: day(1), Income: 50000, Expenses: 10000},
+							{Date: day(3), Expenses: 5000},
+						}, nil
+					},
+				},
+			},
+			args: args{from: day(1), to: time.Date(2023, 4, 3, 18, 30, 0, 0, time.UTC)},
+			want: &model.Balance{
+				From:           day(1),
+				To:             day(3),
+				Currency:       "USD",
+				OpeningBalance: 60000,
+				TotalIncome:    50000,
+				TotalExpenses:  15000,
+				ClosingBalance: 95000,
+				Daily: []model.DailyBalance{
+					{Date: day(1), Income: 50000, Expenses: 10000, Balance: 100000},
+					{Date: day(2), Balance: 100000},
+					{Date: day(3), Expenses: 5000, Balance: 95000},
+				},
+			},
+		},
+		{
+			name: "given amounts in other currencies, then convert them with the rate of their day",
+			fields: fields{
+				repository: &mocks.BalanceRepositoryMock{
+					TotalsBeforeFn: func(context.Context, time.Time, string) ([]model.CurrencyTotals, error) {
+						return []model.CurrencyTotals{{Date: day(0), Currency: "EUR", Income: 10000}}, nil
+					},
+					DailyTotalsFn: func(context.Context, time.Time, time.Time) ([]model.CurrencyTotals, error) {
+						return []model.CurrencyTotals{
+							{Date: day(1), Currency: "USD", Expenses: 1000},
+							{Date: day(1), Currency: "EUR", Expenses: 1000},
+							{Date: day(2), Currency: "GBP", Income: 2000},
+						}, nil
+					},
+				},
+			},
+			args: args{from: day(1), to: day(2)},
+			want: &model.Balance{
+				From:           day(1),
+				To:             day(2),
+				Currency:       "USD",
+				OpeningBalance: 11000,
+				TotalIncome:    2750,
+				TotalExpenses:  2100,
+				ClosingBalance: 11650,
+				Daily: []model.DailyBalance{
+					{Date: day(1), Expenses: 2100, Balance: 8900},
+					{Date: day(2), Income: 2750, Balance: 11650},
+				},
+			},
+		},
+		{
+			name: "given an amount in a currency without rates, then get error",
+			fields: fields{
+				repository: &mocks.BalanceRepositoryMock{
+					TotalsBeforeFn: func(context.Context, time.Time, string) ([]model.CurrencyTotals, error) {
+						return []model.CurrencyTotals{{Date: day(0), Currency: "JPY", Income: 10000}}, nil
+					},
+				},
+			},
+			args:    args{from: day(1), to: day(2)},
+			wantErr: true,
+		},
+		{
+			name:    "given a date range, when from is after to, then get error",
+			args:    args{from: day(3), to: day(1)},
+			wantErr: true,
+		},
+		{
+			name:    "given a date range, when the range is too long, then get error",
+			args:    args{from: day(1), to: day(1).AddDate(0, 0, MaxBalanceDays)},
+			wantErr: true,
+		},
+		{
+			name: "given a date range, when the opening totals fail, then get error",
+			fields: fields{
+				repository: &mocks.BalanceRepositoryMock{
+					TotalsBeforeFn: func(context.Context, time.Time, string) ([]model.CurrencyTotals, error) {
+						return nil, errors.ErrUnsupported
+					},
+				},
+			},
+			args:    args{from: day(1), to: day(3)},
+			wantErr: true,
+		},
+		{
+			name: "given a date range, when the daily totals fail, then get error",
+			fields: fields{
+				repository: &mocks.BalanceRepositoryMock{
+					TotalsBeforeFn: func(context.Context, time.Time, string) ([]model.CurrencyTotals, error) {
+						return nil, nil
+					},
+					DailyTotalsFn: func(context.Context, time.Time, time.Time) ([]model.CurrencyTotals, error) {
+						return nil, errors.ErrUnsupported
+					},
+				},
+			},
+			args:    args{from: day(1), to: day(3)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := BalanceUseCase{
+				Repository: tt.fields.repository,
+				Converter:  CurrencyConverter{Rates: rates, Currency: "USD"},
+			}
+			got, err := uc.Calculate(context.Background(), tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BalanceUseCase.Calculate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BalanceUseCase.Calculate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
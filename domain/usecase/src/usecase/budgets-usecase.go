@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	BudgetName     = "budget"
+	BudgetIfExists = "budget if exists"
+)
+
+type BudgetUseCase struct {
+	Repository port.BudgetRepository
+	Categories port.CategoryRepository
+	// Converter turns what was spent in every currency into the reporting
+	// one, the currency of the limits.
+	Converter CurrencyConverter
+	// Now returns the current time and decides which period is the current
+	// one. It defaults to time.Now.
+	Now func() time.Time
+}
+
+func (uc BudgetUseCase) FindByID(ctx context.Context, id int) (*model.Budget, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(BudgetIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(BudgetName)
+	}
+	return uc.Repository.FindByID(ctx, id)
+}
+
+func (uc BudgetUseCase) FindAll(ctx context.Context) ([]model.Budget, error) {
+	return uc.Repository.FindAll(ctx)
+}
+
+func (uc BudgetUseCase) Save(ctx context.Context, budget *model.Budget) (*model.Budget, error) {
+	if budget.Id < 0 {
+		return nil, errors.NewInvalidItemError(BudgetName, "field Id must be a positive integer")
+	}
+	exists, err := uc.Repository.Exists(ctx, budget.Id)
+	if err != nil {
+		return nil, errors.NewFindItemError(BudgetIfExists)
+	}
+	if exists {
+		return nil, errors.NewItemAlreadyExistsError(BudgetName)
+	}
+	if err := uc.validateBudget(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.Repository.Save(ctx, budget)
+	if err != nil {
+		return nil, errors.NewSaveItemError(BudgetName)
+	}
+
+	return result, nil
+}
+
+func (uc BudgetUseCase) Update(ctx context.Context, budget *model.Budget) (*model.Budget, error) {
+	exists, err := uc.Repository.Exists(ctx, budget.Id)
+	if err != nil {
+		return nil, errors.NewFindItemError(BudgetIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(BudgetName)
+	}
+	if err := uc.validateBudget(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.Repository.Update(ctx, budget)
+	if err != nil {
+		return nil, errors.NewUpdateItemError(BudgetName)
+	}
+
+	return result, nil
+}
+
+func (uc BudgetUseCase) Delete(ctx context.Context, id int) error {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return errors.NewFindItemError(BudgetIfExists)
+	}
+	if !exists {
+		return errors.NewItemNotFoundError(BudgetName)
+	}
+
+	if err := uc.Repository.Delete(ctx, id); err != nil {
+		return errors.NewDeleteItemError(BudgetName)
+	}
+
+	return nil
+}
+
+// Status returns how much of the budget has been spent so far in its period.
+func (uc BudgetUseCase) Status(ctx context.Context, id int) (*model.BudgetStatus, error) {
+	budget, err := uc.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.status(ctx, *budget)
+}
+
+// StatusByPeriod returns the status of every budget of period. An empty
+// period means the current one.
+func (uc BudgetUseCase) StatusByPeriod(ctx context.Context, period string) ([]model.BudgetStatus, error) {
+	if period == "" {
+		period = uc.now().UTC().Format(model.BudgetPeriodLayout)
+	}
+	if _, _, err := periodRange(period); err != nil {
+		return nil, err
+	}
+
+	budgets, err := uc.Repository.FindByPeriod(ctx, period)
+	if err != nil {
+		return nil, errors.NewFindItemError(BudgetName)
+	}
+
+	statuses := make([]model.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := uc.status(ctx, budget)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, nil
+}
+
+func (uc BudgetUseCase) status(ctx context.Context, budget model.Budget) (*model.BudgetStatus, error) {
+	from, end, err := periodRange(budget.Period)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := uc.Repository.Spent(ctx, budget.CategoryId, from, end)
+	if err != nil {
+		return nil, errors.NewFindItemError(BudgetName)
+	}
+	converted, err := uc.Converter.ConvertTotals(ctx, totals)
+	if err != nil {
+		return nil, err
+	}
+	var spent model.Money
+	for _, t := range converted {
+		spent += t.Expenses
+	}
+
+	return &model.BudgetStatus{
+		Budget:     budget,
+		From:       from,
+		To:         end.AddDate(0, 0, -1),
+		Spent:      spent,
+		Remaining:  budget.Limit - spent,
+		Percentage: percentage(spent, budget.Limit),
+	}, nil
+}
+
+// validateBudget checks the period, the limit and the category of budget,
+// and that no other budget already covers the same category and period.
+func (uc BudgetUseCase) validateBudget(ctx context.Context, budget *model.Budget) error {
+	if _, _, err := periodRange(budget.Period); err != nil {
+		return err
+	}
+	if budget.Limit <= 0 {
+		return errors.NewInvalidItemError(BudgetName, "field Limit must be greater than zero")
+	}
+
+	exists, err := uc.Categories.Exists(ctx, budget.CategoryId)
+	if err != nil {
+		return errors.NewFindItemError(CategoryIfExists)
+	}
+	if !exists {
+		return errors.NewInvalidItemError(BudgetName,
+			fmt.Sprintf("category %d does not exist", budget.CategoryId))
+	}
+
+	budgets, err := uc.Repository.FindByPeriod(ctx, budget.Period)
+	if err != nil {
+		return errors.NewFindItemError(BudgetName)
+	}
+	for _, b := range budgets {
+		if b.CategoryId == budget.CategoryId && b.Id != budget.Id {
+			return errors.NewItemAlreadyExistsError(BudgetName)
+		}
+	}
+	return nil
+}
+
+func (uc BudgetUseCase) now() time.Time {
+	if uc.Now == nil {
+		return time.Now()
+	}
+	return uc.Now()
+}
+
+// periodRange returns the half-open interval [from, end) covered by period.
+func periodRange(period string) (time.Time, time.Time, error) {
+	from, err := time.Parse(model.BudgetPeriodLayout, period)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewInvalidItemError(BudgetName,
+			fmt.Sprintf("field Period must have the format %s", model.BudgetPeriodLayout))
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+// percentage returns spent as a percentage of limit, rounded to two decimals.
+func percentage(spent, limit model.Money) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return math.Round(float64(spent)*10000/float64(limit)) / 100
+}
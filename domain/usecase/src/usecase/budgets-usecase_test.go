@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+func TestBudgetUseCaseSave(t *testing.T) {
+	categories := newCategoryTree(model.Category{Id: 1, Name: "food"})
+	tests := []struct {
+		name       string
+		repository port.BudgetRepository
+		budget     *model.Budget
+		want       *model.Budget
+		wantErr    bool
+	}{
+		{
+			name: "given a budget, then save with success",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:       func(ctx context.Context, i int) (bool, error) { return false, nil },
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) { return []model.Budget{}, nil },
+				SaveFn: func(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+					b.Id = 3
+					return b, nil
+				},
+			},
+			budget: &model.Budget{CategoryId: 1, Period: "2023-04", Limit: 50000},
+			want:   &model.Budget{Id: 3, CategoryId: 1, Period: "2023-04", Limit: 50000},
+		},
+		{
+			name: "given a budget, when the period is invalid, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			budget:  &model.Budget{CategoryId: 1, Period: "04/2023", Limit: 50000},
+			wantErr: true,
+		},
+		{
+			name: "given a budget, when the limit is negative, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			budget:  &model.Budget{CategoryId: 1, Period: "2023-04", Limit: -100},
+			wantErr: true,
+		},
+		{
+			name: "given a budget, when the category doesn't exists, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			budget:  &model.Budget{CategoryId: 7, Period: "2023-04", Limit: 50000},
+			wantErr: true,
+		},
+		{
+			name: "given a budget, when the category already has a budget in the period, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) {
+					return []model.Budget{{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 10000}}, nil
+				},
+			},
+			budget:  &model.Budget{CategoryId: 1, Period: "2023-04", Limit: 50000},
+			wantErr: true,
+		},
+		{
+			name: "given a budget, when try to save in database, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:       func(ctx context.Context, i int) (bool, error) { return false, nil },
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) { return []model.Budget{}, nil },
+				SaveFn: func(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			budget:  &model.Budget{CategoryId: 1, Period: "2023-04", Limit: 50000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := BudgetUseCase{Repository: tt.repository, Categories: categories}
+			got, err := uc.Save(context.Background(), tt.budget)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BudgetUseCase.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BudgetUseCase.Save() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetUseCaseUpdate(t *testing.T) {
+	categories := newCategoryTree(model.Category{Id: 1, Name: "food"})
+	existing := []model.Budget{{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 10000}}
+	tests := []struct {
+		name       string
+		repository port.BudgetRepository
+		budget     *model.Budget
+		want       *model.Budget
+		wantErr    bool
+	}{
+		{
+			name: "given a budget, when change its limit, then update with success",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:       func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) { return existing, nil },
+				UpdateFn:       func(ctx context.Context, b *model.Budget) (*model.Budget, error) { return b, nil },
+			},
+			budget: &model.Budget{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 20000},
+			want:   &model.Budget{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 20000},
+		},
+		{
+			name: "given a budget, when the budget doesn't exists, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			budget:  &model.Budget{Id: 9, CategoryId: 1, Period: "2023-04", Limit: 20000},
+			wantErr: true,
+		},
+		{
+			name: "given a budget, when get an error on update in database, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:       func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) { return existing, nil },
+				UpdateFn: func(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			budget:  &model.Budget{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 20000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := BudgetUseCase{Repository: tt.repository, Categories: categories}
+			got, err := uc.Update(context.Background(), tt.budget)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BudgetUseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BudgetUseCase.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetUseCaseStatus(t *testing.T) {
+	budget := model.Budget{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 30000}
+	tests := []struct {
+		name       string
+		repository port.BudgetRepository
+		want       *model.BudgetStatus
+		wantErr    bool
+	}{
+		{
+			name: "given an id, then get the spent in the reporting currency, remaining and percentage of the period",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:   func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Budget, error) { return &budget, nil },
+				SpentFn: func(ctx context.Context, categoryId int, from, to time.Time) ([]model.CurrencyTotals, error) {
+					wantFrom := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+					wantTo := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+					if categoryId != 1 || !from.Equal(wantFrom) || !to.Equal(wantTo) {
+						t.Errorf("Spent() = %d, %v - %v, want %d, %v - %v",
+							categoryId, from, to, 1, wantFrom, wantTo)
+					}
+					return []model.CurrencyTotals{
+						{Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Expenses: 4000},
+						{Date: time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC), Currency: "EUR", Expenses: 5000},
+					}, nil
+				},
+			},
+			want: &model.BudgetStatus{
+				Budget:     budget,
+				From:       time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:         time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC),
+				Spent:      10000,
+				Remaining:  20000,
+				Percentage: 33.33,
+			},
+		},
+		{
+			name: "given an id, when the limit is exceeded, then get a negative remaining",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:   func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Budget, error) { return &budget, nil },
+				SpentFn: func(context.Context, int, time.Time, time.Time) ([]model.CurrencyTotals, error) {
+					return []model.CurrencyTotals{{Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Expenses: 45000}}, nil
+				},
+			},
+			want: &model.BudgetStatus{
+				Budget:     budget,
+				From:       time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:         time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC),
+				Spent:      45000,
+				Remaining:  -15000,
+				Percentage: 150,
+			},
+		},
+		{
+			name: "given an id, when the budget doesn't exists, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an id, when the spent query fails, then get error",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:   func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Budget, error) { return &budget, nil },
+				SpentFn: func(context.Context, int, time.Time, time.Time) ([]model.CurrencyTotals, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := BudgetUseCase{
+				Repository: tt.repository,
+				Converter:  CurrencyConverter{Rates: newRateTable(map[string]model.Rate{"EUR/USD": 120000000}), Currency: "USD"},
+			}
+			got, err := uc.Status(context.Background(), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BudgetUseCase.Status() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BudgetUseCase.Status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetUseCaseStatusByPeriod(t *testing.T) {
+	now := func() time.Time { return time.Date(2023, 6, 14, 10, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name       string
+		period     string
+		wantPeriod string
+		wantLen    int
+		wantErr    bool
+	}{
+		{
+			name:       "given no period, then get the status of the current period",
+			wantPeriod: "2023-06",
+			wantLen:    2,
+		},
+		{
+			name:       "given a period, then get the status of its budgets",
+			period:     "2023-04",
+			wantPeriod: "2023-04",
+			wantLen:    2,
+		},
+		{
+			name:    "given an invalid period, then get error",
+			period:  "2023-13",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &mocks.BudgetRepositoryMock{
+				FindByPeriodFn: func(ctx context.Context, period string) ([]model.Budget, error) {
+					if period != tt.wantPeriod {
+						t.Errorf("FindByPeriod() period = %s, want %s", period, tt.wantPeriod)
+					}
+					return []model.Budget{
+						{Id: 1, CategoryId: 1, Period: period, Limit: 10000},
+						{Id: 2, CategoryId: 2, Period: period, Limit: 20000},
+					}, nil
+				},
+				SpentFn: func(context.Context, int, time.Time, time.Time) ([]model.CurrencyTotals, error) {
+					return []model.CurrencyTotals{{Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Expenses: 5000}}, nil
+				},
+			}
+			uc := BudgetUseCase{Repository: repository, Now: now}
+			got, err := uc.StatusByPeriod(context.Background(), tt.period)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BudgetUseCase.StatusByPeriod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("BudgetUseCase.StatusByPeriod() len = %d, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	CategoryName     = "category"
+	CategoryIfExists = "category if exists"
+
+	// MaxCategoryDepth bounds how many ancestors are walked when looking for
+	// cycles, so a corrupted hierarchy can't loop forever.
+	MaxCategoryDepth = 32
+)
+
+type CategoryUseCase struct {
+	Repository port.CategoryRepository
+	// Transactions makes the parent checks and the write of Save and Update
+	// atomic, so concurrent changes can't turn the hierarchy into a cycle.
+	Transactions port.UnitOfWork
+}
+
+func (uc CategoryUseCase) FindByID(ctx context.Context, id int) (*model.Category, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(CategoryIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(CategoryName)
+	}
+	return uc.Repository.FindByID(ctx, id)
+}
+
+func (uc CategoryUseCase) FindAll(ctx context.Context) ([]model.Category, error) {
+	return uc.Repository.FindAll(ctx)
+}
+
+func (uc CategoryUseCase) Save(ctx context.Context, category *model.Category) (*model.Category, error) {
+	if category.Id < 0 {
+		return nil, errors.NewInvalidItemError(CategoryName, "field Id must be a positive integer")
+	}
+
+	var result *model.Category
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), nil, func(repos port.Repositories) error {
+		exists, err := repos.Categories.Exists(ctx, category.Id)
+		if err != nil {
+			return errors.NewFindItemError(CategoryIfExists)
+		}
+		if exists {
+			return errors.NewItemAlreadyExistsError(CategoryName)
+		}
+		if err := validateParent(ctx, repos.Categories, category); err != nil {
+			return err
+		}
+
+		if result, err = repos.Categories.Save(ctx, category); err != nil {
+			return errors.NewSaveItemError(CategoryName)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc CategoryUseCase) Update(ctx context.Context, category *model.Category) (*model.Category, error) {
+	var result *model.Category
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), nil, func(repos port.Repositories) error {
+		exists, err := repos.Categories.Exists(ctx, category.Id)
+		if err != nil {
+			return errors.NewFindItemError(CategoryIfExists)
+		}
+		if !exists {
+			return errors.NewItemNotFoundError(CategoryName)
+		}
+		if err := validateParent(ctx, repos.Categories, category); err != nil {
+			return err
+		}
+
+		if result, err = repos.Categories.Update(ctx, category); err != nil {
+			return errors.NewUpdateItemError(CategoryName)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc CategoryUseCase) Delete(ctx context.Context, id int) error {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return errors.NewFindItemError(CategoryIfExists)
+	}
+	if !exists {
+		return errors.NewItemNotFoundError(CategoryName)
+	}
+
+	if err := uc.Repository.Delete(ctx, id); err != nil {
+		return errors.NewDeleteItemError(CategoryName)
+	}
+
+	return nil
+}
+
+// repositories are the ones fn runs on without Transactions.
+func (uc CategoryUseCase) repositories() port.Repositories {
+	return port.Repositories{Categories: uc.Repository}
+}
+
+// validateParent checks that the parent category exists and that making it
+// the parent would not turn the hierarchy into a cycle.
+func validateParent(ctx context.Context, categories port.CategoryRepository, category *model.Category) error {
+	if category.ParentId == 0 {
+		return nil
+	}
+	if category.ParentId < 0 {
+		return errors.NewInvalidItemError(CategoryName, "field ParentId must be a positive integer")
+	}
+
+	ancestorId := category.ParentId
+	for depth := 0; ancestorId != 0; depth++ {
+		if ancestorId == category.Id {
+			return errors.NewInvalidItemError(CategoryName,
+				"a category can't be its own ancestor")
+		}
+		if depth == MaxCategoryDepth {
+			return errors.NewInvalidItemError(CategoryName,
+				fmt.Sprintf("category hierarchy can't be deeper than %d levels", MaxCategoryDepth))
+		}
+		exists, err := categories.Exists(ctx, ancestorId)
+		if err != nil {
+			return errors.NewFindItemError(CategoryIfExists)
+		}
+		if !exists {
+			return errors.NewInvalidItemError(CategoryName,
+				fmt.Sprintf("parent category %d does not exist", ancestorId))
+		}
+		ancestor, err := categories.FindByID(ctx, ancestorId)
+		if err != nil {
+			return errors.NewFindItemError(CategoryName)
+		}
+		ancestorId = ancestor.ParentId
+	}
+
+	return nil
+}
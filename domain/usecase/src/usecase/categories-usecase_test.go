@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+// newCategoryTree returns a mock backed by the given categories, keyed by id.
+func newCategoryTree(categories ...model.Category) *mocks.CategoryRepositoryMock {
+	byId := map[int]model.Category{}
+	for _, c := range categories {
+		byId[c.Id] = c
+	}
+	return &mocks.CategoryRepositoryMock{
+		ExistsFn: func(ctx context.Context, id int) (bool, error) {
+			_, ok := byId[id]
+			return ok, nil
+		},
+		FindByIDFn: func(ctx context.Context, id int) (*model.Category, error) {
+			c := byId[id]
+			return &c, nil
+		},
+		SaveFn: func(ctx context.Context, c *model.Category) (*model.Category, error) {
+			return c, nil
+		},
+		UpdateFn: func(ctx context.Context, c *model.Category) (*model.Category, error) {
+			return c, nil
+		},
+	}
+}
+
+func TestCategoryUseCaseFindByID(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository port.CategoryRepository
+		id         int
+		want       *model.Category
+		wantErr    bool
+	}{
+		{
+			name:       "given an id then get a category model",
+			repository: newCategoryTree(model.Category{Id: 1, Name: "food"}),
+			id:         1,
+			want:       &model.Category{Id: 1, Name: "food"},
+		},
+		{
+			name:       "given an id, when the category not exists then get an error",
+			repository: newCategoryTree(),
+			id:         1,
+			wantErr:    true,
+		},
+		{
+			name: "given an id, when check if the category exists, then get an error",
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) {
+					return false, errors.ErrUnsupported
+				},
+			},
+			id:      1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := CategoryUseCase{Repository: tt.repository}
+			got, err := uc.FindByID(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategoryUseCase.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CategoryUseCase.FindByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryUseCaseSave(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository port.CategoryRepository
+		category   *model.Category
+		want       *model.Category
+		wantErr    bool
+	}{
+		{
+			name:       "given a top-level category, then save with success",
+			repository: newCategoryTree(),
+			category:   &model.Category{Name: "food"},
+			want:       &model.Category{Name: "food"},
+		},
+		{
+			name:       "given a subcategory, then save with success",
+			repository: newCategoryTree(model.Category{Id: 1, Name: "food"}),
+			category:   &model.Category{Name: "groceries", ParentId: 1},
+			want:       &model.Category{Name: "groceries", ParentId: 1},
+		},
+		{
+			name:       "given a subcategory, when the parent doesn't exists, then get error",
+			repository: newCategoryTree(),
+			category:   &model.Category{Name: "groceries", ParentId: 1},
+			wantErr:    true,
+		},
+		{
+			name:       "given a category, when the id is undefined, then get error",
+			repository: newCategoryTree(),
+			category:   &model.Category{Id: -1, Name: "food"},
+			wantErr:    true,
+		},
+		{
+			name:       "given a category, when exists in database, then get error",
+			repository: newCategoryTree(model.Category{Id: 1, Name: "food"}),
+			category:   &model.Category{Id: 1, Name: "food"},
+			wantErr:    true,
+		},
+		{
+			name: "given a category, when try to save in database, then get error",
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) {
+					return false, nil
+				},
+				SaveFn: func(ctx context.Context, c *model.Category) (*model.Category, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			category: &model.Category{Name: "food"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := CategoryUseCase{Repository: tt.repository}
+			got, err := uc.Save(context.Background(), tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategoryUseCase.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CategoryUseCase.Save() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryUseCaseUpdate(t *testing.T) {
+	tree := newCategoryTree(
+		model.Category{Id: 1, Name: "food"},
+		model.Category{Id: 2, Name: "groceries", ParentId: 1},
+		model.Category{Id: 3, Name: "vegetables", ParentId: 2},
+	)
+	tests := []struct {
+		name       string
+		repository port.CategoryRepository
+		category   *model.Category
+		want       *model.Category
+		wantErr    bool
+	}{
+		{
+			name:       "given a category, when move it under another category, then update with success",
+			repository: tree,
+			category:   &model.Category{Id: 3, Name: "vegetables", ParentId: 1},
+			want:       &model.Category{Id: 3, Name: "vegetables", ParentId: 1},
+		},
+		{
+			name:       "given a category, when it is its own parent, then get error",
+			repository: tree,
+			category:   &model.Category{Id: 2, Name: "groceries", ParentId: 2},
+			wantErr:    true,
+		},
+		{
+			name:       "given a category, when move it under a descendant, then get error",
+			repository: tree,
+			category:   &model.Category{Id: 1, Name: "food", ParentId: 3},
+			wantErr:    true,
+		},
+		{
+			name:       "given a category, when the category doesn't exists, then get error",
+			repository: tree,
+			category:   &model.Category{Id: 9, Name: "travel"},
+			wantErr:    true,
+		},
+		{
+			name: "given a category, when get an error on update in database, then get error",
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) {
+					return true, nil
+				},
+				UpdateFn: func(ctx context.Context, c *model.Category) (*model.Category, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			category: &model.Category{Id: 1, Name: "food"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := CategoryUseCase{Repository: tt.repository}
+			got, err := uc.Update(context.Background(), tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategoryUseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CategoryUseCase.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryUseCaseDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository port.CategoryRepository
+		id         int
+		wantErr    bool
+	}{
+		{
+			name: "given an id, then delete with success",
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				DeleteFn: func(ctx context.Context, i int) error { return nil },
+			},
+			id: 1,
+		},
+		{
+			name:       "given an id, when the category doesn't exists, then get error",
+			repository: newCategoryTree(),
+			id:         1,
+			wantErr:    true,
+		},
+		{
+			name: "given an id, when get an error on delete in database, then get error",
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				DeleteFn: func(ctx context.Context, i int) error { return errors.ErrUnsupported },
+			},
+			id:      1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := CategoryUseCase{Repository: tt.repository}
+			if err := uc.Delete(context.Background(), tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("CategoryUseCase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCategoryUseCase_Transactions(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     func(uc CategoryUseCase) error
+		commitErr  error
+		wantErr    bool
+		wantRunErr bool
+	}{
+		{
+			name: "given a unit of work, then check the parent and save through its repositories",
+			change: func(uc CategoryUseCase) error {
+				_, err := uc.Save(context.Background(), &model.Category{Name: "groceries", ParentId: 1})
+				return err
+			},
+		},
+		{
+			name: "given a unit of work, then check the parent and update through its repositories",
+			change: func(uc CategoryUseCase) error {
+				_, err := uc.Update(context.Background(), &model.Category{Id: 2, Name: "groceries", ParentId: 1})
+				return err
+			},
+		},
+		{
+			name: "given a unit of work, when the parent would make a cycle, then the unit of work gets the error",
+			change: func(uc CategoryUseCase) error {
+				_, err := uc.Update(context.Background(), &model.Category{Id: 1, Name: "food", ParentId: 2})
+				return err
+			},
+			wantErr:    true,
+			wantRunErr: true,
+		},
+		{
+			name: "given a unit of work, when the commit fails, then get error",
+			change: func(uc CategoryUseCase) error {
+				_, err := uc.Save(context.Background(), &model.Category{Name: "groceries", ParentId: 1})
+				return err
+			},
+			commitErr: errors.ErrUnsupported,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runErr error
+			uow := &mocks.UnitOfWorkMock{
+				Repositories: port.Repositories{Categories: newCategoryTree(
+					model.Category{Id: 1, Name: "food"},
+					model.Category{Id: 2, Name: "groceries", ParentId: 1},
+				)},
+			}
+			uow.RunFn = func(ctx context.Context, fn func(port.Repositories) error) error {
+				if runErr = fn(uow.Repositories); runErr != nil {
+					return runErr
+				}
+				return tt.commitErr
+			}
+			// the use case's own repository must not be used inside the unit of work
+			uc := CategoryUseCase{
+				Repository: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, errors.ErrUnsupported },
+				},
+				Transactions: uow,
+			}
+
+			if err := tt.change(uc); (err != nil) != tt.wantErr {
+				t.Errorf("CategoryUseCase change error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (runErr != nil) != tt.wantRunErr {
+				t.Errorf("CategoryUseCase change unit of work error = %v, wantRunErr %v", runErr, tt.wantRunErr)
+			}
+		})
+	}
+}
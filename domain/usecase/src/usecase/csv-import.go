@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"encoding/csv"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+)
+
+const (
+	CSVMappingName = "csv mapping"
+
+	DefaultCSVDateFormat = "YYYY-MM-DD"
+)
+
+// dateFormatPlaceholders turns a DD/MM/YYYY like format into a Go layout.
+var dateFormatPlaceholders = strings.NewReplacer("YYYY", "2006", "MM", "01", "DD", "02")
+
+// normalizeCSVMapping fills the defaults of mapping and rejects the layouts
+// that can't be read.
+func normalizeCSVMapping(mapping model.CSVMapping) (model.CSVMapping, error) {
+	details := []string{}
+
+	if mapping.Delimiter == 0 {
+		mapping.Delimiter = ','
+	}
+	if mapping.Delimiter == '"' || mapping.Delimiter == '\r' || mapping.Delimiter == '\n' {
+		details = append(details, fmt.Sprintf("delimiter %q is not supported", mapping.Delimiter))
+	}
+	if mapping.DateColumn == "" {
+		details = append(details, "date column is required")
+	}
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = DefaultCSVDateFormat
+	}
+	switch mapping.DecimalSeparator {
+	case "":
+		mapping.DecimalSeparator = "."
+	case ".", ",":
+	default:
+		details = append(details, fmt.Sprintf("decimal separator %s is not supported", mapping.DecimalSeparator))
+	}
+	if string(mapping.Delimiter) == mapping.DecimalSeparator {
+		details = append(details, "delimiter and decimal separator must differ")
+	}
+
+	switch {
+	case mapping.AmountColumn != "" && (mapping.DebitColumn != "" || mapping.CreditColumn != ""):
+		details = append(details, "use either an amount column or debit and credit columns")
+	case mapping.AmountColumn != "":
+		switch mapping.Sign {
+		case "":
+			mapping.Sign = model.NegativeExpenses
+		case model.NegativeExpenses, model.PositiveExpenses:
+		default:
+			details = append(details, fmt.Sprintf("sign convention %s is not supported", mapping.Sign))
+		}
+	case mapping.DebitColumn == "" || mapping.CreditColumn == "":
+		details = append(details, "an amount column or both debit and credit columns are required")
+	}
+
+	if len(details) > 0 {
+		return mapping, errors.NewInvalidItemError(CSVMappingName, details...)
+	}
+	return mapping, nil
+}
+
+// parseCSV reads the transactions of a CSV file laid out as mapping says.
+// Every row is checked, and the problems of all of them are reported
+// together in one InvalidItemError.
+func parseCSV(r io.Reader, mapping model.CSVMapping) ([]model.ImportedTransaction, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = mapping.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if goerrors.Is(err, io.EOF) {
+		return nil, errors.NewInvalidItemError(ImportName, "file is empty")
+	}
+	if err != nil {
+		return nil, errors.NewInvalidItemError(ImportName, err.Error())
+	}
+	columns, err := csvColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := []model.ImportedTransaction{}
+	details := []string{}
+	for rows := 1; ; rows++ {
+		record, err := reader.Read()
+		if goerrors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewInvalidItemError(ImportName, err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+		if rows > MaxImportTransactions {
+			return nil, errors.NewInvalidItemError(ImportName,
+				fmt.Sprintf("a file can hold at most %d transactions", MaxImportTransactions))
+		}
+
+		transaction, problems := parseCSVRecord(record, columns, mapping)
+		if len(problems) > 0 {
+			for _, problem := range problems {
+				details = append(details, fmt.Sprintf("line %d: %s", line, problem))
+			}
+			continue
+		}
+		transaction.Line = line
+		transactions = append(transactions, transaction)
+	}
+
+	if len(details) > 0 {
+		return nil, errors.NewInvalidItemError(ImportName, details...)
+	}
+	if len(transactions) == 0 {
+		return nil, errors.NewInvalidItemError(ImportName, "file has no transactions")
+	}
+	return transactions, nil
+}
+
+// csvColumns finds the position of every mapped column in the header.
+func csvColumns(header []string, mapping model.CSVMapping) (map[string]int, error) {
+	positions := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+
+	columns := map[string]int{}
+	details := []string{}
+	mapped := []string{mapping.DateColumn, mapping.AmountColumn, mapping.DebitColumn, mapping.CreditColumn,
+		mapping.CurrencyColumn}
+	for _, name := range mapped {
+		if name == "" {
+			continue
+		}
+		i, ok := positions[name]
+		if !ok {
+			details = append(details, fmt.Sprintf("column %s is not in the header", name))
+			continue
+		}
+		columns[name] = i
+	}
+	if len(details) > 0 {
+		return nil, errors.NewInvalidItemError(CSVMappingName, details...)
+	}
+	return columns, nil
+}
+
+func parseCSVRecord(record []string, columns map[string]int, mapping model.CSVMapping) (model.ImportedTransaction, []string) {
+	transaction := model.ImportedTransaction{}
+	problems := []string{}
+	field := func(name string) (string, bool) {
+		i := columns[name]
+		if i >= len(record) {
+			problems = append(problems, fmt.Sprintf("column %s is missing", name))
+			return "", false
+		}
+		return strings.TrimSpace(record[i]), true
+	}
+
+	if raw, ok := field(mapping.DateColumn); ok {
+		created, err := time.Parse(dateFormatPlaceholders.Replace(mapping.DateFormat), raw)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("date %q does not match the format %s", raw, mapping.DateFormat))
+		}
+		transaction.Created = created
+	}
+
+	var amount model.Money
+	if mapping.AmountColumn != "" {
+		if raw, ok := field(mapping.AmountColumn); ok {
+			signed, err := parseCSVAmount(raw, mapping.DecimalSeparator)
+			switch {
+			case err != nil:
+				problems = append(problems, err.Error())
+			case signed == 0:
+				problems = append(problems, "amount must not be zero")
+			case (signed < 0) == (mapping.Sign == model.NegativeExpenses):
+				transaction.Kind, amount = model.KindExpense, signed
+			default:
+				transaction.Kind, amount = model.KindIncome, signed
+			}
+		}
+	} else {
+		debit, debitOk := field(mapping.DebitColumn)
+		credit, creditOk := field(mapping.CreditColumn)
+		if debitOk && creditOk {
+			switch {
+			case (debit == "") == (credit == ""):
+				problems = append(problems, "exactly one of the debit and credit columns must hold an amount")
+			case debit != "":
+				transaction.Kind = model.KindExpense
+				amount = parseCSVSide(debit, mapping.DecimalSeparator, &problems)
+			default:
+				transaction.Kind = model.KindIncome
+				amount = parseCSVSide(credit, mapping.DecimalSeparator, &problems)
+			}
+		}
+	}
+	if amount < 0 {
+		amount = -amount
+	}
+	transaction.Amount = amount
+
+	// an empty currency cell leaves the amount in the currency of the account
+	if mapping.CurrencyColumn != "" {
+		if raw, ok := field(mapping.CurrencyColumn); ok {
+			transaction.Currency = strings.ToUpper(raw)
+			if raw != "" && !isCurrencyCode(transaction.Currency) {
+				problems = append(problems, fmt.Sprintf("currency %q is not an ISO 4217 code", raw))
+			} else if !model.SupportsCurrency(transaction.Currency) {
+				problems = append(problems, unsupportedCurrency(transaction.Currency))
+			}
+		}
+	}
+
+	return transaction, problems
+}
+
+func parseCSVSide(raw, decimalSeparator string, problems *[]string) model.Money {
+	amount, err := parseCSVAmount(raw, decimalSeparator)
+	if err != nil {
+		*problems = append(*problems, err.Error())
+	} else if amount == 0 {
+		*problems = append(*problems, "amount must not be zero")
+	}
+	return amount
+}
+
+// parseCSVAmount reads an amount written with decimalSeparator, dropping
+// the other separator, which groups thousands, and any blanks.
+func parseCSVAmount(raw, decimalSeparator string) (model.Money, error) {
+	thousands := ","
+	if decimalSeparator == "," {
+		thousands = "."
+	}
+	clean := strings.NewReplacer(thousands, "", " ", "", "\u00a0", "").Replace(raw)
+	amount, err := model.ParseMoney(strings.Replace(clean, decimalSeparator, ".", 1))
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a valid amount", raw)
+	}
+	return amount, nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	ExchangeRateName = "exchange rate"
+
+	// crossCurrency is the currency pairs without a rate of their own are
+	// converted through. The ECB publishes every rate against the euro.
+	crossCurrency = "EUR"
+)
+
+// CurrencyConverter converts amounts into the reporting Currency with the
+// exchange rate in effect on the day of each amount.
+type CurrencyConverter struct {
+	Rates port.ExchangeRateRepository
+	// Currency is the reporting currency. Amounts without a currency are
+	// taken to be in it, so they are never converted.
+	Currency string
+}
+
+// ConvertTotals converts every group of totals and merges those of the same
+// day, ordered by day. The result is in the reporting currency. The rates of
+// each pair are read once for the whole period the totals span.
+func (c CurrencyConverter) ConvertTotals(ctx context.Context, totals []model.CurrencyTotals) ([]model.CurrencyTotals, error) {
+	history := &rateHistory{pairs: map[[2]string][]model.ExchangeRate{}}
+	for i, t := range totals {
+		day := truncateDay(t.Date)
+		if i == 0 || day.Before(history.from) {
+			history.from = day
+		}
+		if day.After(history.to) {
+			history.to = day
+		}
+	}
+
+	rates := map[rateKey]*big.Rat{}
+	byDay := map[time.Time]*model.CurrencyTotals{}
+	for _, t := range totals {
+		day := truncateDay(t.Date)
+		key := rateKey{currency: t.Currency, day: day}
+		rate, ok := rates[key]
+		if !ok {
+			var err error
+			if rate, err = c.rate(ctx, history, t.Currency, day); err != nil {
+				return nil, err
+			}
+			rates[key] = rate
+		}
+
+		converted, ok := byDay[day]
+		if !ok {
+			converted = &model.CurrencyTotals{Date: day, Currency: c.Currency}
+			byDay[day] = converted
+		}
+		converted.Income += t.Income.Convert(rate)
+		converted.Expenses += t.Expenses.Convert(rate)
+	}
+
+	result := make([]model.CurrencyTotals, 0, len(byDay))
+	for _, t := range byDay {
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result, nil
+}
+
+type rateKey struct {
+	currency string
+	day      time.Time
+}
+
+// rateHistory keeps the rates of every pair looked up while converting, as
+// in effect from from to to, oldest first.
+type rateHistory struct {
+	from, to time.Time
+	pairs    map[[2]string][]model.ExchangeRate
+}
+
+// rate returns what one unit of currency is worth in the reporting currency
+// on date, crossing through crossCurrency when the pair has no rate. The
+// ratio is exact, so amounts are only rounded once, when converted.
+func (c CurrencyConverter) rate(ctx context.Context, history *rateHistory, currency string,
+	date time.Time) (*big.Rat, error) {
+	if currency == "" || currency == c.Currency {
+		return big.NewRat(1, 1), nil
+	}
+	missing := errors.NewInvalidItemError(ExchangeRateName, fmt.Sprintf("no rate from %s to %s on or before %s",
+		currency, c.Currency, date.Format(time.DateOnly)))
+	if c.Rates == nil || c.Currency == "" {
+		return nil, missing
+	}
+
+	rate, found, err := c.pairRate(ctx, history, currency, c.Currency, date)
+	if err != nil || found {
+		return rate, err
+	}
+	if currency == crossCurrency || c.Currency == crossCurrency {
+		return nil, missing
+	}
+	toCross, found, err := c.pairRate(ctx, history, currency, crossCurrency, date)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, missing
+	}
+	fromCross, found, err := c.pairRate(ctx, history, crossCurrency, c.Currency, date)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, missing
+	}
+	return toCross.Mul(toCross, fromCross), nil
+}
+
+// pairRate looks for the rate of base in quote on date, or for the inverse
+// one, among the rates of history. The rates of a pair not in history yet
+// are read and kept.
+func (c CurrencyConverter) pairRate(ctx context.Context, history *rateHistory, base, quote string,
+	date time.Time) (*big.Rat, bool, error) {
+	for _, inverse := range []bool{false, true} {
+		pair := [2]string{base, quote}
+		if inverse {
+			pair = [2]string{quote, base}
+		}
+		rates, ok := history.pairs[pair]
+		if !ok {
+			var err error
+			if rates, err = c.Rates.FindInEffect(ctx, pair[0], pair[1], history.from, history.to); err != nil {
+				return nil, false, errors.NewFindItemError(ExchangeRateName)
+			}
+			history.pairs[pair] = rates
+		}
+
+		// the rate in effect is the latest one on or before date
+		i := sort.Search(len(rates), func(i int) bool { return rates[i].Date.After(date) })
+		if i == 0 || rates[i-1].Rate <= 0 {
+			continue
+		}
+		if inverse {
+			return new(big.Rat).Inv(rates[i-1].Rate.Ratio()), true, nil
+		}
+		return rates[i-1].Rate.Ratio(), true, nil
+	}
+	return nil, false, nil
+}
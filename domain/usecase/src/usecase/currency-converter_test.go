@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+// newRateTable returns a mock with the given rates, keyed by "BASE/QUOTE",
+// in effect on every day.
+func newRateTable(rates map[string]model.Rate) *mocks.ExchangeRateRepositoryMock {
+	return &mocks.ExchangeRateRepositoryMock{
+		FindEffectiveFn: func(ctx context.Context, base, quote string, date time.Time) (*model.ExchangeRate, error) {
+			rate, ok := rates[base+"/"+quote]
+			if !ok {
+				return nil, customErrors.NewItemNotFoundError(ExchangeRateName)
+			}
+			return &model.ExchangeRate{Date: date, Base: base, Quote: quote, Rate: rate}, nil
+		},
+		FindInEffectFn: func(ctx context.Context, base, quote string, from, to time.Time) ([]model.ExchangeRate, error) {
+			rate, ok := rates[base+"/"+quote]
+			if !ok {
+				return []model.ExchangeRate{}, nil
+			}
+			return []model.ExchangeRate{{Date: from, Base: base, Quote: quote, Rate: rate}}, nil
+		},
+	}
+}
+
+// newRateHistory returns an empty history for the days from from to to.
+func newRateHistory(from, to time.Time) *rateHistory {
+	return &rateHistory{from: from, to: to, pairs: map[[2]string][]model.ExchangeRate{}}
+}
+
+func TestCurrencyConverter_ConvertTotals(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 4, d, 0, 0, 0, 0, time.UTC) }
+	rates := newRateTable(map[string]model.Rate{"USD/COP": 450000000000, "EUR/COP": 495000000000, "EUR/GBP": 80000000})
+	tests := []struct {
+		name    string
+		totals  []model.CurrencyTotals
+		want    []model.CurrencyTotals
+		wantErr bool
+	}{
+		{
+			name: "given totals in the reporting currency or without one, then keep them",
+			totals: []model.CurrencyTotals{
+				{Date: day(2), Income: 100},
+				{Date: day(1), Currency: "COP", Expenses: 4500},
+			},
+			want: []model.CurrencyTotals{
+				{Date: day(1), Currency: "COP", Expenses: 4500},
+				{Date: day(2), Currency: "COP", Income: 100},
+			},
+		},
+		{
+			name: "given totals with a direct rate, then multiply them by it",
+			totals: []model.CurrencyTotals{
+				{Date: day(1), Currency: "USD", Income: 1000, Expenses: 1},
+			},
+			want: []model.CurrencyTotals{
+				{Date: day(1), Currency: "COP", Income: 4500000, Expenses: 4500},
+			},
+		},
+		{
+			name: "given totals with a rate through the euro, then cross the rates",
+			totals: []model.CurrencyTotals{
+				{Date: day(1), Currency: "EUR", Expenses: 100},
+				{Date: day(1), Currency: "GBP", Expenses: 80},
+			},
+			want: []model.CurrencyTotals{
+				{Date: day(1), Currency: "COP", Expenses: 990000},
+			},
+		},
+		{
+			name:    "given totals in a currency without rates, then get error",
+			totals:  []model.CurrencyTotals{{Date: day(1), Currency: "JPY", Income: 100}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := CurrencyConverter{Rates: rates, Currency: "COP"}
+			got, err := c.ConvertTotals(context.Background(), tt.totals)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CurrencyConverter.ConvertTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CurrencyConverter.ConvertTotals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyConverter_ConvertTotals_history(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 4, d, 0, 0, 0, 0, time.UTC) }
+	calls := map[string]int{}
+	rates := &mocks.ExchangeRateRepositoryMock{
+		FindInEffectFn: func(ctx context.Context, base, quote string, from, to time.Time) ([]model.ExchangeRate, error) {
+			calls[base+"/"+quote]++
+			if !from.Equal(day(3)) || !to.Equal(day(12)) {
+				t.Errorf("FindInEffect() period = %v to %v, want %v to %v", from, to, day(3), day(12))
+			}
+			if base != "EUR" || quote != "USD" {
+				return []model.ExchangeRate{}, nil
+			}
+			return []model.ExchangeRate{
+				{Date: day(1), Base: base, Quote: quote, Rate: 110000000},
+				{Date: day(10), Base: base, Quote: quote, Rate: 120000000},
+			}, nil
+		},
+	}
+	totals := []model.CurrencyTotals{
+		{Date: day(12).Add(18 * time.Hour), Currency: "USD", Expenses: 1200},
+		{Date: day(3), Currency: "USD", Expenses: 1100},
+		{Date: day(9), Currency: "USD", Expenses: 1100},
+		{Date: day(10), Currency: "USD", Expenses: 1200},
+	}
+
+	c := CurrencyConverter{Rates: rates, Currency: "EUR"}
+	got, err := c.ConvertTotals(context.Background(), totals)
+	want := []model.CurrencyTotals{
+		{Date: day(3), Currency: "EUR", Expenses: 1000},
+		{Date: day(9), Currency: "EUR", Expenses: 1000},
+		{Date: day(10), Currency: "EUR", Expenses: 1000},
+		{Date: day(12), Currency: "EUR", Expenses: 1000},
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("CurrencyConverter.ConvertTotals() = %+v, %v, want %+v", got, err, want)
+	}
+	if !reflect.DeepEqual(calls, map[string]int{"USD/EUR": 1, "EUR/USD": 1}) {
+		t.Errorf("FindInEffect() calls = %v, want one per pair", calls)
+	}
+}
+
+func TestCurrencyConverter_rate(t *testing.T) {
+	date := time.Date(2023, 4, 14, 0, 0, 0, 0, time.UTC)
+	c := CurrencyConverter{Rates: newRateTable(map[string]model.Rate{"USD/EUR": 80000000}), Currency: "USD"}
+	history := newRateHistory(date, date)
+	if got, err := c.rate(context.Background(), history, "EUR", date); err != nil || got.Cmp(big.NewRat(5, 4)) != 0 {
+		t.Errorf("CurrencyConverter.rate() of an inverse pair = %v, %v, want 1.25", got, err)
+	}
+
+	c.Rates = &mocks.ExchangeRateRepositoryMock{
+		FindInEffectFn: func(ctx context.Context, base, quote string, from, to time.Time) ([]model.ExchangeRate, error) {
+			if !from.Equal(date) || !to.Equal(date) {
+				t.Errorf("FindInEffect() period = %v to %v, want %v", from, to, date)
+			}
+			return nil, errors.ErrUnsupported
+		},
+	}
+	var findErr *customErrors.FindItemError
+	if _, err := c.rate(context.Background(), newRateHistory(date, date), "EUR", date); !errors.As(err, &findErr) {
+		t.Errorf("CurrencyConverter.rate() with a failing repository error = %v, want FindItemError", err)
+	}
+}
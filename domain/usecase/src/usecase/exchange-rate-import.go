@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+)
+
+// MaxExchangeRateFileSize bounds the rate files read. The full ECB history
+// takes a few megabytes.
+const MaxExchangeRateFileSize = 32 << 20
+
+// exchangeRateColumns are the columns a rates CSV file must have, in any
+// order.
+var exchangeRateColumns = []string{"date", "base", "quote", "rate"}
+
+// parseExchangeRatesCSV reads a CSV file with a date, base, quote and rate
+// column, named by its header row. Like parseCSV, it reports the problems of
+// every row together.
+func parseExchangeRatesCSV(r io.Reader) ([]model.ExchangeRate, error) {
+	reader := csv.NewReader(io.LimitReader(r, MaxExchangeRateFileSize))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if goerrors.Is(err, io.EOF) {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, "file is empty")
+	}
+	if err != nil {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, err.Error())
+	}
+	positions := map[string]int{}
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	details := []string{}
+	for _, column := range exchangeRateColumns {
+		if _, ok := positions[column]; !ok {
+			details = append(details, fmt.Sprintf("column %s is missing", column))
+		}
+	}
+	if len(details) > 0 {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, details...)
+	}
+
+	rates := []model.ExchangeRate{}
+	for {
+		record, err := reader.Read()
+		if goerrors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			details = append(details, fmt.Sprintf("line %d: %s", line, err))
+			continue
+		}
+		field := func(column string) string {
+			if i := positions[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rate, problems := parseExchangeRate(field("date"), field("base"), field("quote"), field("rate"))
+		for _, problem := range problems {
+			details = append(details, fmt.Sprintf("line %d: %s", line, problem))
+		}
+		if len(problems) == 0 {
+			rates = append(rates, rate)
+		}
+	}
+
+	if len(details) > 0 {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, details...)
+	}
+	return rates, nil
+}
+
+// ecbEnvelope is the layout of the ECB euro foreign exchange reference rate
+// files, daily or historical: a Cube per day holding a Cube per currency.
+type ecbEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Days    []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// parseECBExchangeRates reads an ECB reference rates XML file, whose rates
+// all have the euro as base. The file quotes currencies without cents, like
+// JPY, which are skipped since no amount can be in them.
+func parseECBExchangeRates(r io.Reader) ([]model.ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(io.LimitReader(r, MaxExchangeRateFileSize)).Decode(&envelope); err != nil {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, "file is not an ECB reference rates file")
+	}
+
+	rates := []model.ExchangeRate{}
+	details := []string{}
+	for _, day := range envelope.Days {
+		for _, entry := range day.Rates {
+			if !model.SupportsCurrency(strings.ToUpper(entry.Currency)) {
+				continue
+			}
+			rate, problems := parseExchangeRate(day.Time, crossCurrency, entry.Currency, entry.Rate)
+			for _, problem := range problems {
+				details = append(details, fmt.Sprintf("%s %s: %s", day.Time, entry.Currency, problem))
+			}
+			if len(problems) == 0 {
+				rates = append(rates, rate)
+			}
+		}
+	}
+
+	if len(details) > 0 {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, details...)
+	}
+	return rates, nil
+}
+
+func parseExchangeRate(rawDate, base, quote, rawRate string) (model.ExchangeRate, []string) {
+	rate := model.ExchangeRate{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote)}
+	problems := []string{}
+
+	date, err := time.Parse(time.DateOnly, rawDate)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("date %q must have the format YYYY-MM-DD", rawDate))
+	}
+	rate.Date = date
+	if !isCurrencyCode(rate.Base) {
+		problems = append(problems, fmt.Sprintf("base %q must be an ISO 4217 code", base))
+	} else if !model.SupportsCurrency(rate.Base) {
+		problems = append(problems, unsupportedCurrency(rate.Base))
+	}
+	if !isCurrencyCode(rate.Quote) {
+		problems = append(problems, fmt.Sprintf("quote %q must be an ISO 4217 code", quote))
+	} else if !model.SupportsCurrency(rate.Quote) {
+		problems = append(problems, unsupportedCurrency(rate.Quote))
+	}
+	if rate.Base == rate.Quote {
+		problems = append(problems, "base and quote must differ")
+	}
+	value, err := model.ParseRate(rawRate)
+	if err != nil || value <= 0 {
+		problems = append(problems, fmt.Sprintf("rate %q must be a positive number with at most %d decimal places",
+			rawRate, model.RateScale))
+	}
+	rate.Rate = value
+	return rate, problems
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+// ExchangeRateUseCase loads the exchange rates amounts are converted with.
+type ExchangeRateUseCase struct {
+	Repository port.ExchangeRateRepository
+}
+
+// ImportCSV stores the rates of a CSV file with a date, base, quote and rate
+// column. A file with any invalid row is rejected as a whole.
+func (uc ExchangeRateUseCase) ImportCSV(ctx context.Context, r io.Reader) (*model.ExchangeRateImport, error) {
+	rates, err := parseExchangeRatesCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, rates)
+}
+
+// ImportECB stores the rates of an ECB euro reference rates XML file, daily
+// or historical.
+func (uc ExchangeRateUseCase) ImportECB(ctx context.Context, r io.Reader) (*model.ExchangeRateImport, error) {
+	rates, err := parseECBExchangeRates(r)
+	if err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, rates)
+}
+
+// FindEffective returns the rate of base in quote in effect on date.
+func (uc ExchangeRateUseCase) FindEffective(ctx context.Context, base, quote string,
+	date time.Time) (*model.ExchangeRate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if !isCurrencyCode(base) || !isCurrencyCode(quote) {
+		return nil, errors.NewInvalidItemError(ExchangeRateName, "base and quote must be ISO 4217 codes, e.g. USD")
+	}
+	for _, code := range []string{base, quote} {
+		if !model.SupportsCurrency(code) {
+			return nil, errors.NewInvalidItemError(ExchangeRateName, unsupportedCurrency(code))
+		}
+	}
+	return uc.Repository.FindEffective(ctx, base, quote, truncateDay(date))
+}
+
+func (uc ExchangeRateUseCase) save(ctx context.Context, rates []model.ExchangeRate) (*model.ExchangeRateImport, error) {
+	if err := uc.Repository.Save(ctx, rates); err != nil {
+		return nil, errors.NewSaveItemError(ExchangeRateName)
+	}
+	return &model.ExchangeRateImport{Imported: len(rates)}, nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+const ecbRates = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2023-04-14">
+			<Cube currency="USD" rate="1.1053"/>
+			<Cube currency="JPY" rate="146.36"/>
+			<Cube currency="GBP" rate="0.88575"/>
+		</Cube>
+		<Cube time="2023-04-13">
+			<Cube currency="USD" rate="1.1043"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestExchangeRateUseCase_Import(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 4, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name        string
+		ecb         bool
+		file        string
+		want        []model.ExchangeRate
+		wantDetails []string
+	}{
+		{
+			name: "given a rates CSV file, then save its rates with upper-cased codes",
+			file: "Quote,Date,Base,Rate\nusd,2023-04-14,EUR,1.1053\nCOP,2023-04-13,USD,4480.5\n",
+			want: []model.ExchangeRate{
+				{Date: day(14), Base: "EUR", Quote: "USD", Rate: 110530000},
+				{Date: day(13), Base: "USD", Quote: "COP", Rate: 448050000000},
+			},
+		},
+		{
+			name:        "given a CSV file without a rate column, then get error",
+			file:        "date,base,quote\n2023-04-14,EUR,USD\n",
+			wantDetails: []string{"column rate is missing"},
+		},
+		{
+			name: "given invalid CSV rows, then get one detail per problem with its line",
+			file: "date,base,quote,rate\n14/04/2023,EUR,USD,1.1\n2023-04-14,EURO,EUR,0\n2023-04-14,USD,USD,1\n",
+			wantDetails: []string{
+				`line 2: date "14/04/2023" must have the format YYYY-MM-DD`,
+				`line 3: base "EURO" must be an ISO 4217 code`,
+				`line 3: rate "0" must be a positive number with at most 8 decimal places`,
+				"line 4: base and quote must differ",
+			},
+		},
+		{
+			name:        "given a CSV rate of a currency without cents, then get error",
+			file:        "date,base,quote,rate\n2023-04-14,EUR,JPY,146.36\n",
+			wantDetails: []string{"line 2: currency JPY is not supported, amounts must have 2 decimal places"},
+		},
+		{
+			name: "given CSV rates that aren't finite or have too many decimals, then get error",
+			file: "date,base,quote,rate\n2023-04-14,EUR,USD,NaN\n2023-04-14,EUR,GBP,Inf\n2023-04-14,EUR,CHF,0.123456789\n",
+			wantDetails: []string{
+				`line 2: rate "NaN" must be a positive number with at most 8 decimal places`,
+				`line 3: rate "Inf" must be a positive number with at most 8 decimal places`,
+				`line 4: rate "0.123456789" must be a positive number with at most 8 decimal places`,
+			},
+		},
+		{
+			name: "given an ECB file, then save its rates against the euro but those without cents",
+			ecb:  true,
+			file: ecbRates,
+			want: []model.ExchangeRate{
+				{Date: day(14), Base: "EUR", Quote: "USD", Rate: 110530000},
+				{Date: day(14), Base: "EUR", Quote: "GBP", Rate: 88575000},
+				{Date: day(13), Base: "EUR", Quote: "USD", Rate: 110430000},
+			},
+		},
+		{
+			name:        "given a file that is not an ECB one, then get error",
+			ecb:         true,
+			file:        "date,base,quote,rate\n",
+			wantDetails: []string{"file is not an ECB reference rates file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved []model.ExchangeRate
+			uc := ExchangeRateUseCase{Repository: &mocks.ExchangeRateRepositoryMock{
+				SaveFn: func(ctx context.Context, rates []model.ExchangeRate) error {
+					saved = rates
+					return nil
+				},
+			}}
+			importFile := uc.ImportCSV
+			if tt.ecb {
+				importFile = uc.ImportECB
+			}
+			got, err := importFile(context.Background(), strings.NewReader(tt.file))
+			if tt.wantDetails != nil {
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("ExchangeRateUseCase import error = %v, want details %q", err, tt.wantDetails)
+				}
+				if saved != nil {
+					t.Errorf("ExchangeRateUseCase import saved %v, want nothing", saved)
+				}
+				return
+			}
+			if err != nil || got.Imported != len(tt.want) || !reflect.DeepEqual(saved, tt.want) {
+				t.Errorf("ExchangeRateUseCase import = %+v, %v, saved %+v, want %+v", got, err, saved, tt.want)
+			}
+		})
+	}
+}
+
+func TestExchangeRateUseCase_FindEffective(t *testing.T) {
+	uc := ExchangeRateUseCase{Repository: newRateTable(map[string]model.Rate{"EUR/USD": 110000000})}
+	got, err := uc.FindEffective(context.Background(), "eur", "usd", time.Date(2023, 4, 14, 18, 0, 0, 0, time.UTC))
+	want := &model.ExchangeRate{Date: time.Date(2023, 4, 14, 0, 0, 0, 0, time.UTC), Base: "EUR", Quote: "USD", Rate: 110000000}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ExchangeRateUseCase.FindEffective() = %+v, %v, want %+v", got, err, want)
+	}
+
+	var invalid *customErrors.InvalidItemError
+	if _, err := uc.FindEffective(context.Background(), "euro", "USD", time.Now()); !errors.As(err, &invalid) {
+		t.Errorf("ExchangeRateUseCase.FindEffective() with an invalid code error = %v, want InvalidItemError", err)
+	}
+
+	uc.Repository = &mocks.ExchangeRateRepositoryMock{
+		SaveFn: func(ctx context.Context, rates []model.ExchangeRate) error {
+			return errors.ErrUnsupported
+		},
+	}
+	var saveErr *customErrors.SaveItemError
+	if _, err := uc.ImportCSV(context.Background(), strings.NewReader("date,base,quote,rate\n2023-04-14,EUR,USD,1.1\n")); !errors.As(err, &saveErr) {
+		t.Errorf("ExchangeRateUseCase.ImportCSV() with a failing repository error = %v, want SaveItemError", err)
+	}
+}
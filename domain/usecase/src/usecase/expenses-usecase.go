@@ -1,84 +1,463 @@
 package usecase
 
 import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/enaldo1709/budget-manager/domain/model/src/model"
 	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
 	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
 )
 
 const (
-	ExpenseName     = "expense"
-	ExpenseIfExists = "expense if exists"
+	ExpenseName        = "expense"
+	ExpenseIfExists    = "expense if exists"
+	DeletedExpenseName = "deleted expense"
+	AuditEntryName     = "audit entry"
+
+	DefaultExpensePageSize = 50
+	MaxExpensePageSize     = 500
+	// MaxExpenseSplits bounds the lines an expense can be split into.
+	MaxExpenseSplits = 100
 )
 
 type ExpenseUseCase struct {
 	Repository port.ExpenseRepository
+	Categories port.CategoryRepository
+	// Accounts rejects expenses for unknown or closed accounts.
+	Accounts port.AccountRepository
+	// Transactions makes the checks and the write of Save, Update and
+	// Delete atomic.
+	Transactions port.UnitOfWork
+	// Audit receives an entry for every change; without it, changes are not
+	// audited.
+	Audit port.AuditRepository
+	// Currency is the reporting currency, which records without a currency
+	// or account of their own are in.
+	Currency string
 }
 
-func (uc ExpenseUseCase) FindByID(id int) (*model.Expense, error) {
-	exists, err := uc.Repository.Exists(id)
+func (uc ExpenseUseCase) FindByID(ctx context.Context, id int) (*model.Expense, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
 	if err != nil {
 		return nil, errors.NewFindItemError(ExpenseIfExists)
 	}
 	if !exists {
 		return nil, errors.NewItemNotFoundError(ExpenseName)
 	}
-	return uc.Repository.FindByID(id)
+	return uc.Repository.FindByID(ctx, id)
 }
 
-func (uc ExpenseUseCase) FindAll() ([]model.Expense, error) {
-	return uc.Repository.FindAll()
+// FindAll returns the page of expenses matching query. Pages are keyset
+// based: the NextCursor of a page is passed back as query.After to get the
+// following one.
+func (uc ExpenseUseCase) FindAll(ctx context.Context, query model.ExpenseQuery) (*model.ExpensePage, error) {
+	query, err := normalizeExpenseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra row tells whether there is a next page
+	limit := query.Limit
+	query.Limit++
+	expenses, err := uc.Repository.FindAll(ctx, query)
+	if err != nil {
+		return nil, errors.NewFindItemError(ExpenseName)
+	}
+
+	page := &model.ExpensePage{Items: expenses}
+	if len(expenses) > limit {
+		page.Items = expenses[:limit]
+		page.NextCursor = model.NewExpenseCursor(page.Items[limit-1], query.SortBy).Encode()
+	}
+	return page, nil
 }
 
-func (uc ExpenseUseCase) Save(expense *model.Expense) (*model.Expense, error) {
+func (uc ExpenseUseCase) Save(ctx context.Context, expense *model.Expense) (*model.Expense, error) {
 	if expense.Id < 0 {
 		return nil, errors.NewInvalidItemError(ExpenseName, "field Id must be a positive integer")
 	}
-	exists, err := uc.Repository.Exists(expense.Id)
+
+	var result *model.Expense
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		exists, err := repos.Expenses.Exists(ctx, expense.Id)
+		if err != nil {
+			return errors.NewFindItemError(ExpenseIfExists)
+		}
+		if exists {
+			return errors.NewItemAlreadyExistsError(ExpenseName)
+		}
+		if err := validateCategory(ctx, repos.Categories, expense); err != nil {
+			return err
+		}
+		account, err := validateAccount(ctx, repos.Accounts, ExpenseName, expense.AccountId, 0)
+		if err != nil {
+			return err
+		}
+		if err := validateCurrency(ExpenseName, &expense.Currency, account, uc.Currency); err != nil {
+			return err
+		}
+
+		if result, err = repos.Expenses.Save(ctx, expense); err != nil {
+			return errors.NewSaveItemError(ExpenseName)
+		}
+		return audit(ctx, repos.Audit, model.AuditCreate, result.Id, nil, result)
+	})
 	if err != nil {
-		return nil, errors.NewFindItemError(ExpenseIfExists)
+		return nil, err
 	}
-	if exists {
-		return nil, errors.NewItemAlreadyExistsError(ExpenseName)
+
+	return result, nil
+}
+
+// Update writes expense only if it still carries the stored Version; a stale
+// version is reported as a ConcurrentModificationError.
+func (uc ExpenseUseCase) Update(ctx context.Context, expense *model.Expense) (*model.Expense, error) {
+	if expense.Version <= 0 {
+		return nil, errors.NewInvalidItemError(ExpenseName,
+			"field Version is required, send it in the body or in an If-Match header")
 	}
 
-	result, err := uc.Repository.Save(expense)
+	var result *model.Expense
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		exists, err := repos.Expenses.Exists(ctx, expense.Id)
+		if err != nil {
+			return errors.NewFindItemError(ExpenseIfExists)
+		}
+		if !exists {
+			return errors.NewItemNotFoundError(ExpenseName)
+		}
+		if err := validateCategory(ctx, repos.Categories, expense); err != nil {
+			return err
+		}
+		before, err := auditSnapshot(ctx, repos, expense.Id)
+		if err != nil {
+			return err
+		}
+		stored := before
+		if stored == nil {
+			if stored, err = repos.Expenses.FindByID(ctx, expense.Id); err != nil {
+				return errors.NewFindItemError(ExpenseName)
+			}
+		}
+		account, err := validateAccount(ctx, repos.Accounts, ExpenseName, expense.AccountId, stored.AccountId)
+		if err != nil {
+			return err
+		}
+		if err := validateCurrency(ExpenseName, &expense.Currency, account, stored.Currency); err != nil {
+			return err
+		}
+
+		if result, err = repos.Expenses.Update(ctx, expense); err != nil {
+			var concurrent *errors.ConcurrentModificationError
+			if goerrors.As(err, &concurrent) {
+				return err
+			}
+			return errors.NewUpdateItemError(ExpenseName)
+		}
+		return audit(ctx, repos.Audit, model.AuditUpdate, result.Id, before, result)
+	})
 	if err != nil {
-		return nil, errors.NewSaveItemError(ExpenseName)
+		return nil, err
 	}
 
 	return result, nil
 }
 
-func (uc ExpenseUseCase) Update(expense *model.Expense) (*model.Expense, error) {
-	exists, err := uc.Repository.Exists(expense.Id)
+// Delete moves the expense to the trash, from where it can be restored until
+// it is purged.
+func (uc ExpenseUseCase) Delete(ctx context.Context, id int) error {
+	return runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		exists, err := repos.Expenses.Exists(ctx, id)
+		if err != nil {
+			return errors.NewFindItemError(ExpenseIfExists)
+		}
+		if !exists {
+			return errors.NewItemNotFoundError(ExpenseName)
+		}
+		before, err := auditSnapshot(ctx, repos, id)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Expenses.Delete(ctx, id); err != nil {
+			return errors.NewDeleteItemError(ExpenseName)
+		}
+		return audit(ctx, repos.Audit, model.AuditDelete, id, before, nil)
+	})
+}
+
+// FindDeleted lists the expenses in the trash, the most recently deleted
+// first.
+func (uc ExpenseUseCase) FindDeleted(ctx context.Context) ([]model.Expense, error) {
+	expenses, err := uc.Repository.FindDeleted(ctx)
 	if err != nil {
-		return nil, errors.NewFindItemError(ExpenseIfExists)
-	}
-	if !exists {
-		return nil, errors.NewItemNotFoundError(ExpenseName)
+		return nil, errors.NewFindItemError(DeletedExpenseName)
 	}
+	return expenses, nil
+}
+
+// Restore takes the expense out of the trash and returns it.
+func (uc ExpenseUseCase) Restore(ctx context.Context, id int) (*model.Expense, error) {
+	var result *model.Expense
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		if err := repos.Expenses.Restore(ctx, id); err != nil {
+			var notFound *errors.ItemNotFound
+			if goerrors.As(err, &notFound) {
+				return err
+			}
+			return errors.NewUpdateItemError(DeletedExpenseName)
+		}
 
-	result, err := uc.Repository.Update(expense)
+		var err error
+		if result, err = repos.Expenses.FindByID(ctx, id); err != nil {
+			return errors.NewFindItemError(ExpenseName)
+		}
+		return audit(ctx, repos.Audit, model.AuditRestore, id, nil, result)
+	})
 	if err != nil {
-		return nil, errors.NewUpdateItemError(ExpenseName)
+		return nil, err
 	}
 
 	return result, nil
 }
 
-func (uc ExpenseUseCase) Delete(id int) error {
-	exists, err := uc.Repository.Exists(id)
+// History returns the audit entries of the expense, oldest first. An expense
+// in the trash still has its history.
+func (uc ExpenseUseCase) History(ctx context.Context, id int) ([]model.AuditEntry, error) {
+	entries := []model.AuditEntry{}
+	if uc.Audit != nil {
+		var err error
+		if entries, err = uc.Audit.FindByEntity(ctx, ExpenseName, id); err != nil {
+			return nil, errors.NewFindItemError(AuditEntryName)
+		}
+	}
+	if len(entries) == 0 {
+		exists, err := uc.Repository.Exists(ctx, id)
+		if err != nil {
+			return nil, errors.NewFindItemError(ExpenseIfExists)
+		}
+		if !exists {
+			return nil, errors.NewItemNotFoundError(ExpenseName)
+		}
+	}
+	return entries, nil
+}
+
+// PurgeDeleted removes for good the expenses that have been in the trash for
+// longer than retention, and returns how many were removed.
+func (uc ExpenseUseCase) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	purged, err := uc.Repository.Purge(ctx, time.Now().Add(-retention))
 	if err != nil {
-		return errors.NewFindItemError(ExpenseIfExists)
+		return 0, errors.NewDeleteItemError(DeletedExpenseName)
 	}
-	if !exists {
-		return errors.NewItemNotFoundError(ExpenseName)
+	return purged, nil
+}
+
+// runInTransaction runs fn in the unit of work uow, so its checks and writes
+// can't interleave with another request's. Without uow, fn runs on the
+// fallback repositories directly. Either way fn only gets an audit
+// repository when audit isn't nil.
+func runInTransaction(ctx context.Context, uow port.UnitOfWork, fallback port.Repositories,
+	audit port.AuditRepository, fn func(repos port.Repositories) error) error {
+	if uow == nil {
+		fallback.Audit = audit
+		return fn(fallback)
 	}
+	return uow.Run(ctx, func(repos port.Repositories) error {
+		if audit == nil {
+			repos.Audit = nil
+		}
+		return fn(repos)
+	})
+}
+
+// repositories are the ones fn runs on without Transactions.
+func (uc ExpenseUseCase) repositories() port.Repositories {
+	return port.Repositories{Expenses: uc.Repository, Categories: uc.Categories, Accounts: uc.Accounts}
+}
 
-	if err := uc.Repository.Delete(id); err != nil {
-		return errors.NewDeleteItemError(ExpenseName)
+// auditSnapshot returns the stored expense with id, to be recorded as the
+// state before a change, or nil when changes are not audited.
+func auditSnapshot(ctx context.Context, repos port.Repositories, id int) (*model.Expense, error) {
+	if repos.Audit == nil {
+		return nil, nil
 	}
+	before, err := repos.Expenses.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(ExpenseName)
+	}
+	return before, nil
+}
+
+// audit records a change to the expense with id, made by the actor carried
+// by ctx. A nil before or after is left out of the entry.
+func audit(ctx context.Context, repository port.AuditRepository, action model.AuditAction,
+	id int, before, after *model.Expense) error {
+	return auditChange(ctx, repository, ExpenseName, action, id, before, after)
+}
 
+// auditChange records a change to the named entity with id, like audit
+// does for expenses.
+func auditChange[T any](ctx context.Context, repository port.AuditRepository, entity string,
+	action model.AuditAction, id int, before, after *T) error {
+	if repository == nil {
+		return nil
+	}
+	entry := &model.AuditEntry{
+		Entity:   entity,
+		EntityId: id,
+		Action:   action,
+		Actor:    model.ActorFrom(ctx),
+		Recorded: time.Now().UTC().Truncate(time.Second),
+	}
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return errors.NewSaveItemError(AuditEntryName)
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return errors.NewSaveItemError(AuditEntryName)
+		}
+	}
+	if err := repository.Record(ctx, entry); err != nil {
+		return errors.NewSaveItemError(AuditEntryName)
+	}
+	return nil
+}
+
+// validateCategory checks that the category of the expense exists, or
+// those of its split lines when it has any.
+func validateCategory(ctx context.Context, categories port.CategoryRepository, expense *model.Expense) error {
+	if err := validateSplits(expense); err != nil {
+		return err
+	}
+	ids := []int{expense.CategoryId}
+	for _, split := range expense.Splits {
+		ids = append(ids, split.CategoryId)
+	}
+	checked := map[int]bool{0: true}
+	for _, id := range ids {
+		if checked[id] {
+			continue
+		}
+		checked[id] = true
+		exists, err := categories.Exists(ctx, id)
+		if err != nil {
+			return errors.NewFindItemError(CategoryIfExists)
+		}
+		if !exists {
+			return errors.NewInvalidItemError(ExpenseName, fmt.Sprintf("category %d does not exist", id))
+		}
+	}
+	return nil
+}
+
+// validateSplits checks that the split lines of the expense add up to its
+// amount. A split expense has no category of its own, so that reports
+// count every line once, in its category.
+func validateSplits(expense *model.Expense) error {
+	if len(expense.Splits) == 0 {
+		return nil
+	}
+	details := []string{}
+
+	if len(expense.Splits) > MaxExpenseSplits {
+		details = append(details, fmt.Sprintf("field Splits must not have more than %d lines", MaxExpenseSplits))
+	}
+	if expense.CategoryId != 0 {
+		details = append(details, "field CategoryId must be empty on a split expense, set it on its lines")
+	}
+	var total model.Money
+	for i, split := range expense.Splits {
+		if split.Amount <= 0 {
+			details = append(details, fmt.Sprintf("field Splits[%d].Amount must be greater than zero", i))
+		}
+		if split.CategoryId < 0 {
+			details = append(details, fmt.Sprintf("field Splits[%d].CategoryId must be a positive integer", i))
+		}
+		total += split.Amount
+	}
+	if total != expense.Amount {
+		details = append(details,
+			fmt.Sprintf("split amounts add up to %s instead of the expense amount %s", total, expense.Amount))
+	}
+
+	if len(details) > 0 {
+		return errors.NewInvalidItemError(ExpenseName, details...)
+	}
 	return nil
 }
+
+// normalizeExpenseQuery fills the defaults of query and rejects the
+// combinations that can't be answered.
+func normalizeExpenseQuery(query model.ExpenseQuery) (model.ExpenseQuery, error) {
+	details := []string{}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = model.SortByCreated
+	case model.SortByCreated, model.SortByAmount, model.SortById:
+	default:
+		details = append(details, fmt.Sprintf("sort field %s is not supported", query.SortBy))
+	}
+	switch query.Direction {
+	case "":
+		query.Direction = model.SortDescending
+	case model.SortAscending, model.SortDescending:
+	default:
+		details = append(details, fmt.Sprintf("sort direction %s is not supported", query.Direction))
+	}
+
+	if query.Limit == 0 {
+		query.Limit = DefaultExpensePageSize
+	}
+	if query.Limit < 0 || query.Limit > MaxExpensePageSize {
+		details = append(details,
+			fmt.Sprintf("limit must be between 1 and %d", MaxExpensePageSize))
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		details = append(details, "from must be before to")
+	}
+	if query.MinAmount != nil && query.MaxAmount != nil && *query.MinAmount > *query.MaxAmount {
+		details = append(details, "minimum amount must not be greater than maximum amount")
+	}
+	if query.CategoryId < 0 {
+		details = append(details, "category must be a positive integer")
+	}
+	if query.AccountId < 0 {
+		details = append(details, "account must be a positive integer")
+	}
+	if query.After != nil && query.After.SortBy != query.SortBy {
+		details = append(details, "cursor belongs to a listing with a different sort field")
+	} else if query.After != nil && !validCursorValue(*query.After) {
+		details = append(details, "cursor is not valid")
+	}
+
+	if len(details) > 0 {
+		return query, errors.NewInvalidItemError(ExpenseName, details...)
+	}
+	return query, nil
+}
+
+// validCursorValue reports whether the sorted value of cursor has the form
+// of its sort field, so a tampered cursor never reaches the repository.
+func validCursorValue(cursor model.ExpenseCursor) bool {
+	switch cursor.SortBy {
+	case model.SortByCreated:
+		_, err := time.Parse(time.RFC3339, cursor.Value)
+		return err == nil
+	case model.SortByAmount:
+		_, err := model.ParseMoney(cursor.Value)
+		return err == nil
+	default:
+		id, err := strconv.Atoi(cursor.Value)
+		return err == nil && id == cursor.Id
+	}
+}
@@ -1,12 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
 	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
 	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
 )
@@ -29,13 +31,13 @@ func TestExpenseUseCaseFindByID(t *testing.T) {
 			name: "given an id then get a expense model",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(s int) (bool, error) {
+					ExistsFn: func(ctx context.Context, s int) (bool, error) {
 						return true, nil
 					},
-					FindByIDFn: func(id int) (*model.Expense, error) {
+					FindByIDFn: func(ctx context.Context, id int) (*model.Expense, error) {
 						return &model.Expense{
 							Id:      1,
-							Amount:  25.3,
+							Amount:  2530,
 							Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
 						}, nil
 					},
@@ -44,7 +46,7 @@ func TestExpenseUseCaseFindByID(t *testing.T) {
 			args: args{id: 1},
 			want: &model.Expense{
 				Id:      1,
-				Amount:  25.3,
+				Amount:  2530,
 				Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
 			},
 			wantErr: false,
@@ -53,7 +55,7 @@ func TestExpenseUseCaseFindByID(t *testing.T) {
 			name: "given an id, when the expense not exists then get an error",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(s int) (bool, error) {
+					ExistsFn: func(ctx context.Context, s int) (bool, error) {
 						return false, nil
 					},
 				},
@@ -65,7 +67,7 @@ func TestExpenseUseCaseFindByID(t *testing.T) {
 			name: "given an id, when check if the expense exists, then get an error",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(s int) (bool, error) {
+					ExistsFn: func(ctx context.Context, s int) (bool, error) {
 						return false, errors.ErrUnsupported
 					},
 				},
@@ -79,7 +81,7 @@ func TestExpenseUseCaseFindByID(t *testing.T) {
 			uc := ExpenseUseCase{
 				Repository: tt.fields.repository,
 			}
-			got, err := uc.FindByID(tt.args.id)
+			got, err := uc.FindByID(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExpenseUseCase.FindByID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -92,66 +94,104 @@ func TestExpenseUseCaseFindByID(t *testing.T) {
 }
 
 func TestExpenseUseCaseFindAll(t *testing.T) {
+	expenses := []model.Expense{
+		{Id: 3, Amount: 3350, Created: time.Date(2023, 4, 17, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Amount: 2470, Created: time.Date(2023, 4, 16, 0, 0, 0, 0, time.UTC)},
+		{Id: 1, Amount: 1200, Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)},
+	}
 	type fields struct {
 		repository port.ExpenseRepository
 	}
+	type args struct {
+		query model.ExpenseQuery
+	}
 	tests := []struct {
 		name    string
 		fields  fields
-		want    []model.Expense
+		args    args
+		want    *model.ExpensePage
 		wantErr bool
 	}{
 		{
-			name: "got an array of expenses",
+			name: "given an empty query, then get the first page with the default sort and limit",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					FindAllFn: func() ([]model.Expense, error) {
-						return []model.Expense{
-							{
-								Id:      1,
-								Amount:  33.5,
-								Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
-							},
-							{
-								Id:      1,
-								Amount:  24.7,
-								Created: time.Date(2023, 4, 16, 0, 0, 0, 0, time.Local),
-							},
-						}, nil
+					FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+						want := model.ExpenseQuery{
+							SortBy:    model.SortByCreated,
+							Direction: model.SortDescending,
+							Limit:     DefaultExpensePageSize + 1,
+						}
+						if !reflect.DeepEqual(q, want) {
+							t.Errorf("FindAll() query = %+v, want %+v", q, want)
+						}
+						return expenses, nil
 					},
 				},
 			},
-			want: []model.Expense{
-				{
-					Id:      1,
-					Amount:  33.5,
-					Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
-				},
-				{
-					Id:      1,
-					Amount:  24.7,
-					Created: time.Date(2023, 4, 16, 0, 0, 0, 0, time.Local),
+			want: &model.ExpensePage{Items: expenses},
+		},
+		{
+			name: "given a limit, when there are more expenses, then get a next cursor",
+			fields: fields{
+				repository: &mocks.ExpenseRepositoryMock{
+					FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+						return expenses[:q.Limit], nil
+					},
 				},
 			},
-			wantErr: false,
+			args: args{query: model.ExpenseQuery{Limit: 2}},
+			want: &model.ExpensePage{
+				Items: expenses[:2],
+				NextCursor: model.ExpenseCursor{
+					SortBy: model.SortByCreated,
+					Value:  "2023-04-16T00:00:00Z",
+					Id:     2,
+				}.Encode(),
+			},
 		},
 		{
-			name: "got an empty array",
+			name: "given an empty database, then get an empty page",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					FindAllFn: func() ([]model.Expense, error) {
+					FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
 						return []model.Expense{}, nil
 					},
 				},
 			},
-			want:    []model.Expense{},
-			wantErr: false,
+			want: &model.ExpensePage{Items: []model.Expense{}},
+		},
+		{
+			name:    "given an unknown sort field, then get error",
+			args:    args{query: model.ExpenseQuery{SortBy: "category"}},
+			wantErr: true,
+		},
+		{
+			name:    "given a limit over the maximum, then get error",
+			args:    args{query: model.ExpenseQuery{Limit: MaxExpensePageSize + 1}},
+			wantErr: true,
+		},
+		{
+			name: "given an amount range, when min is greater than max, then get error",
+			args: args{query: model.ExpenseQuery{
+				MinAmount: func() *model.Money { m := model.Money(500); return &m }(),
+				MaxAmount: func() *model.Money { m := model.Money(100); return &m }(),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "given a cursor, when it was created for another sort field, then get error",
+			args: args{query: model.ExpenseQuery{
+				SortBy: model.SortByAmount,
+				After:  &model.ExpenseCursor{SortBy: model.SortByCreated, Value: "2023-04-16T00:00:00Z", Id: 2},
+			}},
+			wantErr: true,
 		},
 		{
 			name: "got an error",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					FindAllFn: func() ([]model.Expense, error) {
+					FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
 						return nil, errors.New("error finding expenses")
 					},
 				},
@@ -164,7 +204,7 @@ func TestExpenseUseCaseFindAll(t *testing.T) {
 			uc := ExpenseUseCase{
 				Repository: tt.fields.repository,
 			}
-			got, err := uc.FindAll()
+			got, err := uc.FindAll(context.Background(), tt.args.query)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExpenseUseCase.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -176,9 +216,45 @@ func TestExpenseUseCaseFindAll(t *testing.T) {
 	}
 }
 
+func TestExpenseUseCase_FindAll_cursorValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor model.ExpenseCursor
+	}{
+		{
+			name:   "given a created cursor, when its value isn't a timestamp, then get invalid item error",
+			cursor: model.ExpenseCursor{SortBy: model.SortByCreated, Value: "abc", Id: 2},
+		},
+		{
+			name:   "given an amount cursor, when its value isn't money, then get invalid item error",
+			cursor: model.ExpenseCursor{SortBy: model.SortByAmount, Value: "abc", Id: 2},
+		},
+		{
+			name:   "given an id cursor, when its value isn't its id, then get invalid item error",
+			cursor: model.ExpenseCursor{SortBy: model.SortById, Value: "3", Id: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{Repository: &mocks.ExpenseRepositoryMock{
+				FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+					t.Errorf("ExpenseUseCase.FindAll() queried the repository with cursor %+v", q.After)
+					return nil, nil
+				},
+			}}
+			_, err := uc.FindAll(context.Background(), model.ExpenseQuery{SortBy: tt.cursor.SortBy, After: &tt.cursor})
+			var invalid *customErrors.InvalidItemError
+			if !errors.As(err, &invalid) {
+				t.Errorf("ExpenseUseCase.FindAll() error = %v, want InvalidItemError", err)
+			}
+		})
+	}
+}
+
 func TestExpenseUseCaseSave(t *testing.T) {
 	type fields struct {
 		repository port.ExpenseRepository
+		categories port.CategoryRepository
 	}
 	type args struct {
 		expense *model.Expense
@@ -194,10 +270,10 @@ func TestExpenseUseCaseSave(t *testing.T) {
 			name: "given a expense, then save with success",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, nil
 					},
-					SaveFn: func(e *model.Expense) (*model.Expense, error) {
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
 						return e, nil
 					},
 				},
@@ -205,25 +281,86 @@ func TestExpenseUseCaseSave(t *testing.T) {
 			args: args{
 				expense: &model.Expense{
 					Id:      1,
-					Amount:  100,
+					Amount:  10000,
 					Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
 				},
 			},
 			want: &model.Expense{
 				Id:      1,
-				Amount:  100,
+				Amount:  10000,
 				Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
 			},
 			wantErr: false,
 		},
+		{
+			name: "given a expense with category, then save with success",
+			fields: fields{
+				repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+				},
+				categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return i == 3, nil
+					},
+				},
+			},
+			args: args{
+				expense: &model.Expense{Id: 1, Amount: 10000, CategoryId: 3},
+			},
+			want:    &model.Expense{Id: 1, Amount: 10000, CategoryId: 3},
+			wantErr: false,
+		},
+		{
+			name: "given a expense, when the category doesn't exists, then get error",
+			fields: fields{
+				repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+				},
+				categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				expense: &model.Expense{Id: 1, Amount: 10000, CategoryId: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given a expense, when check if the category exists, then get error",
+			fields: fields{
+				repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+				},
+				categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, errors.ErrUnsupported
+					},
+				},
+			},
+			args: args{
+				expense: &model.Expense{Id: 1, Amount: 10000, CategoryId: 3},
+			},
+			wantErr: true,
+		},
 		{
 			name: "given a expense, when try to save in database, then get error",
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, nil
 					},
-					SaveFn: func(e *model.Expense) (*model.Expense, error) {
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
 						return nil, errors.ErrUnsupported
 					},
 				},
@@ -249,12 +386,12 @@ func TestExpenseUseCaseSave(t *testing.T) {
 			args: args{
 				expense: &model.Expense{
 					Id:     1,
-					Amount: 100,
+					Amount: 10000,
 				},
 			},
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return true, nil
 					},
 				},
@@ -266,12 +403,12 @@ func TestExpenseUseCaseSave(t *testing.T) {
 			args: args{
 				expense: &model.Expense{
 					Id:     1,
-					Amount: 100,
+					Amount: 10000,
 				},
 			},
 			fields: fields{
 				repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, errors.ErrUnsupported
 					},
 				},
@@ -283,8 +420,9 @@ func TestExpenseUseCaseSave(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := ExpenseUseCase{
 				Repository: tt.fields.repository,
+				Categories: tt.fields.categories,
 			}
-			got, err := uc.Save(tt.args.expense)
+			got, err := uc.Save(context.Background(), tt.args.expense)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExpenseUseCase.Save() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -299,54 +437,84 @@ func TestExpenseUseCaseSave(t *testing.T) {
 func TestExpenseUseCase_Update(t *testing.T) {
 	type fields struct {
 		Repository port.ExpenseRepository
+		Categories port.CategoryRepository
 	}
 	type args struct {
 		expense *model.Expense
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *model.Expense
-		wantErr bool
+		name           string
+		fields         fields
+		args           args
+		want           *model.Expense
+		wantErr        bool
+		wantConcurrent bool
 	}{
 		{
 			name: "given a expense, update in database with success",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return true, nil
 					},
-					UpdateFn: func(e *model.Expense) (*model.Expense, error) {
+					FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+						return &model.Expense{Id: 1, Amount: 10000, Version: 1}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
 						return e, nil
 					},
 				},
 			},
 			args: args{
 				expense: &model.Expense{
-					Id:     1,
-					Amount: 200,
+					Id:      1,
+					Amount:  20000,
+					Version: 1,
 				},
 			},
 			want: &model.Expense{
-				Id:     1,
-				Amount: 200,
+				Id:      1,
+				Amount:  20000,
+				Version: 1,
 			},
 			wantErr: false,
 		},
+		{
+			name: "given a expense, when the new category doesn't exists, then get error",
+			fields: fields{
+				Repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+						return &model.Expense{Id: 1, Amount: 10000, Version: 1}, nil
+					},
+				},
+				Categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				expense: &model.Expense{Id: 1, Amount: 20000, CategoryId: 8, Version: 1},
+			},
+			wantErr: true,
+		},
 		{
 			name: "given a expense, when check if the expense exists in database, then get error",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, errors.ErrUnsupported
 					},
 				},
 			},
 			args: args{
 				expense: &model.Expense{
-					Id:     1,
-					Amount: 200,
+					Id:      1,
+					Amount:  20000,
+					Version: 1,
 				},
 			},
 			wantErr: true,
@@ -355,15 +523,16 @@ func TestExpenseUseCase_Update(t *testing.T) {
 			name: "given a expense, when the expense doesn't exists in database, then get error",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, nil
 					},
 				},
 			},
 			args: args{
 				expense: &model.Expense{
-					Id:     1,
-					Amount: 200,
+					Id:      1,
+					Amount:  20000,
+					Version: 1,
 				},
 			},
 			wantErr: true,
@@ -372,33 +541,73 @@ func TestExpenseUseCase_Update(t *testing.T) {
 			name: "given a expense, when get an error on update in database, then get error",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return true, nil
 					},
-					UpdateFn: func(e *model.Expense) (*model.Expense, error) {
+					FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+						return &model.Expense{Id: 1, Amount: 10000, Version: 1}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
 						return nil, errors.ErrUnsupported
 					},
 				},
 			},
 			args: args{
 				expense: &model.Expense{
-					Id:     1,
-					Amount: 200,
+					Id:      1,
+					Amount:  20000,
+					Version: 1,
 				},
 			},
 			wantErr: true,
 		},
+		{
+			name: "given a expense without version, then get an invalid item error",
+			fields: fields{
+				Repository: &mocks.ExpenseRepositoryMock{},
+			},
+			args: args{
+				expense: &model.Expense{Id: 1, Amount: 20000},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given a expense with a stale version, then get a concurrent modification error",
+			fields: fields{
+				Repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+						return &model.Expense{Id: 1, Amount: 10000, Version: 1}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return nil, customErrors.NewConcurrentModificationError("expense")
+					},
+				},
+			},
+			args: args{
+				expense: &model.Expense{Id: 1, Amount: 20000, Version: 1},
+			},
+			wantErr:        true,
+			wantConcurrent: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := ExpenseUseCase{
 				Repository: tt.fields.Repository,
+				Categories: tt.fields.Categories,
 			}
-			got, err := uc.Update(tt.args.expense)
+			got, err := uc.Update(context.Background(), tt.args.expense)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExpenseUseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			var concurrent *customErrors.ConcurrentModificationError
+			if errors.As(err, &concurrent) != tt.wantConcurrent {
+				t.Errorf("ExpenseUseCase.Update() error = %v, wantConcurrent %v", err, tt.wantConcurrent)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ExpenseUseCase.Update() = %v, want %v", got, tt.want)
 			}
@@ -423,10 +632,10 @@ func TestExpenseUseCase_Delete(t *testing.T) {
 			name: "given an id, then delete item with success",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return true, nil
 					},
-					DeleteFn: func(i int) error {
+					DeleteFn: func(ctx context.Context, i int) error {
 						return nil
 					},
 				},
@@ -440,7 +649,7 @@ func TestExpenseUseCase_Delete(t *testing.T) {
 			name: "given an id, when check if the item exist in database, then get error",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, errors.ErrUnsupported
 					},
 				},
@@ -454,7 +663,7 @@ func TestExpenseUseCase_Delete(t *testing.T) {
 			name: "given an id, when the item doesn't exist in database, then get error",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return false, nil
 					},
 				},
@@ -468,10 +677,10 @@ func TestExpenseUseCase_Delete(t *testing.T) {
 			name: "given an id, when get an error on delete item, then get error",
 			fields: fields{
 				Repository: &mocks.ExpenseRepositoryMock{
-					ExistsFn: func(i int) (bool, error) {
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
 						return true, nil
 					},
-					DeleteFn: func(i int) error {
+					DeleteFn: func(ctx context.Context, i int) error {
 						return errors.ErrUnsupported
 					},
 				},
@@ -487,9 +696,749 @@ func TestExpenseUseCase_Delete(t *testing.T) {
 			uc := ExpenseUseCase{
 				Repository: tt.fields.Repository,
 			}
-			if err := uc.Delete(tt.args.id); (err != nil) != tt.wantErr {
+			if err := uc.Delete(context.Background(), tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("ExpenseUseCase.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestExpenseUseCase_FindDeleted(t *testing.T) {
+	deleted := time.Date(2023, 4, 20, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		repository port.ExpenseRepository
+		want       []model.Expense
+		wantErr    bool
+	}{
+		{
+			name: "given expenses in the trash, then get them",
+			repository: &mocks.ExpenseRepositoryMock{
+				FindDeletedFn: func(ctx context.Context) ([]model.Expense, error) {
+					return []model.Expense{{Id: 1, Amount: 20000, Deleted: &deleted}}, nil
+				},
+			},
+			want: []model.Expense{{Id: 1, Amount: 20000, Deleted: &deleted}},
+		},
+		{
+			name: "given a database error, then get error",
+			repository: &mocks.ExpenseRepositoryMock{
+				FindDeletedFn: func(ctx context.Context) ([]model.Expense, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{Repository: tt.repository}
+			got, err := uc.FindDeleted(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseUseCase.FindDeleted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpenseUseCase.FindDeleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpenseUseCase_Restore(t *testing.T) {
+	tests := []struct {
+		name         string
+		repository   port.ExpenseRepository
+		want         *model.Expense
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name: "given a deleted expense, then restore it",
+			repository: &mocks.ExpenseRepositoryMock{
+				RestoreFn: func(ctx context.Context, i int) error { return nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+					return &model.Expense{Id: i, Amount: 20000, Version: 3}, nil
+				},
+			},
+			want: &model.Expense{Id: 1, Amount: 20000, Version: 3},
+		},
+		{
+			name: "given an expense out of the trash, then get item not found",
+			repository: &mocks.ExpenseRepositoryMock{
+				RestoreFn: func(ctx context.Context, i int) error {
+					return customErrors.NewItemNotFoundError("deleted expense")
+				},
+			},
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name: "given a database error, then get error",
+			repository: &mocks.ExpenseRepositoryMock{
+				RestoreFn: func(ctx context.Context, i int) error { return errors.ErrUnsupported },
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{Repository: tt.repository}
+			got, err := uc.Restore(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseUseCase.Restore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var notFound *customErrors.ItemNotFound
+			if errors.As(err, &notFound) != tt.wantNotFound {
+				t.Errorf("ExpenseUseCase.Restore() error = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpenseUseCase.Restore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpenseUseCase_PurgeDeleted(t *testing.T) {
+	retention := 30 * 24 * time.Hour
+	var before time.Time
+	uc := ExpenseUseCase{
+		Repository: &mocks.ExpenseRepositoryMock{
+			PurgeFn: func(ctx context.Context, b time.Time) (int, error) {
+				before = b
+				return 2, nil
+			},
+		},
+	}
+
+	start := time.Now()
+	purged, err := uc.PurgeDeleted(context.Background(), retention)
+	if err != nil || purged != 2 {
+		t.Errorf("ExpenseUseCase.PurgeDeleted() = %d, %v, want 2", purged, err)
+	}
+	if before.Before(start.Add(-retention)) || before.After(time.Now().Add(-retention)) {
+		t.Errorf("ExpenseUseCase.PurgeDeleted() purged before %v, want %v ago", before, retention)
+	}
+
+	uc.Repository = &mocks.ExpenseRepositoryMock{
+		PurgeFn: func(ctx context.Context, b time.Time) (int, error) { return 0, errors.ErrUnsupported },
+	}
+	if _, err := uc.PurgeDeleted(context.Background(), retention); err == nil {
+		t.Errorf("ExpenseUseCase.PurgeDeleted() error = nil, want error")
+	}
+}
+
+func TestExpenseUseCase_Transactions(t *testing.T) {
+	saveFails := func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+		return nil, errors.ErrUnsupported
+	}
+	tests := []struct {
+		name       string
+		saveFn     func(context.Context, *model.Expense) (*model.Expense, error)
+		commitErr  error
+		wantErr    bool
+		wantRunErr bool
+	}{
+		{
+			name: "given a unit of work, then save through its repositories",
+			saveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+				return e, nil
+			},
+		},
+		{
+			name:       "given a unit of work, when save fails, then the unit of work gets the error to roll back",
+			saveFn:     saveFails,
+			wantErr:    true,
+			wantRunErr: true,
+		},
+		{
+			name: "given a unit of work, when the commit fails, then get error",
+			saveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+				return e, nil
+			},
+			commitErr: errors.ErrUnsupported,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runErr error
+			uow := &mocks.UnitOfWorkMock{
+				Repositories: port.Repositories{
+					Expenses: &mocks.ExpenseRepositoryMock{
+						ExistsFn: func(ctx context.Context, i int) (bool, error) {
+							return false, nil
+						},
+						SaveFn: tt.saveFn,
+					},
+				},
+			}
+			uow.RunFn = func(ctx context.Context, fn func(port.Repositories) error) error {
+				if runErr = fn(uow.Repositories); runErr != nil {
+					return runErr
+				}
+				return tt.commitErr
+			}
+			// the use case's own repository must not be used inside the unit of work
+			uc := ExpenseUseCase{
+				Repository:   &mocks.ExpenseRepositoryMock{SaveFn: saveFails},
+				Transactions: uow,
+			}
+
+			_, err := uc.Save(context.Background(), &model.Expense{Id: 1, Amount: 10000})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseUseCase.Save() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (runErr != nil) != tt.wantRunErr {
+				t.Errorf("unit of work function error = %v, wantErr %v", runErr, tt.wantRunErr)
+			}
+		})
+	}
+}
+
+func TestExpenseUseCase_Audit(t *testing.T) {
+	stored := &model.Expense{Id: 1, Amount: 10000, Version: 1}
+	repository := &mocks.ExpenseRepositoryMock{
+		ExistsFn: func(ctx context.Context, i int) (bool, error) { return i == 1, nil },
+		FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+			e := *stored
+			return &e, nil
+		},
+		SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+			e.Id, e.Version = 2, 1
+			return e, nil
+		},
+		UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+			e.Version++
+			return e, nil
+		},
+		DeleteFn:  func(ctx context.Context, i int) error { return nil },
+		RestoreFn: func(ctx context.Context, i int) error { return nil },
+	}
+	tests := []struct {
+		name       string
+		change     func(uc ExpenseUseCase, ctx context.Context) error
+		wantAction model.AuditAction
+		wantId     int
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			name: "given a new expense, then record its creation",
+			change: func(uc ExpenseUseCase, ctx context.Context) error {
+				_, err := uc.Save(ctx, &model.Expense{Amount: 2500})
+				return err
+			},
+			wantAction: model.AuditCreate,
+			wantId:     2,
+			wantAfter:  `{"id":2,"amount":25.00,"created":"0001-01-01T00:00:00Z","version":1}`,
+		},
+		{
+			name: "given an update, then record the expense before and after it",
+			change: func(uc ExpenseUseCase, ctx context.Context) error {
+				_, err := uc.Update(ctx, &model.Expense{Id: 1, Amount: 5000, Version: 1})
+				return err
+			},
+			wantAction: model.AuditUpdate,
+			wantId:     1,
+			wantBefore: `{"id":1,"amount":100.00,"created":"0001-01-01T00:00:00Z","version":1}`,
+			wantAfter:  `{"id":1,"amount":50.00,"created":"0001-01-01T00:00:00Z","version":2}`,
+		},
+		{
+			name: "given a delete, then record the expense before it",
+			change: func(uc ExpenseUseCase, ctx context.Context) error {
+				return uc.Delete(ctx, 1)
+			},
+			wantAction: model.AuditDelete,
+			wantId:     1,
+			wantBefore: `{"id":1,"amount":100.00,"created":"0001-01-01T00:00:00Z","version":1}`,
+		},
+		{
+			name: "given a restore, then record the restored expense",
+			change: func(uc ExpenseUseCase, ctx context.Context) error {
+				_, err := uc.Restore(ctx, 1)
+				return err
+			},
+			wantAction: model.AuditRestore,
+			wantId:     1,
+			wantAfter:  `{"id":1,"amount":100.00,"created":"0001-01-01T00:00:00Z","version":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []model.AuditEntry
+			uc := ExpenseUseCase{
+				Repository: repository,
+				Audit: &mocks.AuditRepositoryMock{
+					RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+						entries = append(entries, *e)
+						return nil
+					},
+				},
+			}
+
+			if err := tt.change(uc, model.WithActor(context.Background(), "ana")); err != nil {
+				t.Fatalf("ExpenseUseCase change error = %v", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("ExpenseUseCase recorded %d audit entries, want 1", len(entries))
+			}
+			got := entries[0]
+			if got.Entity != ExpenseName || got.EntityId != tt.wantId || got.Action != tt.wantAction ||
+				got.Actor != "ana" || got.Recorded.IsZero() {
+				t.Errorf("ExpenseUseCase recorded %+v, want a %s of expense %d by ana", got, tt.wantAction, tt.wantId)
+			}
+			if string(got.Before) != tt.wantBefore || string(got.After) != tt.wantAfter {
+				t.Errorf("ExpenseUseCase recorded snapshots %s and %s, want %s and %s",
+					got.Before, got.After, tt.wantBefore, tt.wantAfter)
+			}
+		})
+	}
+
+	t.Run("given a change without actor, then record it as anonymous", func(t *testing.T) {
+		var actor string
+		uc := ExpenseUseCase{
+			Repository: repository,
+			Audit: &mocks.AuditRepositoryMock{
+				RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+					actor = e.Actor
+					return nil
+				},
+			},
+		}
+		if err := uc.Delete(context.Background(), 1); err != nil || actor != model.UnknownActor {
+			t.Errorf("ExpenseUseCase.Delete() error = %v, actor %q, want actor %q", err, actor, model.UnknownActor)
+		}
+	})
+
+	t.Run("given the audit entry can't be recorded, then the change fails", func(t *testing.T) {
+		var runErr error
+		uow := &mocks.UnitOfWorkMock{}
+		uow.RunFn = func(ctx context.Context, fn func(port.Repositories) error) error {
+			runErr = fn(port.Repositories{
+				Expenses: repository,
+				Audit: &mocks.AuditRepositoryMock{
+					RecordFn: func(ctx context.Context, e *model.AuditEntry) error { return errors.ErrUnsupported },
+				},
+			})
+			return runErr
+		}
+		uc := ExpenseUseCase{Repository: repository, Transactions: uow, Audit: &mocks.AuditRepositoryMock{}}
+		if err := uc.Delete(context.Background(), 1); err == nil || runErr == nil {
+			t.Errorf("ExpenseUseCase.Delete() error = %v, want the unit of work to get an error to roll back", err)
+		}
+	})
+
+	t.Run("given a use case without audit, then changes in a unit of work are not recorded", func(t *testing.T) {
+		uow := &mocks.UnitOfWorkMock{}
+		uow.RunFn = func(ctx context.Context, fn func(port.Repositories) error) error {
+			return fn(port.Repositories{
+				Expenses: repository,
+				Audit: &mocks.AuditRepositoryMock{
+					RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+						t.Errorf("ExpenseUseCase recorded %+v, want no audit entry", e)
+						return nil
+					},
+				},
+			})
+		}
+		uc := ExpenseUseCase{Repository: repository, Transactions: uow}
+		if err := uc.Delete(context.Background(), 1); err != nil {
+			t.Errorf("ExpenseUseCase.Delete() error = %v", err)
+		}
+	})
+}
+
+func TestExpenseUseCase_History(t *testing.T) {
+	entries := []model.AuditEntry{
+		{Id: 1, Entity: ExpenseName, EntityId: 1, Action: model.AuditCreate, Actor: "ana"},
+		{Id: 3, Entity: ExpenseName, EntityId: 1, Action: model.AuditDelete, Actor: "luis"},
+	}
+	exists := &mocks.ExpenseRepositoryMock{
+		ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+	}
+	tests := []struct {
+		name         string
+		repository   port.ExpenseRepository
+		audit        port.AuditRepository
+		want         []model.AuditEntry
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name: "given an audited expense, then get its entries",
+			audit: &mocks.AuditRepositoryMock{
+				FindByEntityFn: func(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+					if entity != ExpenseName || id != 1 {
+						t.Errorf("AuditRepository.FindByEntity() called with %s %d, want %s 1", entity, id, ExpenseName)
+					}
+					return entries, nil
+				},
+			},
+			want: entries,
+		},
+		{
+			name:       "given an expense without entries, then get an empty history",
+			repository: exists,
+			audit: &mocks.AuditRepositoryMock{
+				FindByEntityFn: func(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+					return []model.AuditEntry{}, nil
+				},
+			},
+			want: []model.AuditEntry{},
+		},
+		{
+			name: "given an unknown expense, then get item not found",
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			audit: &mocks.AuditRepositoryMock{
+				FindByEntityFn: func(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+					return []model.AuditEntry{}, nil
+				},
+			},
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name: "given a database error, then get error",
+			audit: &mocks.AuditRepositoryMock{
+				FindByEntityFn: func(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+					return nil, errors.ErrUnsupported
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "given a use case without audit, then get an empty history",
+			repository: exists,
+			want:       []model.AuditEntry{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{Repository: tt.repository, Audit: tt.audit}
+			got, err := uc.History(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseUseCase.History() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var notFound *customErrors.ItemNotFound
+			if errors.As(err, &notFound) != tt.wantNotFound {
+				t.Errorf("ExpenseUseCase.History() error = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpenseUseCase.History() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpenseUseCase_Accounts(t *testing.T) {
+	accounts := newAccountBook(checkingAccount,
+		model.Account{Id: 2, Name: "Old card", Type: model.AccountCard, Currency: "USD", Closed: true})
+	tests := []struct {
+		name        string
+		accountId   int
+		wantDetails []string
+	}{
+		{name: "given an open account, then write the expense", accountId: 1},
+		{name: "given no account, then write the expense", accountId: 0},
+		{name: "given a closed account, then get error", accountId: 2, wantDetails: []string{"account 2 is closed"}},
+		{name: "given an unknown account, then get error", accountId: 3, wantDetails: []string{"account 3 does not exist"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{
+				Repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) {
+						return id == 1, nil
+					},
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+					FindByIDFn: func(ctx context.Context, id int) (*model.Expense, error) {
+						return &model.Expense{Id: id, Amount: 1000, Version: 1}, nil
+					},
+				},
+				Accounts: accounts,
+			}
+			calls := map[string]func() error{
+				"Save": func() error {
+					_, err := uc.Save(context.Background(), &model.Expense{Amount: 1000, AccountId: tt.accountId})
+					return err
+				},
+				"Update": func() error {
+					_, err := uc.Update(context.Background(),
+						&model.Expense{Id: 1, Amount: 1000, AccountId: tt.accountId, Version: 1})
+					return err
+				},
+			}
+			for call, fn := range calls {
+				err := fn()
+				if tt.wantDetails == nil {
+					if err != nil {
+						t.Errorf("ExpenseUseCase.%s() error = %v", call, err)
+					}
+					continue
+				}
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("ExpenseUseCase.%s() error = %v, want details %q", call, err, tt.wantDetails)
+				}
+			}
+		})
+	}
+
+	t.Run("given an expense that stays in a closed account, then update it", func(t *testing.T) {
+		uc := ExpenseUseCase{
+			Repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, id int) (bool, error) {
+					return true, nil
+				},
+				FindByIDFn: func(ctx context.Context, id int) (*model.Expense, error) {
+					return &model.Expense{Id: id, Amount: 1000, AccountId: 2, Version: 1}, nil
+				},
+				UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					return e, nil
+				},
+			},
+			Accounts: accounts,
+		}
+		got, err := uc.Update(context.Background(), &model.Expense{Id: 1, Amount: 1500, AccountId: 2, Version: 1})
+		if err != nil || got.Currency != "USD" {
+			t.Errorf("ExpenseUseCase.Update() = %+v, %v, want the expense in USD", got, err)
+		}
+	})
+}
+
+func TestExpenseUseCase_Currency(t *testing.T) {
+	tests := []struct {
+		name        string
+		currency    string
+		accountId   int
+		want        string
+		wantDetails []string
+	}{
+		{name: "given a currency, then upper-case it", currency: " eur ", want: "EUR"},
+		{name: "given no currency nor account, then take the reporting currency", want: "COP"},
+		{name: "given no currency, then take the one of the account", accountId: 1, want: "USD"},
+		{
+			name: "given an invalid currency, then get error", currency: "euro",
+			wantDetails: []string{"field Currency must be an ISO 4217 code, e.g. USD"},
+		},
+		{
+			name: "given a currency other than the account's, then get error", currency: "EUR", accountId: 1,
+			wantDetails: []string{"currency EUR does not match the USD of account 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{
+				Repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) {
+						return false, nil
+					},
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+				},
+				Accounts: newAccountBook(checkingAccount),
+				Currency: "COP",
+			}
+			got, err := uc.Save(context.Background(),
+				&model.Expense{Amount: 1000, Currency: tt.currency, AccountId: tt.accountId})
+			if tt.wantDetails != nil {
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("ExpenseUseCase.Save() error = %v, want details %q", err, tt.wantDetails)
+				}
+				return
+			}
+			if err != nil || got.Currency != tt.want {
+				t.Errorf("ExpenseUseCase.Save() currency = %q, %v, want %q", got.Currency, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpenseUseCase_UpdateCurrency(t *testing.T) {
+	tests := []struct {
+		name      string
+		currency  string
+		accountId int
+		want      string
+	}{
+		{name: "given no currency nor account, then keep the stored currency", want: "EUR"},
+		{name: "given no currency, then take the one of the account", accountId: 1, want: "USD"},
+		{name: "given a currency, then write it", currency: "cop", want: "COP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{
+				Repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, id int) (*model.Expense, error) {
+						return &model.Expense{Id: id, Amount: 1000, Currency: "EUR", Version: 1}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+				},
+				Accounts: newAccountBook(checkingAccount),
+				Currency: "COP",
+			}
+			got, err := uc.Update(context.Background(),
+				&model.Expense{Id: 1, Amount: 1500, Currency: tt.currency, AccountId: tt.accountId, Version: 1})
+			if err != nil || got.Currency != tt.want {
+				t.Errorf("ExpenseUseCase.Update() currency = %v, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpenseUseCase_Splits(t *testing.T) {
+	tests := []struct {
+		name        string
+		expense     model.Expense
+		wantDetails []string
+	}{
+		{
+			name: "given lines adding up to the amount, then write the expense",
+			expense: model.Expense{Amount: 5000, Splits: []model.ExpenseSplit{
+				{CategoryId: 1, Amount: 3500, Note: "groceries"}, {CategoryId: 2, Amount: 1000}, {Amount: 500},
+			}},
+		},
+		{
+			name: "given lines not adding up to the amount, then get error",
+			expense: model.Expense{Amount: 5000, Splits: []model.ExpenseSplit{
+				{CategoryId: 1, Amount: 3500}, {CategoryId: 2, Amount: 1000},
+			}},
+			wantDetails: []string{"split amounts add up to 45.00 instead of the expense amount 50.00"},
+		},
+		{
+			name: "given a split expense with a category, then get error",
+			expense: model.Expense{Amount: 5000, CategoryId: 1, Splits: []model.ExpenseSplit{
+				{CategoryId: 1, Amount: 5000},
+			}},
+			wantDetails: []string{"field CategoryId must be empty on a split expense, set it on its lines"},
+		},
+		{
+			name: "given invalid lines, then get every problem",
+			expense: model.Expense{Amount: 5000, Splits: []model.ExpenseSplit{
+				{CategoryId: -1, Amount: 5000}, {Amount: 0},
+			}},
+			wantDetails: []string{
+				"field Splits[0].CategoryId must be a positive integer",
+				"field Splits[1].Amount must be greater than zero",
+			},
+		},
+		{
+			name: "given a line in an unknown category, then get error",
+			expense: model.Expense{Amount: 5000, Splits: []model.ExpenseSplit{
+				{CategoryId: 1, Amount: 2500}, {CategoryId: 9, Amount: 2500},
+			}},
+			wantDetails: []string{"category 9 does not exist"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ExpenseUseCase{
+				Repository: &mocks.ExpenseRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) {
+						return id == 1, nil
+					},
+					FindByIDFn: func(ctx context.Context, id int) (*model.Expense, error) {
+						return &model.Expense{Id: id, Amount: 5000, Version: 1}, nil
+					},
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						return e, nil
+					},
+				},
+				Categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) { return id <= 2, nil },
+				},
+			}
+			calls := map[string]func() error{
+				"Save": func() error {
+					expense := tt.expense
+					_, err := uc.Save(context.Background(), &expense)
+					return err
+				},
+				"Update": func() error {
+					expense := tt.expense
+					expense.Id, expense.Version = 1, 1
+					_, err := uc.Update(context.Background(), &expense)
+					return err
+				},
+			}
+			for call, fn := range calls {
+				err := fn()
+				if tt.wantDetails == nil {
+					if err != nil {
+						t.Errorf("ExpenseUseCase.%s() error = %v", call, err)
+					}
+					continue
+				}
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("ExpenseUseCase.%s() error = %v, want details %q", call, err, tt.wantDetails)
+				}
+			}
+		})
+	}
+}
+
+func TestRunInTransaction(t *testing.T) {
+	audit := &mocks.AuditRepositoryMock{}
+	categories := &mocks.CategoryRepositoryMock{}
+	unitAudit := &mocks.AuditRepositoryMock{}
+	tests := []struct {
+		name      string
+		uow       port.UnitOfWork
+		audit     port.AuditRepository
+		wantAudit port.AuditRepository
+		wantRepos port.CategoryRepository
+	}{
+		{
+			name:      "given no unit of work, then run on the fallback with the audit repository",
+			audit:     audit,
+			wantAudit: audit,
+			wantRepos: categories,
+		},
+		{
+			name:      "given a unit of work, then run on its repositories",
+			uow:       &mocks.UnitOfWorkMock{Repositories: port.Repositories{Audit: unitAudit}},
+			audit:     audit,
+			wantAudit: unitAudit,
+		},
+		{
+			name: "given a unit of work and no audit repository, then run without auditing",
+			uow:  &mocks.UnitOfWorkMock{Repositories: port.Repositories{Audit: unitAudit}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fallback := port.Repositories{Categories: categories}
+			err := runInTransaction(context.Background(), tt.uow, fallback, tt.audit, func(repos port.Repositories) error {
+				if repos.Audit != tt.wantAudit || repos.Categories != tt.wantRepos {
+					t.Errorf("runInTransaction() repositories = %+v, want audit %v and categories %v",
+						repos, tt.wantAudit, tt.wantRepos)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Errorf("runInTransaction() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	ImportName              = "import"
+	ImportedTransactionName = "imported transaction"
+
+	MaxImportTransactions = 10000
+)
+
+// ImportUseCase loads the transactions of a bank export as expenses and
+// incomes.
+type ImportUseCase struct {
+	Expenses   port.ExpenseRepository
+	Incomes    port.IncomeRepository
+	Categories port.CategoryRepository
+	Accounts   port.AccountRepository
+	// Imports remembers the transactions imported with a bank id, so
+	// importing them again skips them.
+	Imports port.ImportedTransactionRepository
+	// Transactions makes an import all or nothing.
+	Transactions port.UnitOfWork
+	// Audit receives an entry for every expense and income created; without
+	// it, imports are not audited.
+	Audit port.AuditRepository
+	// Currency is the reporting currency, which records without a currency
+	// or account of their own are in.
+	Currency string
+}
+
+// ImportCSV reads r as mapping describes it and files the records under
+// target. A file with any invalid row is rejected as a whole, with one
+// InvalidItemError detail per problem. In a dry run nothing is saved and the
+// result previews what would be.
+func (uc ImportUseCase) ImportCSV(ctx context.Context, r io.Reader, mapping model.CSVMapping,
+	target model.ImportTarget, dryRun bool) (*model.ImportResult, error) {
+	mapping, err := normalizeCSVMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := parseCSV(r, mapping)
+	if err != nil {
+		return nil, err
+	}
+	return uc.commit(ctx, transactions, target, dryRun)
+}
+
+// ImportOFX reads r as an OFX or QFX statement and files the records under
+// target. Transactions whose FITID was imported before are skipped and
+// counted as such, so overlapping statements can be imported again.
+func (uc ImportUseCase) ImportOFX(ctx context.Context, r io.Reader, target model.ImportTarget,
+	dryRun bool) (*model.ImportResult, error) {
+	transactions, err := parseOFX(r)
+	if err != nil {
+		return nil, err
+	}
+	return uc.commit(ctx, transactions, target, dryRun)
+}
+
+// commit saves every transaction that isn't a duplicate in a single unit of
+// work and reports what was created. The records go through the checks of
+// the expense and income use cases.
+func (uc ImportUseCase) commit(ctx context.Context, transactions []model.ImportedTransaction,
+	target model.ImportTarget, dryRun bool) (*model.ImportResult, error) {
+	markRepeated(transactions)
+	if dryRun {
+		if _, err := validateTarget(ctx, uc.repositories(), target, transactions, uc.Currency); err != nil {
+			return nil, err
+		}
+		if err := markImported(ctx, uc.Imports, transactions); err != nil {
+			return nil, err
+		}
+		return summarize(transactions, dryRun), nil
+	}
+
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		if _, err := validateTarget(ctx, repos, target, transactions, uc.Currency); err != nil {
+			return err
+		}
+		if err := markImported(ctx, repos.Imports, transactions); err != nil {
+			return err
+		}
+		for i := range transactions {
+			t := &transactions[i]
+			switch {
+			case t.Duplicate:
+				continue
+			case t.Kind == model.KindExpense:
+				expense := &model.Expense{Amount: t.Amount, Currency: t.Currency, Created: t.Created,
+					CategoryId: target.CategoryId, AccountId: target.AccountId}
+				saved, err := repos.Expenses.Save(ctx, expense)
+				if err != nil {
+					return errors.NewSaveItemError(ExpenseName)
+				}
+				if err := audit(ctx, repos.Audit, model.AuditCreate, saved.Id, nil, saved); err != nil {
+					return err
+				}
+				t.Id = saved.Id
+			case t.Kind == model.KindIncome:
+				income := &model.Income{Amount: t.Amount, Currency: t.Currency, Created: t.Created,
+					AccountId: target.AccountId}
+				saved, err := repos.Incomes.Save(ctx, income)
+				if err != nil {
+					return errors.NewSaveItemError(IncomeName)
+				}
+				err = auditChange(ctx, repos.Audit, IncomeName, model.AuditCreate, saved.Id, nil, saved)
+				if err != nil {
+					return err
+				}
+				t.Id = saved.Id
+			}
+			if t.ExternalId != "" && repos.Imports != nil {
+				if err := repos.Imports.Save(ctx, t); err != nil {
+					return errors.NewSaveItemError(ImportedTransactionName)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summarize(transactions, dryRun), nil
+}
+
+// validateTarget checks the account and category of target like the expense
+// and income use cases do, and returns the account, nil for none. The
+// transactions must be in the currency of the account; those whose file
+// doesn't tell it take it, or reporting without an account.
+func validateTarget(ctx context.Context, repos port.Repositories, target model.ImportTarget,
+	transactions []model.ImportedTransaction, reporting string) (*model.Account, error) {
+	if target.CategoryId < 0 {
+		return nil, errors.NewInvalidItemError(ImportName, "field CategoryId must be a positive integer")
+	}
+	if err := validateCategory(ctx, repos.Categories, &model.Expense{CategoryId: target.CategoryId}); err != nil {
+		return nil, err
+	}
+	account, err := validateAccount(ctx, repos.Accounts, ImportName, target.AccountId, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range transactions {
+		if err := validateCurrency(ImportName, &transactions[i].Currency, account, reporting); err != nil {
+			return nil, err
+		}
+	}
+	return account, nil
+}
+
+// markRepeated flags the transactions whose bank id appears earlier in the
+// same file.
+func markRepeated(transactions []model.ImportedTransaction) {
+	seen := map[[2]string]bool{}
+	for i := range transactions {
+		t := &transactions[i]
+		if t.ExternalId == "" {
+			continue
+		}
+		key := [2]string{t.Account, t.ExternalId}
+		t.Duplicate = seen[key]
+		seen[key] = true
+	}
+}
+
+// markImported flags the transactions imported before.
+func markImported(ctx context.Context, imports port.ImportedTransactionRepository,
+	transactions []model.ImportedTransaction) error {
+	if imports == nil {
+		return nil
+	}
+	for i := range transactions {
+		t := &transactions[i]
+		if t.ExternalId == "" || t.Duplicate {
+			continue
+		}
+		exists, err := imports.Exists(ctx, t.Account, t.ExternalId)
+		if err != nil {
+			return errors.NewFindItemError(ImportedTransactionName)
+		}
+		t.Duplicate = exists
+	}
+	return nil
+}
+
+func summarize(transactions []model.ImportedTransaction, dryRun bool) *model.ImportResult {
+	result := &model.ImportResult{DryRun: dryRun, Transactions: transactions}
+	for _, t := range transactions {
+		switch {
+		case t.Duplicate:
+			result.Skipped++
+		case t.Kind == model.KindExpense:
+			result.Created++
+			result.Expenses++
+		case t.Kind == model.KindIncome:
+			result.Created++
+			result.Incomes++
+		}
+	}
+	return result
+}
+
+// repositories are the ones fn runs on without Transactions.
+func (uc ImportUseCase) repositories() port.Repositories {
+	return port.Repositories{Expenses: uc.Expenses, Incomes: uc.Incomes, Categories: uc.Categories,
+		Accounts: uc.Accounts, Imports: uc.Imports}
+}
@@ -0,0 +1,519 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+func TestImportUseCase_ImportCSV(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 4, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name        string
+		file        string
+		mapping     model.CSVMapping
+		want        []model.ImportedTransaction
+		wantDetails []string
+	}{
+		{
+			name:    "given a signed amount column, then negative amounts are expenses",
+			file:    "Date,Description,Amount\n2023-04-01,Groceries,-25.30\n2023-04-02,Salary,\"1,500.00\"\n",
+			mapping: model.CSVMapping{DateColumn: "Date", AmountColumn: "Amount"},
+			want: []model.ImportedTransaction{
+				{Line: 2, Kind: model.KindExpense, Amount: 2530, Currency: "USD", Created: day(1), Id: 1},
+				{Line: 3, Kind: model.KindIncome, Amount: 150000, Currency: "USD", Created: day(2), Id: 1},
+			},
+		},
+		{
+			name: "given a credit card layout, then positive amounts are expenses",
+			file: "\ufefffecha;valor\n01/04/2023;1.025,30\n02/04/2023;-10\n",
+			mapping: model.CSVMapping{
+				Delimiter: ';', DateColumn: "fecha", DateFormat: "DD/MM/YYYY",
+				DecimalSeparator: ",", Sign: model.PositiveExpenses, AmountColumn: "valor",
+			},
+			want: []model.ImportedTransaction{
+				{Line: 2, Kind: model.KindExpense, Amount: 102530, Currency: "USD", Created: day(1), Id: 1},
+				{Line: 3, Kind: model.KindIncome, Amount: 1000, Currency: "USD", Created: day(2), Id: 1},
+			},
+		},
+		{
+			name:    "given debit and credit columns, then debits are expenses and credits incomes",
+			file:    "date,debit,credit\n2023-04-01,25.30,\n2023-04-02,,-40\n",
+			mapping: model.CSVMapping{DateColumn: "date", DebitColumn: "debit", CreditColumn: "credit"},
+			want: []model.ImportedTransaction{
+				{Line: 2, Kind: model.KindExpense, Amount: 2530, Currency: "USD", Created: day(1), Id: 1},
+				{Line: 3, Kind: model.KindIncome, Amount: 4000, Currency: "USD", Created: day(2), Id: 1},
+			},
+		},
+		{
+			name:    "given a currency column, then keep its codes and fill the empty ones",
+			file:    "date,amount,currency\n2023-04-01,-25.30,eur\n2023-04-02,10,\n",
+			mapping: model.CSVMapping{DateColumn: "date", AmountColumn: "amount", CurrencyColumn: "currency"},
+			want: []model.ImportedTransaction{
+				{Line: 2, Kind: model.KindExpense, Amount: 2530, Currency: "EUR", Created: day(1), Id: 1},
+				{Line: 3, Kind: model.KindIncome, Amount: 1000, Currency: "USD", Created: day(2), Id: 1},
+			},
+		},
+		{
+			name:    "given invalid currencies, then get error",
+			file:    "date,amount,currency\n2023-04-01,-1,euro\n2023-04-02,-1,JPY\n",
+			mapping: model.CSVMapping{DateColumn: "date", AmountColumn: "amount", CurrencyColumn: "currency"},
+			wantDetails: []string{
+				`line 2: currency "euro" is not an ISO 4217 code`,
+				"line 3: currency JPY is not supported, amounts must have 2 decimal places",
+			},
+		},
+		{
+			name:    "given invalid rows, then get one detail per problem with its line",
+			file:    "date,amount\n2023-04-01,-1\n04/02/2023,abc\n2023-04-03,0\n2023-04-04\n",
+			mapping: model.CSVMapping{DateColumn: "date", AmountColumn: "amount"},
+			wantDetails: []string{
+				`line 3: date "04/02/2023" does not match the format YYYY-MM-DD`,
+				`line 3: amount "abc" is not a valid amount`,
+				"line 4: amount must not be zero",
+				"line 5: column amount is missing",
+			},
+		},
+		{
+			name:        "given a row with both debit and credit, then get error",
+			file:        "date,debit,credit\n2023-04-01,1,2\n",
+			mapping:     model.CSVMapping{DateColumn: "date", DebitColumn: "debit", CreditColumn: "credit"},
+			wantDetails: []string{"line 2: exactly one of the debit and credit columns must hold an amount"},
+		},
+		{
+			name:        "given a mapped column missing from the header, then get error",
+			file:        "date,value\n2023-04-01,1\n",
+			mapping:     model.CSVMapping{DateColumn: "date", AmountColumn: "amount"},
+			wantDetails: []string{"column amount is not in the header"},
+		},
+		{
+			name:    "given an incomplete mapping, then get error",
+			file:    "date,amount\n",
+			mapping: model.CSVMapping{DecimalSeparator: "'", Sign: "both"},
+			wantDetails: []string{
+				"date column is required",
+				"decimal separator ' is not supported",
+				"an amount column or both debit and credit columns are required",
+			},
+		},
+		{
+			name:        "given a file without rows, then get error",
+			file:        "date,amount\n",
+			mapping:     model.CSVMapping{DateColumn: "date", AmountColumn: "amount"},
+			wantDetails: []string{"file has no transactions"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ImportUseCase{
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						e.Id = 1
+						return e, nil
+					},
+				},
+				Incomes: &mocks.IncomeRepositoryMock{
+					SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+						i.Id = 1
+						return i, nil
+					},
+				},
+				Currency: "USD",
+			}
+			got, err := uc.ImportCSV(context.Background(), strings.NewReader(tt.file), tt.mapping, model.ImportTarget{}, false)
+			if tt.wantDetails != nil {
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("ImportUseCase.ImportCSV() error = %v, want details %q", err, tt.wantDetails)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportUseCase.ImportCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Transactions, tt.want) {
+				t.Errorf("ImportUseCase.ImportCSV() = %+v, want %+v", got.Transactions, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportUseCase_DryRunAndCommit(t *testing.T) {
+	file := "date,amount\n2023-04-01,-25.30\n2023-04-02,-10\n2023-04-03,100\n"
+	mapping := model.CSVMapping{DateColumn: "date", AmountColumn: "amount"}
+	saveFails := func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+		return nil, errors.ErrUnsupported
+	}
+
+	t.Run("given a dry run, then preview the counts without saving", func(t *testing.T) {
+		uc := ImportUseCase{
+			Expenses: &mocks.ExpenseRepositoryMock{SaveFn: saveFails},
+			Incomes:  &mocks.IncomeRepositoryMock{},
+		}
+		got, err := uc.ImportCSV(context.Background(), strings.NewReader(file), mapping, model.ImportTarget{}, true)
+		if err != nil || !got.DryRun || got.Expenses != 2 || got.Incomes != 1 {
+			t.Errorf("ImportUseCase.ImportCSV() = %+v, %v, want a dry run of 2 expenses and 1 income", got, err)
+		}
+	})
+
+	t.Run("given a commit, then save every transaction in one unit of work under the target and audit it", func(t *testing.T) {
+		runs, audited, saved := 0, 0, 0
+		var records []any
+		uow := &mocks.UnitOfWorkMock{
+			Repositories: port.Repositories{
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						saved++
+						e.Id = saved
+						records = append(records, *e)
+						return e, nil
+					},
+				},
+				Incomes: &mocks.IncomeRepositoryMock{
+					SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+						i.Id = 7
+						records = append(records, *i)
+						return i, nil
+					},
+				},
+				Categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) { return id == 2, nil },
+				},
+				Accounts: newAccountBook(model.Account{Id: 1, Currency: "EUR"}),
+				Audit: &mocks.AuditRepositoryMock{
+					RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+						audited++
+						return nil
+					},
+				},
+			},
+		}
+		uow.RunFn = func(ctx context.Context, fn func(port.Repositories) error) error {
+			runs++
+			return fn(uow.Repositories)
+		}
+		uc := ImportUseCase{Transactions: uow, Audit: &mocks.AuditRepositoryMock{}}
+
+		target := model.ImportTarget{AccountId: 1, CategoryId: 2}
+		got, err := uc.ImportCSV(context.Background(), strings.NewReader(file), mapping, target, false)
+		if err != nil {
+			t.Fatalf("ImportUseCase.ImportCSV() error = %v", err)
+		}
+		if runs != 1 || saved != 2 || audited != 3 {
+			t.Errorf("ImportUseCase.ImportCSV() ran %d units of work, saved %d expenses and audited %d, want 1, 2 and 3",
+				runs, saved, audited)
+		}
+		wantRecords := []any{
+			model.Expense{Id: 1, Amount: 2530, Currency: "EUR", Created: records[0].(model.Expense).Created, CategoryId: 2, AccountId: 1},
+			model.Expense{Id: 2, Amount: 1000, Currency: "EUR", Created: records[1].(model.Expense).Created, CategoryId: 2, AccountId: 1},
+			model.Income{Id: 7, Amount: 10000, Currency: "EUR", Created: records[2].(model.Income).Created, AccountId: 1},
+		}
+		if !reflect.DeepEqual(records, wantRecords) {
+			t.Errorf("ImportUseCase.ImportCSV() saved %+v, want %+v", records, wantRecords)
+		}
+		if ids := []int{got.Transactions[0].Id, got.Transactions[1].Id, got.Transactions[2].Id}; !reflect.DeepEqual(ids, []int{1, 2, 7}) {
+			t.Errorf("ImportUseCase.ImportCSV() ids = %v, want %v", ids, []int{1, 2, 7})
+		}
+	})
+
+	targets := []struct {
+		name   string
+		target model.ImportTarget
+	}{
+		{name: "given an unknown category, then get error", target: model.ImportTarget{CategoryId: 3}},
+		{name: "given a negative category, then get error", target: model.ImportTarget{CategoryId: -1}},
+		{name: "given an unknown account, then get error", target: model.ImportTarget{AccountId: 5}},
+		{name: "given a closed account, then get error", target: model.ImportTarget{AccountId: 4}},
+	}
+	for _, tt := range targets {
+		for _, dryRun := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s (dry run %t)", tt.name, dryRun), func(t *testing.T) {
+				repos := port.Repositories{
+					Expenses: &mocks.ExpenseRepositoryMock{SaveFn: saveFails},
+					Categories: &mocks.CategoryRepositoryMock{
+						ExistsFn: func(ctx context.Context, id int) (bool, error) { return id == 2, nil },
+					},
+					Accounts: newAccountBook(model.Account{Id: 4, Currency: "EUR", Closed: true}),
+				}
+				uc := ImportUseCase{Expenses: repos.Expenses, Categories: repos.Categories, Accounts: repos.Accounts}
+
+				_, err := uc.ImportCSV(context.Background(), strings.NewReader(file), mapping, tt.target, dryRun)
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) {
+					t.Errorf("ImportUseCase.ImportCSV() error = %v, want an InvalidItemError", err)
+				}
+			})
+		}
+	}
+
+	t.Run("given a save error, then the unit of work gets the error to roll back", func(t *testing.T) {
+		var runErr error
+		uow := &mocks.UnitOfWorkMock{
+			Repositories: port.Repositories{
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						if e.Amount == 1000 {
+							return nil, errors.ErrUnsupported
+						}
+						return e, nil
+					},
+				},
+			},
+		}
+		uow.RunFn = func(ctx context.Context, fn func(port.Repositories) error) error {
+			runErr = fn(uow.Repositories)
+			return runErr
+		}
+		uc := ImportUseCase{Transactions: uow}
+
+		if _, err := uc.ImportCSV(context.Background(), strings.NewReader(file), mapping, model.ImportTarget{}, false); err == nil || runErr == nil {
+			t.Errorf("ImportUseCase.ImportCSV() error = %v, want the unit of work to get an error to roll back", err)
+		}
+	})
+}
+
+const ofxSGMLStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20230405</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>011000015<ACCTID>1234<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20230401<DTEND>20230405
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20230401
+<TRNAMT>-25.30
+<FITID>A-001
+<NAME>Groceries &amp; more
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20230402120000.000[-5:EST]
+<TRNAMT>1500
+<FITID>A-002
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const ofxXMLStatement = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CCACCTFROM><ACCTID>9999</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20230403</DTPOSTED>
+            <TRNAMT>-10,50</TRNAMT>
+            <FITID>B-001</FITID>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20230403</DTPOSTED>
+            <TRNAMT>-10,50</TRNAMT>
+            <FITID>B-001</FITID>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestImportUseCase_ImportOFX(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		target      model.ImportTarget
+		imported    map[string]bool
+		want        []model.ImportedTransaction
+		wantCreated int
+		wantSkipped int
+		wantDetails []string
+	}{
+		{
+			name: "given an OFX 1.x statement, then import its transactions with their FITID",
+			file: ofxSGMLStatement,
+			want: []model.ImportedTransaction{
+				{
+					Line: 1, Kind: model.KindExpense, Amount: 2530, Currency: "USD", Account: "1234", ExternalId: "A-001",
+					Created: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), Id: 1,
+				},
+				{
+					Line: 2, Kind: model.KindIncome, Amount: 150000, Currency: "USD", Account: "1234", ExternalId: "A-002",
+					Created: time.Date(2023, 4, 2, 17, 0, 0, 0, time.UTC), Id: 1,
+				},
+			},
+			wantCreated: 2,
+		},
+		{
+			name:     "given a statement imported before, then skip the known transactions",
+			file:     ofxSGMLStatement,
+			imported: map[string]bool{"1234/A-001": true},
+			want: []model.ImportedTransaction{
+				{
+					Line: 1, Kind: model.KindExpense, Amount: 2530, Currency: "USD", Account: "1234", ExternalId: "A-001",
+					Created: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), Duplicate: true,
+				},
+				{
+					Line: 2, Kind: model.KindIncome, Amount: 150000, Currency: "USD", Account: "1234", ExternalId: "A-002",
+					Created: time.Date(2023, 4, 2, 17, 0, 0, 0, time.UTC), Id: 1,
+				},
+			},
+			wantCreated: 1,
+			wantSkipped: 1,
+		},
+		{
+			name:   "given an OFX 2.x statement repeating a FITID, then import it once in the account currency",
+			file:   ofxXMLStatement,
+			target: model.ImportTarget{AccountId: 1},
+			want: []model.ImportedTransaction{
+				{
+					Line: 1, Kind: model.KindExpense, Amount: 1050, Currency: "EUR", Account: "9999", ExternalId: "B-001",
+					Created: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Id: 1,
+				},
+				{
+					Line: 2, Kind: model.KindExpense, Amount: 1050, Currency: "EUR", Account: "9999", ExternalId: "B-001",
+					Created: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Duplicate: true,
+				},
+			},
+			wantCreated: 1,
+			wantSkipped: 1,
+		},
+		{
+			name: "given a transaction in another currency than the statement, then keep its currency",
+			file: "<OFX><STMTRS><CURDEF>USD<BANKACCTFROM><ACCTID>1234</BANKACCTFROM>" +
+				"<STMTTRN><DTPOSTED>20230401<TRNAMT>-5<FITID>C-001" +
+				"<CURRENCY><CURRATE>1.1<CURSYM>eur</CURRENCY></STMTTRN></STMTRS></OFX>",
+			want: []model.ImportedTransaction{
+				{
+					Line: 1, Kind: model.KindExpense, Amount: 500, Currency: "EUR", Account: "1234", ExternalId: "C-001",
+					Created: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), Id: 1,
+				},
+			},
+			wantCreated: 1,
+		},
+		{
+			name:        "given a statement in another currency than the account, then get error",
+			file:        ofxSGMLStatement,
+			target:      model.ImportTarget{AccountId: 1},
+			wantDetails: []string{"currency USD does not match the EUR of account 1"},
+		},
+		{
+			name:        "given an invalid CURDEF, then get error",
+			file:        "<OFX><CURDEF>dollars<STMTTRN><DTPOSTED>20230401<TRNAMT>-1<FITID>1</STMTTRN></OFX>",
+			wantDetails: []string{`transaction 1: currency "DOLLARS" is not an ISO 4217 code`},
+		},
+		{
+			name: "given invalid transactions, then get one detail per problem",
+			file: "<OFX><STMTTRN><DTPOSTED>2023-04-01<TRNAMT>-1<FITID>1</STMTTRN>" +
+				"<STMTTRN><DTPOSTED>20230401<TRNAMT>abc</STMTTRN></OFX>",
+			wantDetails: []string{
+				`transaction 1: DTPOSTED "2023-04-01" is not a valid date`,
+				"transaction 2: FITID is required",
+				`transaction 2: TRNAMT "abc" is not a valid amount`,
+			},
+		},
+		{
+			name:        "given a file that isn't OFX, then get error",
+			file:        "date,amount\n2023-04-01,-1\n",
+			wantDetails: []string{"file is not an OFX statement"},
+		},
+		{
+			name:        "given a statement without transactions, then get error",
+			file:        "<OFX><BANKTRANLIST></BANKTRANLIST></OFX>",
+			wantDetails: []string{"file has no transactions"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := map[string]bool{}
+			uc := ImportUseCase{
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						e.Id = 1
+						return e, nil
+					},
+				},
+				Incomes: &mocks.IncomeRepositoryMock{
+					SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+						i.Id = 1
+						return i, nil
+					},
+				},
+				Accounts: newAccountBook(model.Account{Id: 1, Currency: "EUR"}),
+				Imports: &mocks.ImportedTransactionRepositoryMock{
+					ExistsFn: func(ctx context.Context, account, id string) (bool, error) {
+						return tt.imported[account+"/"+id], nil
+					},
+					SaveFn: func(ctx context.Context, it *model.ImportedTransaction) error {
+						saved[it.Account+"/"+it.ExternalId] = true
+						return nil
+					},
+				},
+			}
+			got, err := uc.ImportOFX(context.Background(), strings.NewReader(tt.file), tt.target, false)
+			if tt.wantDetails != nil {
+				var invalid *customErrors.InvalidItemError
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+					t.Errorf("ImportUseCase.ImportOFX() error = %v, want details %q", err, tt.wantDetails)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportUseCase.ImportOFX() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Transactions, tt.want) {
+				t.Errorf("ImportUseCase.ImportOFX() = %+v, want %+v", got.Transactions, tt.want)
+			}
+			if got.Created != tt.wantCreated || got.Skipped != tt.wantSkipped {
+				t.Errorf("ImportUseCase.ImportOFX() created %d and skipped %d, want %d and %d",
+					got.Created, got.Skipped, tt.wantCreated, tt.wantSkipped)
+			}
+			if len(saved) != tt.wantCreated {
+				t.Errorf("ImportUseCase.ImportOFX() remembered %d transactions, want %d", len(saved), tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestImportUseCase_ImportOFXDryRun(t *testing.T) {
+	uc := ImportUseCase{
+		Imports: &mocks.ImportedTransactionRepositoryMock{
+			ExistsFn: func(ctx context.Context, account, id string) (bool, error) {
+				return id == "A-002", nil
+			},
+		},
+	}
+	got, err := uc.ImportOFX(context.Background(), strings.NewReader(ofxSGMLStatement), model.ImportTarget{}, true)
+	if err != nil || !got.DryRun || got.Created != 1 || got.Skipped != 1 || got.Expenses != 1 || got.Incomes != 0 {
+		t.Errorf("ImportUseCase.ImportOFX() = %+v, %v, want a dry run creating 1 expense and skipping 1", got, err)
+	}
+
+	uc.Imports = &mocks.ImportedTransactionRepositoryMock{
+		ExistsFn: func(ctx context.Context, account, id string) (bool, error) {
+			return false, errors.ErrUnsupported
+		},
+	}
+	if _, err := uc.ImportOFX(context.Background(), strings.NewReader(ofxSGMLStatement), model.ImportTarget{}, true); err == nil {
+		t.Errorf("ImportUseCase.ImportOFX() error = nil, want error")
+	}
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	IncomeName     = "income"
+	IncomeIfExists = "income if exists"
+)
+
+type IncomeUseCase struct {
+	Repository port.IncomeRepository
+	// Accounts rejects incomes for unknown or closed accounts.
+	Accounts port.AccountRepository
+	// Transactions makes the checks and the write of Save, Update and
+	// Delete atomic.
+	Transactions port.UnitOfWork
+	// Audit receives an entry for every change; without it, changes are not
+	// audited.
+	Audit port.AuditRepository
+	// Currency is the reporting currency, which records without a currency
+	// or account of their own are in.
+	Currency string
+}
+
+func (uc IncomeUseCase) FindByID(ctx context.Context, id int) (*model.Income, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(IncomeIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(IncomeName)
+	}
+	return uc.Repository.FindByID(ctx, id)
+}
+
+func (uc IncomeUseCase) FindAll(ctx context.Context) ([]model.Income, error) {
+	return uc.Repository.FindAll(ctx)
+}
+
+func (uc IncomeUseCase) Save(ctx context.Context, income *model.Income) (*model.Income, error) {
+	if income.Id < 0 {
+		return nil, errors.NewInvalidItemError(IncomeName, "field Id must be a positive integer")
+	}
+
+	var result *model.Income
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		exists, err := repos.Incomes.Exists(ctx, income.Id)
+		if err != nil {
+			return errors.NewFindItemError(IncomeIfExists)
+		}
+		if exists {
+			return errors.NewItemAlreadyExistsError(IncomeName)
+		}
+		account, err := validateAccount(ctx, repos.Accounts, IncomeName, income.AccountId, 0)
+		if err != nil {
+			return err
+		}
+		if err := validateCurrency(IncomeName, &income.Currency, account, uc.Currency); err != nil {
+			return err
+		}
+
+		if result, err = repos.Incomes.Save(ctx, income); err != nil {
+			return errors.NewSaveItemError(IncomeName)
+		}
+		return auditChange(ctx, repos.Audit, IncomeName, model.AuditCreate, result.Id, nil, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc IncomeUseCase) Update(ctx context.Context, income *model.Income) (*model.Income, error) {
+	var result *model.Income
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		exists, err := repos.Incomes.Exists(ctx, income.Id)
+		if err != nil {
+			return errors.NewFindItemError(IncomeIfExists)
+		}
+		if !exists {
+			return errors.NewItemNotFoundError(IncomeName)
+		}
+		stored, err := repos.Incomes.FindByID(ctx, income.Id)
+		if err != nil {
+			return errors.NewFindItemError(IncomeName)
+		}
+		account, err := validateAccount(ctx, repos.Accounts, IncomeName, income.AccountId, stored.AccountId)
+		if err != nil {
+			return err
+		}
+		if err := validateCurrency(IncomeName, &income.Currency, account, stored.Currency); err != nil {
+			return err
+		}
+
+		if result, err = repos.Incomes.Update(ctx, income); err != nil {
+			return errors.NewUpdateItemError(IncomeName)
+		}
+		return auditChange(ctx, repos.Audit, IncomeName, model.AuditUpdate, result.Id, stored, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc IncomeUseCase) Delete(ctx context.Context, id int) error {
+	return runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		exists, err := repos.Incomes.Exists(ctx, id)
+		if err != nil {
+			return errors.NewFindItemError(IncomeIfExists)
+		}
+		if !exists {
+			return errors.NewItemNotFoundError(IncomeName)
+		}
+		var before *model.Income
+		if repos.Audit != nil {
+			if before, err = repos.Incomes.FindByID(ctx, id); err != nil {
+				return errors.NewFindItemError(IncomeName)
+			}
+		}
+
+		if err := repos.Incomes.Delete(ctx, id); err != nil {
+			return errors.NewDeleteItemError(IncomeName)
+		}
+		return auditChange(ctx, repos.Audit, IncomeName, model.AuditDelete, id, before, nil)
+	})
+}
+
+// repositories are the ones fn runs on without Transactions.
+func (uc IncomeUseCase) repositories() port.Repositories {
+	return port.Repositories{Incomes: uc.Repository, Accounts: uc.Accounts}
+}
@@ -0,0 +1,644 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+func TestIncomeUseCaseFindByID(t *testing.T) {
+	type fields struct {
+		repository port.IncomeRepository
+	}
+	type args struct {
+		id int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *model.Income
+		wantErr bool
+	}{
+		{
+			name: "given an id then get an income model",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, s int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, id int) (*model.Income, error) {
+						return &model.Income{
+							Id:      1,
+							Amount:  2530,
+							Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
+						}, nil
+					},
+				},
+			},
+			args: args{id: 1},
+			want: &model.Income{
+				Id:      1,
+				Amount:  2530,
+				Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
+			},
+			wantErr: false,
+		},
+		{
+			name: "given an id, when the income not exists then get an error",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, s int) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args:    args{id: 1},
+			wantErr: true,
+		},
+		{
+			name: "given an id, when check if the income exists, then get an error",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, s int) (bool, error) {
+						return false, errors.ErrUnsupported
+					},
+				},
+			},
+			args:    args{id: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := IncomeUseCase{
+				Repository: tt.fields.repository,
+			}
+			got, err := uc.FindByID(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeUseCase.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IncomeUseCase.FindByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomeUseCaseFindAll(t *testing.T) {
+	type fields struct {
+		repository port.IncomeRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []model.Income
+		wantErr bool
+	}{
+		{
+			name: "got an array of incomes",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					FindAllFn: func(ctx context.Context) ([]model.Income, error) {
+						return []model.Income{
+							{
+								Id:      1,
+								Amount:  3350,
+								Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
+							},
+							{
+								Id:      1,
+								Amount:  2470,
+								Created: time.Date(2023, 4, 16, 0, 0, 0, 0, time.Local),
+							},
+						}, nil
+					},
+				},
+			},
+			want: []model.Income{
+				{
+					Id:      1,
+					Amount:  3350,
+					Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
+				},
+				{
+					Id:      1,
+					Amount:  2470,
+					Created: time.Date(2023, 4, 16, 0, 0, 0, 0, time.Local),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "got an empty array",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					FindAllFn: func(ctx context.Context) ([]model.Income, error) {
+						return []model.Income{}, nil
+					},
+				},
+			},
+			want:    []model.Income{},
+			wantErr: false,
+		},
+		{
+			name: "got an error",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					FindAllFn: func(ctx context.Context) ([]model.Income, error) {
+						return nil, errors.New("error finding incomes")
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := IncomeUseCase{
+				Repository: tt.fields.repository,
+			}
+			got, err := uc.FindAll(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeUseCase.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IncomeUseCase.FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomeUseCaseSave(t *testing.T) {
+	type fields struct {
+		repository port.IncomeRepository
+	}
+	type args struct {
+		income *model.Income
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *model.Income
+		wantErr bool
+	}{
+		{
+			name: "given an income, then save with success",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+					SaveFn: func(ctx context.Context, e *model.Income) (*model.Income, error) {
+						return e, nil
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{
+					Id:      1,
+					Amount:  10000,
+					Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
+				},
+			},
+			want: &model.Income{
+				Id:      1,
+				Amount:  10000,
+				Created: time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local),
+			},
+			wantErr: false,
+		},
+		{
+			name: "given an income, when try to save in database, then get error",
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+					SaveFn: func(ctx context.Context, e *model.Income) (*model.Income, error) {
+						return nil, errors.ErrUnsupported
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{
+					Id: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an income, when the id is undefined, then get error",
+			args: args{
+				income: &model.Income{
+					Id: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an income, when exists in database, then get error",
+			args: args{
+				income: &model.Income{
+					Id:     1,
+					Amount: 10000,
+				},
+			},
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an income, when check if exists in database, then get error",
+			args: args{
+				income: &model.Income{
+					Id:     1,
+					Amount: 10000,
+				},
+			},
+			fields: fields{
+				repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, errors.ErrUnsupported
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := IncomeUseCase{
+				Repository: tt.fields.repository,
+			}
+			got, err := uc.Save(context.Background(), tt.args.income)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeUseCase.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IncomeUseCase.Save() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomeUseCase_Update(t *testing.T) {
+	type fields struct {
+		Repository port.IncomeRepository
+	}
+	type args struct {
+		income *model.Income
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *model.Income
+		wantErr bool
+	}{
+		{
+			name: "given an income, update in database with success",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, i int) (*model.Income, error) {
+						return &model.Income{Id: 1, Amount: 10000}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Income) (*model.Income, error) {
+						return e, nil
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{
+					Id:     1,
+					Amount: 20000,
+				},
+			},
+			want: &model.Income{
+				Id:     1,
+				Amount: 20000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "given an income without currency nor account, then keep the stored currency",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, i int) (*model.Income, error) {
+						return &model.Income{Id: 1, Amount: 10000, Currency: "EUR"}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Income) (*model.Income, error) {
+						return e, nil
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{Id: 1, Amount: 20000},
+			},
+			want:    &model.Income{Id: 1, Amount: 20000, Currency: "EUR"},
+			wantErr: false,
+		},
+		{
+			name: "given an income, when check if the income exists in database, then get error",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, errors.ErrUnsupported
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{
+					Id:     1,
+					Amount: 20000,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an income, when the income doesn't exists in database, then get error",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{
+					Id:     1,
+					Amount: 20000,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an income, when get an error on update in database, then get error",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					FindByIDFn: func(ctx context.Context, i int) (*model.Income, error) {
+						return &model.Income{Id: 1, Amount: 10000}, nil
+					},
+					UpdateFn: func(ctx context.Context, e *model.Income) (*model.Income, error) {
+						return nil, errors.ErrUnsupported
+					},
+				},
+			},
+			args: args{
+				income: &model.Income{
+					Id:     1,
+					Amount: 20000,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := IncomeUseCase{
+				Repository: tt.fields.Repository,
+			}
+			got, err := uc.Update(context.Background(), tt.args.income)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeUseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IncomeUseCase.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomeUseCase_Delete(t *testing.T) {
+	type fields struct {
+		Repository port.IncomeRepository
+	}
+	type args struct {
+		id int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "given an id, then delete item with success",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					DeleteFn: func(ctx context.Context, i int) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "given an id, when check if the item exist in database, then get error",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, errors.ErrUnsupported
+					},
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an id, when the item doesn't exist in database, then get error",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "given an id, when get an error on delete item, then get error",
+			fields: fields{
+				Repository: &mocks.IncomeRepositoryMock{
+					ExistsFn: func(ctx context.Context, i int) (bool, error) {
+						return true, nil
+					},
+					DeleteFn: func(ctx context.Context, i int) error {
+						return errors.ErrUnsupported
+					},
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := IncomeUseCase{
+				Repository: tt.fields.Repository,
+			}
+			if err := uc.Delete(context.Background(), tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("IncomeUseCase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIncomeUseCase_Accounts(t *testing.T) {
+	uc := IncomeUseCase{
+		Repository: &mocks.IncomeRepositoryMock{
+			ExistsFn: func(ctx context.Context, i int) (bool, error) {
+				return i == 1, nil
+			},
+			SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+				return i, nil
+			},
+			UpdateFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+				return i, nil
+			},
+			FindByIDFn: func(ctx context.Context, id int) (*model.Income, error) {
+				return &model.Income{Id: id, Amount: 1000, Currency: "USD", AccountId: 2}, nil
+			},
+		},
+		Accounts: newAccountBook(checkingAccount,
+			model.Account{Id: 2, Name: "Savings", Type: model.AccountBank, Currency: "USD", Closed: true}),
+	}
+
+	if _, err := uc.Save(context.Background(), &model.Income{Amount: 1000, AccountId: 1}); err != nil {
+		t.Errorf("IncomeUseCase.Save() to an open account error = %v", err)
+	}
+	if _, err := uc.Save(context.Background(), &model.Income{Amount: 1000, AccountId: 2}); err == nil {
+		t.Errorf("IncomeUseCase.Save() to a closed account error = nil, want error")
+	}
+	if _, err := uc.Update(context.Background(), &model.Income{Id: 1, Amount: 1000, AccountId: 3}); err == nil {
+		t.Errorf("IncomeUseCase.Update() to an unknown account error = nil, want error")
+	}
+	if _, err := uc.Update(context.Background(), &model.Income{Id: 1, Amount: 1500, AccountId: 2}); err != nil {
+		t.Errorf("IncomeUseCase.Update() of an income staying in a closed account error = %v", err)
+	}
+}
+
+func TestIncomeUseCase_Audit(t *testing.T) {
+	repository := &mocks.IncomeRepositoryMock{
+		ExistsFn: func(ctx context.Context, i int) (bool, error) { return i == 1, nil },
+		FindByIDFn: func(ctx context.Context, i int) (*model.Income, error) {
+			return &model.Income{Id: 1, Amount: 10000, Currency: "USD"}, nil
+		},
+		SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+			i.Id = 2
+			return i, nil
+		},
+		UpdateFn: func(ctx context.Context, i *model.Income) (*model.Income, error) { return i, nil },
+		DeleteFn: func(ctx context.Context, i int) error { return nil },
+	}
+	tests := []struct {
+		name       string
+		change     func(uc IncomeUseCase, ctx context.Context) error
+		wantAction model.AuditAction
+		wantId     int
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			name: "given a new income, then record its creation",
+			change: func(uc IncomeUseCase, ctx context.Context) error {
+				_, err := uc.Save(ctx, &model.Income{Amount: 2500})
+				return err
+			},
+			wantAction: model.AuditCreate,
+			wantId:     2,
+			wantAfter:  `{"id":2,"amount":25.00,"currency":"USD","created":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "given an update, then record the income before and after it",
+			change: func(uc IncomeUseCase, ctx context.Context) error {
+				_, err := uc.Update(ctx, &model.Income{Id: 1, Amount: 5000, Currency: "USD"})
+				return err
+			},
+			wantAction: model.AuditUpdate,
+			wantId:     1,
+			wantBefore: `{"id":1,"amount":100.00,"currency":"USD","created":"0001-01-01T00:00:00Z"}`,
+			wantAfter:  `{"id":1,"amount":50.00,"currency":"USD","created":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "given a delete, then record the income before it",
+			change: func(uc IncomeUseCase, ctx context.Context) error {
+				return uc.Delete(ctx, 1)
+			},
+			wantAction: model.AuditDelete,
+			wantId:     1,
+			wantBefore: `{"id":1,"amount":100.00,"currency":"USD","created":"0001-01-01T00:00:00Z"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []model.AuditEntry
+			audit := &mocks.AuditRepositoryMock{
+				RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+					entries = append(entries, *e)
+					return nil
+				},
+			}
+			// the use case's own repositories must not be used inside the unit of work
+			uc := IncomeUseCase{
+				Repository:   &mocks.IncomeRepositoryMock{},
+				Transactions: &mocks.UnitOfWorkMock{Repositories: port.Repositories{Incomes: repository, Audit: audit}},
+				Audit:        audit,
+				Currency:     "USD",
+			}
+
+			if err := tt.change(uc, model.WithActor(context.Background(), "ana")); err != nil {
+				t.Fatalf("IncomeUseCase change error = %v", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("IncomeUseCase recorded %d audit entries, want 1", len(entries))
+			}
+			got := entries[0]
+			if got.Entity != IncomeName || got.EntityId != tt.wantId || got.Action != tt.wantAction || got.Actor != "ana" {
+				t.Errorf("IncomeUseCase recorded %+v, want a %s of income %d by ana", got, tt.wantAction, tt.wantId)
+			}
+			if string(got.Before) != tt.wantBefore || string(got.After) != tt.wantAfter {
+				t.Errorf("IncomeUseCase recorded snapshots %s and %s, want %s and %s",
+					got.Before, got.After, tt.wantBefore, tt.wantAfter)
+			}
+		})
+	}
+}
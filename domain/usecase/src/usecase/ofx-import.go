@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+)
+
+// MaxOFXFileSize bounds the statement files read, which are loaded whole.
+const MaxOFXFileSize = 16 << 20
+
+// ofxDateLayouts are the precisions a DTPOSTED value can have, by length.
+var ofxDateLayouts = map[int]string{
+	8:  "20060102",
+	12: "200601021504",
+	14: "20060102150405",
+}
+
+// ofxTransaction gathers the elements of one STMTTRN aggregate. The
+// elements of its CURRENCY aggregate are keyed like CURRENCY.CURSYM.
+type ofxTransaction struct {
+	account  string
+	currency string
+	fields   map[string]string
+}
+
+// parseOFX reads the STMTTRN entries of an OFX statement, either OFX 1.x,
+// an SGML dialect whose elements may be left unclosed, or OFX 2.x, which is
+// XML. QFX files are OFX with extra elements, which are ignored. Amounts are
+// in the CURDEF of their statement, or in the CURSYM of a transaction's
+// CURRENCY aggregate. Like parseCSV, it reports the problems of every
+// transaction together.
+func parseOFX(r io.Reader) ([]model.ImportedTransaction, error) {
+	content, err := io.ReadAll(io.LimitReader(r, MaxOFXFileSize+1))
+	if err != nil {
+		return nil, errors.NewInvalidItemError(ImportName, err.Error())
+	}
+	if len(content) > MaxOFXFileSize {
+		return nil, errors.NewInvalidItemError(ImportName,
+			fmt.Sprintf("a file can hold at most %d bytes", MaxOFXFileSize))
+	}
+
+	entries, isOFX := ofxTransactions(string(content))
+	if !isOFX {
+		return nil, errors.NewInvalidItemError(ImportName, "file is not an OFX statement")
+	}
+	if len(entries) > MaxImportTransactions {
+		return nil, errors.NewInvalidItemError(ImportName,
+			fmt.Sprintf("a file can hold at most %d transactions", MaxImportTransactions))
+	}
+
+	transactions := []model.ImportedTransaction{}
+	details := []string{}
+	for i, entry := range entries {
+		transaction, problems := parseOFXTransaction(entry)
+		if len(problems) > 0 {
+			for _, problem := range problems {
+				details = append(details, fmt.Sprintf("transaction %d: %s", i+1, problem))
+			}
+			continue
+		}
+		transaction.Line = i + 1
+		transactions = append(transactions, transaction)
+	}
+
+	if len(details) > 0 {
+		return nil, errors.NewInvalidItemError(ImportName, details...)
+	}
+	if len(transactions) == 0 {
+		return nil, errors.NewInvalidItemError(ImportName, "file has no transactions")
+	}
+	return transactions, nil
+}
+
+// ofxTransactions walks the tags of content and collects the elements of
+// every STMTTRN, along with the CURDEF of the statement holding it and the
+// ACCTID of its BANKACCTFROM, CCACCTFROM or INVACCTFROM. An element's value
+// is the text up to the next tag, so closing tags are optional. The header
+// and processing instructions are skipped.
+func ofxTransactions(content string) ([]ofxTransaction, bool) {
+	entries := []ofxTransaction{}
+	var current *ofxTransaction
+	account, currency, aggregate := "", "", ""
+	isOFX := false
+
+	for {
+		start := strings.IndexByte(content, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(content[start:], '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(content[start+1 : start+end]))
+		content = content[start+end+1:]
+		value := content
+		if next := strings.IndexByte(content, '<'); next >= 0 {
+			value = content[:next]
+		}
+		value = strings.TrimSpace(html.UnescapeString(value))
+
+		switch {
+		case strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!"):
+		case tag == "OFX":
+			isOFX = true
+		case tag == "STMTTRN":
+			current = &ofxTransaction{account: account, currency: currency, fields: map[string]string{}}
+		case tag == "/STMTTRN":
+			if current != nil {
+				entries = append(entries, *current)
+				current = nil
+			}
+		case tag == "/"+aggregate:
+			aggregate = ""
+		case strings.HasSuffix(tag, "ACCTFROM") && !strings.HasPrefix(tag, "/"),
+			tag == "CURRENCY", tag == "ORIGCURRENCY":
+			aggregate = tag
+		case strings.HasPrefix(tag, "/") || value == "":
+		case current != nil && aggregate != "":
+			current.fields[aggregate+"."+tag] = value
+		case current != nil:
+			current.fields[tag] = value
+		case tag == "CURDEF":
+			currency = value
+		case tag == "ACCTID" && strings.HasSuffix(aggregate, "ACCTFROM"):
+			account = value
+		}
+	}
+	return entries, isOFX
+}
+
+func parseOFXTransaction(entry ofxTransaction) (model.ImportedTransaction, []string) {
+	transaction := model.ImportedTransaction{Account: entry.account}
+	problems := []string{}
+
+	transaction.Currency = entry.currency
+	if currency, ok := entry.fields["CURRENCY.CURSYM"]; ok {
+		transaction.Currency = currency
+	}
+	transaction.Currency = strings.ToUpper(transaction.Currency)
+	if transaction.Currency != "" && !isCurrencyCode(transaction.Currency) {
+		problems = append(problems, fmt.Sprintf("currency %q is not an ISO 4217 code", transaction.Currency))
+	} else if !model.SupportsCurrency(transaction.Currency) {
+		problems = append(problems, unsupportedCurrency(transaction.Currency))
+	}
+
+	transaction.ExternalId = entry.fields["FITID"]
+	if transaction.ExternalId == "" {
+		problems = append(problems, "FITID is required")
+	}
+
+	if raw, ok := entry.fields["DTPOSTED"]; !ok {
+		problems = append(problems, "DTPOSTED is required")
+	} else if created, err := parseOFXDate(raw); err != nil {
+		problems = append(problems, fmt.Sprintf("DTPOSTED %q is not a valid date", raw))
+	} else {
+		transaction.Created = created
+	}
+
+	raw, ok := entry.fields["TRNAMT"]
+	if !ok {
+		problems = append(problems, "TRNAMT is required")
+		return transaction, problems
+	}
+	if !strings.Contains(raw, ".") {
+		// some banks write the decimal comma of their locale
+		raw = strings.Replace(raw, ",", ".", 1)
+	}
+	amount, err := model.ParseMoney(raw)
+	switch {
+	case err != nil:
+		problems = append(problems, fmt.Sprintf("TRNAMT %q is not a valid amount", entry.fields["TRNAMT"]))
+	case amount == 0:
+		problems = append(problems, "TRNAMT must not be zero")
+	case amount < 0:
+		transaction.Kind, transaction.Amount = model.KindExpense, -amount
+	default:
+		transaction.Kind, transaction.Amount = model.KindIncome, amount
+	}
+
+	return transaction, problems
+}
+
+// parseOFXDate reads a date like 20230401, 20230401120000.000 or
+// 20230401120000[-5:EST]. The time zone only applies when the time is given,
+// so plain dates keep their day.
+func parseOFXDate(raw string) (time.Time, error) {
+	value, zone, hasZone := strings.Cut(raw, "[")
+	value, _, _ = strings.Cut(value, ".")
+	layout, ok := ofxDateLayouts[len(value)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid OFX date %q", raw)
+	}
+
+	location := time.UTC
+	if hasZone && len(value) > 8 {
+		offset, _, _ := strings.Cut(strings.TrimSuffix(zone, "]"), ":")
+		hours, err := strconv.ParseFloat(offset, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid OFX date %q", raw)
+		}
+		location = time.FixedZone("", int(hours*3600))
+	}
+	created, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return created.UTC(), nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// occurrenceDates returns the days rule falls on between from and to, both
+// inclusive, leaving out those before its Start or after its End.
+func occurrenceDates(rule model.RecurringRule, from, to time.Time) []time.Time {
+	start := truncateDay(rule.Start)
+	from, to = truncateDay(from), truncateDay(to)
+	if from.Before(start) {
+		from = start
+	}
+	if rule.End != nil && truncateDay(*rule.End).Before(to) {
+		to = truncateDay(*rule.End)
+	}
+	interval := rule.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	dates := []time.Time{}
+	if to.Before(from) {
+		return dates
+	}
+	// jump close to from instead of walking every occurrence since Start;
+	// one step back covers the days clamped to the end of a month
+	n := unitsBetween(rule.Frequency, start, from)/interval - 1
+	if n < 0 {
+		n = 0
+	}
+	for ; ; n++ {
+		date := nthOccurrence(rule, start, n*interval)
+		if date.After(to) {
+			return dates
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+	}
+}
+
+// nthOccurrence returns the day that is units frequency units after start.
+func nthOccurrence(rule model.RecurringRule, start time.Time, units int) time.Time {
+	day := rule.DayOfMonth
+	if day == 0 {
+		day = start.Day()
+	}
+	switch rule.Frequency {
+	case model.FrequencyWeekly:
+		return start.AddDate(0, 0, 7*units)
+	case model.FrequencyMonthly:
+		return clampedDate(start.Year(), start.Month()+time.Month(units), day)
+	case model.FrequencyYearly:
+		return clampedDate(start.Year()+units, start.Month(), day)
+	default:
+		return start.AddDate(0, 0, units)
+	}
+}
+
+// unitsBetween counts the whole frequency units from start to date.
+func unitsBetween(frequency model.Frequency, start, date time.Time) int {
+	switch frequency {
+	case model.FrequencyWeekly:
+		return int(date.Sub(start).Hours() / 24 / 7)
+	case model.FrequencyMonthly:
+		return (date.Year()-start.Year())*12 + int(date.Month()-start.Month())
+	case model.FrequencyYearly:
+		return date.Year() - start.Year()
+	default:
+		return int(date.Sub(start).Hours() / 24)
+	}
+}
+
+// clampedDate returns the day of the month, or its last day when the month
+// is shorter. month may overflow the year, like in time.Date.
+func clampedDate(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
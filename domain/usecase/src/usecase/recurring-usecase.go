@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	RecurringRuleName     = "recurring rule"
+	RecurringRuleIfExists = "recurring rule if exists"
+	OccurrenceName        = "occurrence"
+
+	MaxOccurrenceDays = 3660
+)
+
+// errOccurrenceCreated rolls back the records made for an occurrence that
+// another run created first.
+var errOccurrenceCreated = goerrors.New("error: occurrence was already created... ")
+
+// RecurringUseCase manages recurring rules and creates the expenses and
+// incomes of their occurrences once they are due.
+type RecurringUseCase struct {
+	Repository port.RecurringRuleRepository
+	Expenses   port.ExpenseRepository
+	Incomes    port.IncomeRepository
+	Categories port.CategoryRepository
+	// Accounts rejects rules for unknown or closed accounts.
+	Accounts port.AccountRepository
+	// Transactions creates the record of an occurrence and marks the
+	// occurrence created atomically, so it is never created twice.
+	Transactions port.UnitOfWork
+	// Audit receives an entry for every expense and income created; without
+	// it, they are not audited.
+	Audit port.AuditRepository
+	// Currency is the reporting currency, which records without a currency
+	// or account of their own are in.
+	Currency string
+}
+
+func (uc RecurringUseCase) FindByID(ctx context.Context, id int) (*model.RecurringRule, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(RecurringRuleIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(RecurringRuleName)
+	}
+	return uc.Repository.FindByID(ctx, id)
+}
+
+func (uc RecurringUseCase) FindAll(ctx context.Context) ([]model.RecurringRule, error) {
+	rules, err := uc.Repository.FindAll(ctx)
+	if err != nil {
+		return nil, errors.NewFindItemError(RecurringRuleName)
+	}
+	return rules, nil
+}
+
+func (uc RecurringUseCase) Save(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	if err := uc.validate(ctx, rule); err != nil {
+		return nil, err
+	}
+	result, err := uc.Repository.Save(ctx, rule)
+	if err != nil {
+		return nil, errors.NewSaveItemError(RecurringRuleName)
+	}
+	return result, nil
+}
+
+// Update changes the occurrences still to come; those already created keep
+// their expense or income.
+func (uc RecurringUseCase) Update(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	exists, err := uc.Repository.Exists(ctx, rule.Id)
+	if err != nil {
+		return nil, errors.NewFindItemError(RecurringRuleIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(RecurringRuleName)
+	}
+	if err := uc.validate(ctx, rule); err != nil {
+		return nil, err
+	}
+	result, err := uc.Repository.Update(ctx, rule)
+	if err != nil {
+		return nil, errors.NewUpdateItemError(RecurringRuleName)
+	}
+	return result, nil
+}
+
+// Delete stops the rule. The expenses and incomes it created are kept.
+func (uc RecurringUseCase) Delete(ctx context.Context, id int) error {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return errors.NewFindItemError(RecurringRuleIfExists)
+	}
+	if !exists {
+		return errors.NewItemNotFoundError(RecurringRuleName)
+	}
+	if err := uc.Repository.Delete(ctx, id); err != nil {
+		return errors.NewDeleteItemError(RecurringRuleName)
+	}
+	return nil
+}
+
+// Occurrences lists the occurrences of the rule with id between from and
+// to, both inclusive, with what became of each.
+func (uc RecurringUseCase) Occurrences(ctx context.Context, id int,
+	from, to time.Time) ([]model.RecurringOccurrence, error) {
+	from, to, err := occurrenceRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := uc.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.occurrences(ctx, *rule, from, to)
+}
+
+// Upcoming lists the occurrences of every rule between from and to, both
+// inclusive, by date.
+func (uc RecurringUseCase) Upcoming(ctx context.Context, from, to time.Time) ([]model.RecurringOccurrence, error) {
+	from, to, err := occurrenceRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := uc.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	upcoming := []model.RecurringOccurrence{}
+	for _, rule := range rules {
+		occurrences, err := uc.occurrences(ctx, rule, from, to)
+		if err != nil {
+			return nil, err
+		}
+		upcoming = append(upcoming, occurrences...)
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Date.Before(upcoming[j].Date)
+	})
+	return upcoming, nil
+}
+
+// Skip keeps the occurrence of the rule on date from being created.
+func (uc RecurringUseCase) Skip(ctx context.Context, id int, date time.Time) (*model.RecurringOccurrence, error) {
+	return uc.change(ctx, id, date, func(o *model.RecurringOccurrence) {
+		o.Status = model.OccurrenceSkipped
+	})
+}
+
+// Override creates the occurrence of the rule on date with amount instead
+// of the rule's. It also brings back a skipped occurrence.
+func (uc RecurringUseCase) Override(ctx context.Context, id int, date time.Time,
+	amount model.Money) (*model.RecurringOccurrence, error) {
+	if amount <= 0 {
+		return nil, errors.NewInvalidItemError(OccurrenceName, "field Amount must be greater than zero")
+	}
+	return uc.change(ctx, id, date, func(o *model.RecurringOccurrence) {
+		o.Status = model.OccurrenceOverridden
+		o.Amount = amount
+	})
+}
+
+// Materialize creates the expenses and incomes of the occurrences due by
+// now that were neither created nor skipped, and returns how many it
+// created. Each rule resumes from the day after its last created
+// occurrence, so days a later change to the rule adds before it are not
+// filled in. Running it again, or from several instances at once, never
+// creates an occurrence twice. A failing rule doesn't stop the others.
+func (uc RecurringUseCase) Materialize(ctx context.Context, now time.Time) (int, error) {
+	rules, err := uc.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, rule := range rules {
+		last, err := uc.Repository.LastCreated(ctx, rule.Id)
+		if err != nil {
+			errs = append(errs, errors.NewFindItemError(OccurrenceName))
+			continue
+		}
+		from := rule.Start
+		if next := last.AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+		occurrences, err := uc.occurrences(ctx, rule, from, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, o := range occurrences {
+			if o.Status == model.OccurrenceCreated || o.Status == model.OccurrenceSkipped {
+				continue
+			}
+			ok, err := uc.create(ctx, rule, o)
+			if err != nil {
+				// the later occurrences of the rule would fail alike
+				errs = append(errs, err)
+				break
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+	return created, goerrors.Join(errs...)
+}
+
+// create saves the record of the occurrence and marks it created, in a
+// single unit of work. It returns false when another run created it first.
+func (uc RecurringUseCase) create(ctx context.Context, rule model.RecurringRule,
+	occurrence model.RecurringOccurrence) (bool, error) {
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), uc.Audit, func(repos port.Repositories) error {
+		if _, err := validateAccount(ctx, repos.Accounts, RecurringRuleName, rule.AccountId, 0); err != nil {
+			return err
+		}
+		switch rule.Kind {
+		case model.KindExpense:
+			saved, err := repos.Expenses.Save(ctx, &model.Expense{
+				Amount: occurrence.Amount, Currency: rule.Currency, Created: occurrence.Date,
+				CategoryId: rule.CategoryId, AccountId: rule.AccountId,
+			})
+			if err != nil {
+				return errors.NewSaveItemError(ExpenseName)
+			}
+			if err := audit(ctx, repos.Audit, model.AuditCreate, saved.Id, nil, saved); err != nil {
+				return err
+			}
+			occurrence.RecordId = saved.Id
+		case model.KindIncome:
+			saved, err := repos.Incomes.Save(ctx, &model.Income{
+				Amount: occurrence.Amount, Currency: rule.Currency, Created: occurrence.Date,
+				AccountId: rule.AccountId,
+			})
+			if err != nil {
+				return errors.NewSaveItemError(IncomeName)
+			}
+			if err := auditChange(ctx, repos.Audit, IncomeName, model.AuditCreate, saved.Id, nil, saved); err != nil {
+				return err
+			}
+			occurrence.RecordId = saved.Id
+		}
+
+		occurrence.Status = model.OccurrenceCreated
+		saved, err := repos.Recurring.SaveOccurrence(ctx, &occurrence)
+		if err != nil {
+			return errors.NewSaveItemError(OccurrenceName)
+		}
+		if !saved {
+			return errOccurrenceCreated
+		}
+		return nil
+	})
+	if goerrors.Is(err, errOccurrenceCreated) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// change stores the occurrence of the rule on date as edit leaves it. Only
+// occurrences not created yet can change.
+func (uc RecurringUseCase) change(ctx context.Context, id int, date time.Time,
+	edit func(o *model.RecurringOccurrence)) (*model.RecurringOccurrence, error) {
+	rule, err := uc.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	date = truncateDay(date)
+	occurrences, err := uc.occurrences(ctx, *rule, date, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, errors.NewInvalidItemError(OccurrenceName,
+			fmt.Sprintf("rule %d has no occurrence on %s", id, date.Format(time.DateOnly)))
+	}
+	occurrence := occurrences[0]
+	created := errors.NewInvalidItemError(OccurrenceName,
+		fmt.Sprintf("occurrence of %s was already created", date.Format(time.DateOnly)))
+	if occurrence.Status == model.OccurrenceCreated {
+		return nil, created
+	}
+
+	edit(&occurrence)
+	saved, err := uc.Repository.SaveOccurrence(ctx, &occurrence)
+	if err != nil {
+		return nil, errors.NewSaveItemError(OccurrenceName)
+	}
+	if !saved {
+		return nil, created
+	}
+	return &occurrence, nil
+}
+
+// occurrences merges the days rule falls on between from and to with the
+// occurrences stored for them. Created occurrences are listed even when a
+// change to the rule moved them off its schedule.
+func (uc RecurringUseCase) occurrences(ctx context.Context, rule model.RecurringRule,
+	from, to time.Time) ([]model.RecurringOccurrence, error) {
+	stored, err := uc.Repository.FindOccurrences(ctx, rule.Id, truncateDay(from), truncateDay(to))
+	if err != nil {
+		return nil, errors.NewFindItemError(OccurrenceName)
+	}
+	byDate := make(map[time.Time]model.RecurringOccurrence, len(stored))
+	occurrences := []model.RecurringOccurrence{}
+	for _, o := range stored {
+		byDate[truncateDay(o.Date)] = o
+		if o.Status == model.OccurrenceCreated {
+			occurrences = append(occurrences, o)
+		}
+	}
+	for _, date := range occurrenceDates(rule, from, to) {
+		o, ok := byDate[date]
+		switch {
+		case !ok:
+			occurrences = append(occurrences, model.RecurringOccurrence{
+				RuleId: rule.Id, Date: date, Status: model.OccurrencePending, Amount: rule.Amount,
+			})
+		case o.Status != model.OccurrenceCreated:
+			occurrences = append(occurrences, o)
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+	return occurrences, nil
+}
+
+// validate normalizes rule and checks it, along with its category and
+// account.
+func (uc RecurringUseCase) validate(ctx context.Context, rule *model.RecurringRule) error {
+	if err := normalizeRecurringRule(rule); err != nil {
+		return err
+	}
+	if err := validateCategory(ctx, uc.Categories, &model.Expense{CategoryId: rule.CategoryId}); err != nil {
+		return err
+	}
+	account, err := validateAccount(ctx, uc.Accounts, RecurringRuleName, rule.AccountId, 0)
+	if err != nil {
+		return err
+	}
+	return validateCurrency(RecurringRuleName, &rule.Currency, account, uc.Currency)
+}
+
+// repositories are the ones fn runs on without Transactions.
+func (uc RecurringUseCase) repositories() port.Repositories {
+	return port.Repositories{Recurring: uc.Repository, Expenses: uc.Expenses, Incomes: uc.Incomes,
+		Categories: uc.Categories, Accounts: uc.Accounts}
+}
+
+func normalizeRecurringRule(rule *model.RecurringRule) error {
+	details := []string{}
+
+	rule.Frequency = model.Frequency(strings.ToUpper(string(rule.Frequency)))
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	rule.Start = truncateDay(rule.Start)
+	if rule.End != nil {
+		end := truncateDay(*rule.End)
+		rule.End = &end
+	}
+
+	if rule.Id < 0 {
+		details = append(details, "field Id must be a positive integer")
+	}
+	if rule.Kind != model.KindExpense && rule.Kind != model.KindIncome {
+		details = append(details, "field Kind must be expense or income")
+	}
+	if rule.Amount <= 0 {
+		details = append(details, "field Amount must be greater than zero")
+	}
+	switch rule.Frequency {
+	case model.FrequencyDaily, model.FrequencyWeekly:
+		if rule.DayOfMonth != 0 {
+			details = append(details, "field DayOfMonth only applies to MONTHLY and YEARLY rules")
+		}
+	case model.FrequencyMonthly, model.FrequencyYearly:
+		if rule.DayOfMonth < 0 || rule.DayOfMonth > 31 {
+			details = append(details, "field DayOfMonth must be between 1 and 31")
+		}
+	default:
+		details = append(details, "field Frequency must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+	if rule.Interval < 0 {
+		details = append(details, "field Interval must be a positive integer")
+	}
+	if rule.CategoryId < 0 {
+		details = append(details, "field CategoryId must be a positive integer")
+	}
+	if rule.CategoryId != 0 && rule.Kind == model.KindIncome {
+		details = append(details, "field CategoryId only applies to expense rules")
+	}
+	if rule.Start.Year() <= 1 {
+		details = append(details, "field Start is required")
+	}
+	if rule.End != nil && rule.End.Before(rule.Start) {
+		details = append(details, "field End must not be before Start")
+	}
+
+	if len(details) > 0 {
+		return errors.NewInvalidItemError(RecurringRuleName, details...)
+	}
+	return nil
+}
+
+// occurrenceRange checks the days occurrences are listed for.
+func occurrenceRange(from, to time.Time) (time.Time, time.Time, error) {
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) {
+		return from, to, errors.NewInvalidItemError(OccurrenceName, "field from must not be after to")
+	}
+	if int(to.Sub(from).Hours()/24)+1 > MaxOccurrenceDays {
+		return from, to, errors.NewInvalidItemError(OccurrenceName,
+			fmt.Sprintf("date range must not exceed %d days", MaxOccurrenceDays))
+	}
+	return from, to, nil
+}
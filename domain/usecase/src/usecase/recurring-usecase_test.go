@@ -0,0 +1,503 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// newRuleBook returns a mock holding rules, keyed by id, and the occurrences
+// saved for them, which it refuses to replace once created.
+func newRuleBook(rules ...model.RecurringRule) (*mocks.RecurringRuleRepositoryMock,
+	map[time.Time]model.RecurringOccurrence) {
+	occurrences := map[time.Time]model.RecurringOccurrence{}
+	byId := map[int]model.RecurringRule{}
+	for _, r := range rules {
+		byId[r.Id] = r
+	}
+	return &mocks.RecurringRuleRepositoryMock{
+		ExistsFn: func(ctx context.Context, id int) (bool, error) {
+			_, ok := byId[id]
+			return ok, nil
+		},
+		FindByIDFn: func(ctx context.Context, id int) (*model.RecurringRule, error) {
+			r := byId[id]
+			return &r, nil
+		},
+		FindAllFn: func(ctx context.Context) ([]model.RecurringRule, error) {
+			return rules, nil
+		},
+		SaveFn: func(ctx context.Context, r *model.RecurringRule) (*model.RecurringRule, error) {
+			r.Id = len(byId) + 1
+			return r, nil
+		},
+		FindOccurrencesFn: func(ctx context.Context, ruleId int, from, to time.Time) ([]model.RecurringOccurrence, error) {
+			found := []model.RecurringOccurrence{}
+			for date, o := range occurrences {
+				if o.RuleId == ruleId && !date.Before(from) && !date.After(to) {
+					found = append(found, o)
+				}
+			}
+			return found, nil
+		},
+		SaveOccurrenceFn: func(ctx context.Context, o *model.RecurringOccurrence) (bool, error) {
+			if occurrences[o.Date].Status == model.OccurrenceCreated {
+				return false, nil
+			}
+			occurrences[o.Date] = *o
+			return true, nil
+		},
+		LastCreatedFn: func(ctx context.Context, ruleId int) (time.Time, error) {
+			var last time.Time
+			for date, o := range occurrences {
+				if o.RuleId == ruleId && o.Status == model.OccurrenceCreated && date.After(last) {
+					last = date
+				}
+			}
+			return last, nil
+		},
+	}, occurrences
+}
+
+var rentRule = model.RecurringRule{
+	Id: 1, Kind: model.KindExpense, Description: "rent", Amount: 120000, Currency: "USD",
+	Frequency: model.FrequencyMonthly, Interval: 1, Start: day(2023, time.January, 31),
+}
+
+func TestOccurrenceDates(t *testing.T) {
+	end := day(2023, time.January, 10)
+	tests := []struct {
+		name     string
+		rule     model.RecurringRule
+		from, to time.Time
+		want     []time.Time
+	}{
+		{
+			name: "given a monthly rule on the 31st, then shorter months get their last day",
+			rule: rentRule,
+			from: day(2023, time.January, 1), to: day(2023, time.May, 1),
+			want: []time.Time{
+				day(2023, time.January, 31), day(2023, time.February, 28),
+				day(2023, time.March, 31), day(2023, time.April, 30),
+			},
+		},
+		{
+			name: "given a day of month before the start day, then the first occurrence is next month",
+			rule: model.RecurringRule{
+				Frequency: model.FrequencyMonthly, Interval: 3, DayOfMonth: 5, Start: day(2023, time.January, 20),
+			},
+			from: day(2023, time.January, 1), to: day(2023, time.December, 31),
+			want: []time.Time{day(2023, time.April, 5), day(2023, time.July, 5), day(2023, time.October, 5)},
+		},
+		{
+			name: "given a weekly rule every two weeks, then get every other week from a later day",
+			rule: model.RecurringRule{
+				Frequency: model.FrequencyWeekly, Interval: 2, Start: day(2023, time.January, 6),
+			},
+			from: day(2023, time.March, 1), to: day(2023, time.March, 31),
+			want: []time.Time{day(2023, time.March, 3), day(2023, time.March, 17), day(2023, time.March, 31)},
+		},
+		{
+			name: "given a daily rule with an end, then stop on the end",
+			rule: model.RecurringRule{
+				Frequency: model.FrequencyDaily, Start: day(2023, time.January, 8), End: &end,
+			},
+			from: day(2023, time.January, 1), to: day(2023, time.January, 31),
+			want: []time.Time{day(2023, time.January, 8), day(2023, time.January, 9), day(2023, time.January, 10)},
+		},
+		{
+			name: "given a yearly rule on a leap day, then other years get February 28",
+			rule: model.RecurringRule{
+				Frequency: model.FrequencyYearly, Interval: 1, Start: day(2024, time.February, 29),
+			},
+			from: day(2024, time.January, 1), to: day(2026, time.December, 31),
+			want: []time.Time{day(2024, time.February, 29), day(2025, time.February, 28), day(2026, time.February, 28)},
+		},
+		{
+			name: "given a range before the start, then get no occurrences",
+			rule: rentRule,
+			from: day(2022, time.January, 1), to: day(2022, time.December, 31),
+			want: []time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := occurrenceDates(tt.rule, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("occurrenceDates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurringUseCase_Save(t *testing.T) {
+	closed := model.Account{Id: 3, Name: "Old", Type: model.AccountBank, Currency: "USD", Closed: true}
+	tests := []struct {
+		name        string
+		rule        model.RecurringRule
+		want        *model.RecurringRule
+		wantDetails []string
+	}{
+		{
+			name: "given a rule, then save it normalized",
+			rule: model.RecurringRule{
+				Kind: model.KindExpense, Amount: 120000, CategoryId: 2, AccountId: 1, Frequency: "monthly",
+				Start: time.Date(2023, time.January, 31, 15, 4, 5, 0, time.UTC),
+			},
+			want: &model.RecurringRule{
+				Id: 1, Kind: model.KindExpense, Amount: 120000, Currency: "USD", CategoryId: 2, AccountId: 1,
+				Frequency: model.FrequencyMonthly, Interval: 1, Start: day(2023, time.January, 31),
+			},
+		},
+		{
+			name: "given an invalid rule, then get every problem",
+			rule: model.RecurringRule{
+				Kind: "transfer", Amount: -5, Frequency: model.FrequencyWeekly, DayOfMonth: 3, Interval: -1,
+			},
+			wantDetails: []string{
+				"field Kind must be expense or income",
+				"field Amount must be greater than zero",
+				"field DayOfMonth only applies to MONTHLY and YEARLY rules",
+				"field Interval must be a positive integer",
+				"field Start is required",
+			},
+		},
+		{
+			name: "given an unknown frequency and an end before the start, then get invalid item",
+			rule: model.RecurringRule{
+				Kind: model.KindIncome, Amount: 100, Frequency: "HOURLY", CategoryId: 2,
+				Start: day(2023, time.May, 1), End: &rentRule.Start,
+			},
+			wantDetails: []string{
+				"field Frequency must be DAILY, WEEKLY, MONTHLY or YEARLY",
+				"field CategoryId only applies to expense rules",
+				"field End must not be before Start",
+			},
+		},
+		{
+			name: "given a missing category, then get invalid item",
+			rule: model.RecurringRule{
+				Kind: model.KindExpense, Amount: 100, CategoryId: 9, Frequency: model.FrequencyDaily, Start: rentRule.Start,
+			},
+			wantDetails: []string{"category 9 does not exist"},
+		},
+		{
+			name: "given a closed account, then get invalid item",
+			rule: model.RecurringRule{
+				Kind: model.KindIncome, Amount: 100, AccountId: 3, Frequency: model.FrequencyDaily, Start: rentRule.Start,
+			},
+			wantDetails: []string{"account 3 is closed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, _ := newRuleBook()
+			uc := RecurringUseCase{
+				Repository: rules,
+				Categories: &mocks.CategoryRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) { return id == 2, nil },
+				},
+				Accounts: newAccountBook(checkingAccount, closed),
+			}
+			rule := tt.rule
+			got, err := uc.Save(context.Background(), &rule)
+
+			if tt.wantDetails == nil {
+				if err != nil || !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("RecurringUseCase.Save() = %+v, %v, want %+v", got, err, tt.want)
+				}
+				return
+			}
+			var invalid *customErrors.InvalidItemError
+			if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+				t.Errorf("RecurringUseCase.Save() error = %v, want details %q", err, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestRecurringUseCase_Materialize(t *testing.T) {
+	salary := model.RecurringRule{
+		Id: 2, Kind: model.KindIncome, Amount: 300000, Frequency: model.FrequencyMonthly, Interval: 1,
+		DayOfMonth: 15, Start: day(2023, time.March, 1),
+	}
+	rules, occurrences := newRuleBook(rentRule, salary)
+	occurrences[day(2023, time.February, 28)] = model.RecurringOccurrence{
+		RuleId: 1, Date: day(2023, time.February, 28), Status: model.OccurrenceSkipped, Amount: 120000,
+	}
+	occurrences[day(2023, time.March, 31)] = model.RecurringOccurrence{
+		RuleId: 1, Date: day(2023, time.March, 31), Status: model.OccurrenceOverridden, Amount: 90000,
+	}
+
+	expenses := []model.Expense{}
+	incomes := []model.Income{}
+	repos := port.Repositories{
+		Expenses: &mocks.ExpenseRepositoryMock{
+			SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+				e.Id = len(expenses) + 1
+				expenses = append(expenses, *e)
+				return e, nil
+			},
+		},
+		Incomes: &mocks.IncomeRepositoryMock{
+			SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+				i.Id = len(incomes) + 1
+				incomes = append(incomes, *i)
+				return i, nil
+			},
+		},
+		Recurring: rules,
+	}
+	uc := RecurringUseCase{Repository: rules, Transactions: &mocks.UnitOfWorkMock{Repositories: repos}}
+	now := time.Date(2023, time.April, 15, 6, 0, 0, 0, time.UTC)
+
+	created, err := uc.Materialize(context.Background(), now)
+	if err != nil || created != 4 {
+		t.Fatalf("RecurringUseCase.Materialize() = %d, %v, want 4", created, err)
+	}
+	wantExpenses := []model.Expense{
+		{Id: 1, Amount: 120000, Currency: "USD", Created: day(2023, time.January, 31)},
+		{Id: 2, Amount: 90000, Currency: "USD", Created: day(2023, time.March, 31)},
+	}
+	if !reflect.DeepEqual(expenses, wantExpenses) {
+		t.Errorf("RecurringUseCase.Materialize() expenses = %+v, want %+v", expenses, wantExpenses)
+	}
+	wantIncomes := []model.Income{
+		{Id: 1, Amount: 300000, Created: day(2023, time.March, 15)},
+		{Id: 2, Amount: 300000, Created: day(2023, time.April, 15)},
+	}
+	if !reflect.DeepEqual(incomes, wantIncomes) {
+		t.Errorf("RecurringUseCase.Materialize() incomes = %+v, want %+v", incomes, wantIncomes)
+	}
+	if o := occurrences[day(2023, time.March, 31)]; o.Status != model.OccurrenceCreated || o.RecordId != 2 {
+		t.Errorf("RecurringUseCase.Materialize() occurrence = %+v, want created with expense 2", o)
+	}
+
+	created, err = uc.Materialize(context.Background(), now)
+	if err != nil || created != 0 || len(expenses) != 2 || len(incomes) != 2 {
+		t.Errorf("RecurringUseCase.Materialize() again = %d, %v, want nothing created", created, err)
+	}
+}
+
+func TestRecurringUseCase_Materialize_FromLastCreated(t *testing.T) {
+	rules, occurrences := newRuleBook(rentRule)
+	for _, date := range []time.Time{day(2023, time.January, 31), day(2023, time.February, 28)} {
+		occurrences[date] = model.RecurringOccurrence{
+			RuleId: 1, Date: date, Status: model.OccurrenceCreated, Amount: 120000, RecordId: 1,
+		}
+	}
+	findOccurrences := rules.FindOccurrencesFn
+	rules.FindOccurrencesFn = func(ctx context.Context, ruleId int, from, to time.Time) ([]model.RecurringOccurrence, error) {
+		if want := day(2023, time.March, 1); !from.Equal(want) {
+			t.Errorf("FindOccurrences() from = %v, want %v", from, want)
+		}
+		return findOccurrences(ctx, ruleId, from, to)
+	}
+	expenses := []model.Expense{}
+	uc := RecurringUseCase{Repository: rules, Transactions: &mocks.UnitOfWorkMock{Repositories: port.Repositories{
+		Expenses: &mocks.ExpenseRepositoryMock{
+			SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+				expenses = append(expenses, *e)
+				return e, nil
+			},
+		},
+		Recurring: rules,
+	}}}
+
+	created, err := uc.Materialize(context.Background(), day(2023, time.April, 15))
+	want := []model.Expense{{Amount: 120000, Currency: "USD", Created: day(2023, time.March, 31)}}
+	if err != nil || created != 1 || !reflect.DeepEqual(expenses, want) {
+		t.Errorf("RecurringUseCase.Materialize() = %d, %v with %+v, want 1 created with %+v", created, err, expenses, want)
+	}
+
+	rules.LastCreatedFn = func(ctx context.Context, ruleId int) (time.Time, error) {
+		return time.Time{}, errors.ErrUnsupported
+	}
+	if _, err := uc.Materialize(context.Background(), day(2023, time.April, 15)); err == nil {
+		t.Errorf("RecurringUseCase.Materialize() when the last created occurrence fails error = nil, want error")
+	}
+}
+
+func TestRecurringUseCase_Materialize_Audit(t *testing.T) {
+	salary := model.RecurringRule{
+		Id: 2, Kind: model.KindIncome, Amount: 300000, Currency: "USD", Frequency: model.FrequencyMonthly,
+		Interval: 1, DayOfMonth: 15, Start: day(2023, time.January, 1),
+	}
+	rules, _ := newRuleBook(rentRule, salary)
+	type record struct {
+		entity string
+		id     int
+		action model.AuditAction
+	}
+	var entries []record
+	audit := &mocks.AuditRepositoryMock{
+		RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+			entries = append(entries, record{e.Entity, e.EntityId, e.Action})
+			return nil
+		},
+	}
+	uc := RecurringUseCase{
+		Repository: rules,
+		Transactions: &mocks.UnitOfWorkMock{Repositories: port.Repositories{
+			Expenses: &mocks.ExpenseRepositoryMock{
+				SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					e.Id = 3
+					return e, nil
+				},
+			},
+			Incomes: &mocks.IncomeRepositoryMock{
+				SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+					i.Id = 4
+					return i, nil
+				},
+			},
+			Recurring: rules,
+			Audit:     audit,
+		}},
+		Audit: audit,
+	}
+
+	created, err := uc.Materialize(context.Background(), day(2023, time.January, 31))
+	want := []record{{ExpenseName, 3, model.AuditCreate}, {IncomeName, 4, model.AuditCreate}}
+	if err != nil || created != 2 || !reflect.DeepEqual(entries, want) {
+		t.Errorf("RecurringUseCase.Materialize() = %d, %v with audit %+v, want 2 created with %+v",
+			created, err, entries, want)
+	}
+}
+
+func TestRecurringUseCase_Materialize_CreatedMeanwhile(t *testing.T) {
+	rules, _ := newRuleBook(rentRule)
+	rules.SaveOccurrenceFn = func(ctx context.Context, o *model.RecurringOccurrence) (bool, error) {
+		return false, nil
+	}
+	rolledBack := 0
+	uc := RecurringUseCase{
+		Repository: rules,
+		Transactions: &mocks.UnitOfWorkMock{RunFn: func(ctx context.Context, fn func(port.Repositories) error) error {
+			err := fn(port.Repositories{
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) { return e, nil },
+				},
+				Recurring: rules,
+			})
+			if err != nil {
+				rolledBack++
+			}
+			return err
+		}},
+	}
+
+	created, err := uc.Materialize(context.Background(), day(2023, time.January, 31))
+	if err != nil || created != 0 || rolledBack != 1 {
+		t.Errorf("RecurringUseCase.Materialize() = %d, %v with %d rollbacks, want 0 created and 1 rollback",
+			created, err, rolledBack)
+	}
+}
+
+func TestRecurringUseCase_SkipAndOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		date        time.Time
+		amount      model.Money
+		want        *model.RecurringOccurrence
+		wantDetails []string
+	}{
+		{
+			name: "given an occurrence, when skipped, then it is stored as skipped",
+			date: day(2023, time.February, 28),
+			want: &model.RecurringOccurrence{
+				RuleId: 1, Date: day(2023, time.February, 28), Status: model.OccurrenceSkipped, Amount: 120000,
+			},
+		},
+		{
+			name:   "given an occurrence, when overridden, then it keeps the new amount",
+			date:   day(2023, time.April, 30),
+			amount: 90000,
+			want: &model.RecurringOccurrence{
+				RuleId: 1, Date: day(2023, time.April, 30), Status: model.OccurrenceOverridden, Amount: 90000,
+			},
+		},
+		{
+			name:        "given a day the rule doesn't fall on, then get invalid item",
+			date:        day(2023, time.February, 27),
+			wantDetails: []string{"rule 1 has no occurrence on 2023-02-27"},
+		},
+		{
+			name:        "given a created occurrence, then get invalid item",
+			date:        day(2023, time.January, 31),
+			wantDetails: []string{"occurrence of 2023-01-31 was already created"},
+		},
+		{
+			name:        "given a negative override, then get invalid item",
+			date:        day(2023, time.April, 30),
+			amount:      -1,
+			wantDetails: []string{"field Amount must be greater than zero"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, occurrences := newRuleBook(rentRule)
+			occurrences[day(2023, time.January, 31)] = model.RecurringOccurrence{
+				RuleId: 1, Date: day(2023, time.January, 31), Status: model.OccurrenceCreated, Amount: 120000, RecordId: 4,
+			}
+			uc := RecurringUseCase{Repository: rules}
+
+			var got *model.RecurringOccurrence
+			var err error
+			if tt.amount == 0 {
+				got, err = uc.Skip(context.Background(), 1, tt.date)
+			} else {
+				got, err = uc.Override(context.Background(), 1, tt.date, tt.amount)
+			}
+
+			if tt.wantDetails == nil {
+				if err != nil || !reflect.DeepEqual(got, tt.want) || occurrences[tt.date] != *tt.want {
+					t.Errorf("RecurringUseCase occurrence = %+v, %v, want %+v", got, err, tt.want)
+				}
+				return
+			}
+			var invalid *customErrors.InvalidItemError
+			if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+				t.Errorf("RecurringUseCase occurrence error = %v, want details %q", err, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestRecurringUseCase_Upcoming(t *testing.T) {
+	weekly := model.RecurringRule{
+		Id: 2, Kind: model.KindExpense, Amount: 2000, Frequency: model.FrequencyWeekly, Interval: 1,
+		Start: day(2023, time.April, 20),
+	}
+	rules, occurrences := newRuleBook(rentRule, weekly)
+	occurrences[day(2023, time.April, 27)] = model.RecurringOccurrence{
+		RuleId: 2, Date: day(2023, time.April, 27), Status: model.OccurrenceSkipped, Amount: 2000,
+	}
+	uc := RecurringUseCase{Repository: rules}
+
+	got, err := uc.Upcoming(context.Background(), day(2023, time.April, 25), day(2023, time.May, 5))
+	want := []model.RecurringOccurrence{
+		{RuleId: 2, Date: day(2023, time.April, 27), Status: model.OccurrenceSkipped, Amount: 2000},
+		{RuleId: 1, Date: day(2023, time.April, 30), Status: model.OccurrencePending, Amount: 120000},
+		{RuleId: 2, Date: day(2023, time.May, 4), Status: model.OccurrencePending, Amount: 2000},
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("RecurringUseCase.Upcoming() = %+v, %v, want %+v", got, err, want)
+	}
+
+	_, err = uc.Upcoming(context.Background(), day(2023, time.May, 5), day(2023, time.April, 25))
+	var invalid *customErrors.InvalidItemError
+	if !errors.As(err, &invalid) {
+		t.Errorf("RecurringUseCase.Upcoming() of a reversed range error = %v, want invalid item", err)
+	}
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+const (
+	TransferName     = "transfer"
+	TransferIfExists = "transfer if exists"
+)
+
+// TransferUseCase moves money between accounts. A transfer is a single
+// record that debits one account and credits the other, so it never shows
+// up in the income and expense reports.
+type TransferUseCase struct {
+	Repository port.TransferRepository
+	Accounts   port.AccountRepository
+	// Transactions makes the account checks and the write of Save atomic,
+	// so an account can't be closed in between.
+	Transactions port.UnitOfWork
+}
+
+func (uc TransferUseCase) FindByID(ctx context.Context, id int) (*model.Transfer, error) {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.NewFindItemError(TransferIfExists)
+	}
+	if !exists {
+		return nil, errors.NewItemNotFoundError(TransferName)
+	}
+	return uc.Repository.FindByID(ctx, id)
+}
+
+// FindAll returns the transfers from or to accountId, or every transfer when
+// it is zero, oldest first.
+func (uc TransferUseCase) FindAll(ctx context.Context, accountId int) ([]model.Transfer, error) {
+	if accountId < 0 {
+		return nil, errors.NewInvalidItemError(TransferName, "account must be a positive integer")
+	}
+	transfers, err := uc.Repository.FindAll(ctx, accountId)
+	if err != nil {
+		return nil, errors.NewFindItemError(TransferName)
+	}
+	return transfers, nil
+}
+
+// Save records the transfer between two open accounts of the same currency,
+// in that currency.
+func (uc TransferUseCase) Save(ctx context.Context, transfer *model.Transfer) (*model.Transfer, error) {
+	if err := validateTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	var result *model.Transfer
+	err := runInTransaction(ctx, uc.Transactions, uc.repositories(), nil, func(repos port.Repositories) error {
+		from, err := openAccount(ctx, repos.Accounts, TransferName, transfer.FromAccountId)
+		if err != nil {
+			return err
+		}
+		to, err := openAccount(ctx, repos.Accounts, TransferName, transfer.ToAccountId)
+		if err != nil {
+			return err
+		}
+		if from.Currency != to.Currency {
+			return errors.NewInvalidItemError(TransferName,
+				fmt.Sprintf("accounts must share a currency, got %s and %s", from.Currency, to.Currency))
+		}
+		transfer.Currency = from.Currency
+
+		if result, err = repos.Transfers.Save(ctx, transfer); err != nil {
+			return errors.NewSaveItemError(TransferName)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Delete undoes the transfer on both accounts.
+func (uc TransferUseCase) Delete(ctx context.Context, id int) error {
+	exists, err := uc.Repository.Exists(ctx, id)
+	if err != nil {
+		return errors.NewFindItemError(TransferIfExists)
+	}
+	if !exists {
+		return errors.NewItemNotFoundError(TransferName)
+	}
+
+	if err := uc.Repository.Delete(ctx, id); err != nil {
+		return errors.NewDeleteItemError(TransferName)
+	}
+
+	return nil
+}
+
+// repositories are the ones fn runs on without Transactions.
+func (uc TransferUseCase) repositories() port.Repositories {
+	return port.Repositories{Transfers: uc.Repository, Accounts: uc.Accounts}
+}
+
+func validateTransfer(transfer *model.Transfer) error {
+	details := []string{}
+
+	if transfer.Id < 0 {
+		details = append(details, "field Id must be a positive integer")
+	}
+	if transfer.FromAccountId <= 0 {
+		details = append(details, "field FromAccountId must be a positive integer")
+	}
+	if transfer.ToAccountId <= 0 {
+		details = append(details, "field ToAccountId must be a positive integer")
+	}
+	if transfer.FromAccountId == transfer.ToAccountId {
+		details = append(details, "fields FromAccountId and ToAccountId must be different accounts")
+	}
+	if transfer.Amount <= 0 {
+		details = append(details, "field Amount must be greater than zero")
+	}
+	if transfer.Created.IsZero() {
+		details = append(details, "field Created is required")
+	}
+
+	if len(details) > 0 {
+		return errors.NewInvalidItemError(TransferName, details...)
+	}
+	return nil
+}
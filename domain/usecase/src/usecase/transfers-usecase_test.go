@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+)
+
+func TestTransferUseCase_Save(t *testing.T) {
+	savings := model.Account{Id: 2, Name: "Savings", Type: model.AccountBank, Currency: "USD"}
+	closed := model.Account{Id: 3, Name: "Old", Type: model.AccountBank, Currency: "USD", Closed: true}
+	euros := model.Account{Id: 4, Name: "Euros", Type: model.AccountBank, Currency: "EUR"}
+	created := time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		transfer    model.Transfer
+		wantDetails []string
+	}{
+		{
+			name:     "given two open accounts, then save the transfer",
+			transfer: model.Transfer{FromAccountId: 1, ToAccountId: 2, Amount: 10000, Created: created},
+		},
+		{
+			name:        "given the same account on both sides, then get invalid item",
+			transfer:    model.Transfer{FromAccountId: 1, ToAccountId: 1, Amount: 10000, Created: created},
+			wantDetails: []string{"fields FromAccountId and ToAccountId must be different accounts"},
+		},
+		{
+			name:        "given a negative amount, then get invalid item",
+			transfer:    model.Transfer{FromAccountId: 1, ToAccountId: 2, Amount: -100, Created: created},
+			wantDetails: []string{"field Amount must be greater than zero"},
+		},
+		{
+			name:        "given a missing account, then get invalid item",
+			transfer:    model.Transfer{FromAccountId: 1, ToAccountId: 9, Amount: 10000, Created: created},
+			wantDetails: []string{"account 9 does not exist"},
+		},
+		{
+			name:        "given a closed account, then get invalid item",
+			transfer:    model.Transfer{FromAccountId: 3, ToAccountId: 1, Amount: 10000, Created: created},
+			wantDetails: []string{"account 3 is closed"},
+		},
+		{
+			name:        "given accounts in different currencies, then get invalid item",
+			transfer:    model.Transfer{FromAccountId: 1, ToAccountId: 4, Amount: 10000, Created: created},
+			wantDetails: []string{"accounts must share a currency, got USD and EUR"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := false
+			uc := TransferUseCase{
+				Repository: &mocks.TransferRepositoryMock{
+					SaveFn: func(ctx context.Context, t *model.Transfer) (*model.Transfer, error) {
+						saved = true
+						t.Id = 1
+						return t, nil
+					},
+				},
+				Accounts: newAccountBook(checkingAccount, savings, closed, euros),
+			}
+			transfer := tt.transfer
+			got, err := uc.Save(context.Background(), &transfer)
+
+			if tt.wantDetails == nil {
+				if err != nil || got.Id != 1 || got.Currency != "USD" || !saved {
+					t.Errorf("TransferUseCase.Save() = %+v, %v, want a saved transfer in USD", got, err)
+				}
+				return
+			}
+			var invalid *customErrors.InvalidItemError
+			if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Details(), tt.wantDetails) {
+				t.Errorf("TransferUseCase.Save() error = %v, want details %q", err, tt.wantDetails)
+			}
+			if saved {
+				t.Errorf("TransferUseCase.Save() saved an invalid transfer")
+			}
+		})
+	}
+}
+
+func TestTransferUseCase_Delete(t *testing.T) {
+	uc := TransferUseCase{
+		Repository: &mocks.TransferRepositoryMock{
+			ExistsFn: func(ctx context.Context, id int) (bool, error) { return id == 1, nil },
+			DeleteFn: func(ctx context.Context, id int) error { return nil },
+		},
+	}
+	if err := uc.Delete(context.Background(), 1); err != nil {
+		t.Errorf("TransferUseCase.Delete() error = %v", err)
+	}
+	var notFound *customErrors.ItemNotFound
+	if err := uc.Delete(context.Background(), 2); !errors.As(err, &notFound) {
+		t.Errorf("TransferUseCase.Delete() of a missing transfer error = %v, want ItemNotFound", err)
+	}
+}
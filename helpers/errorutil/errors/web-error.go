@@ -3,20 +3,54 @@ package errorutil
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 type WebError struct {
 	code    int
+	reason  string
 	message string
+	details []string
+}
+
+type WebErrorBody struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details"`
 }
 
 func NewWebError(code int, message string) error {
+	return NewWebErrorWithDetails(code, defaultReason(code), message)
+}
+
+func NewWebErrorWithDetails(code int, reason, message string, details ...string) error {
+	if details == nil {
+		details = []string{}
+	}
 	return &WebError{
 		code:    code,
+		reason:  reason,
 		message: message,
+		details: details,
 	}
 }
 
 func (we *WebError) Error() string {
 	return fmt.Sprintf("%d %s: %s", we.code, http.StatusText(we.code), we.message)
 }
+
+func (we *WebError) Code() int {
+	return we.code
+}
+
+func (we *WebError) Body() WebErrorBody {
+	return WebErrorBody{
+		Code:    we.reason,
+		Message: we.message,
+		Details: we.details,
+	}
+}
+
+func defaultReason(code int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(code), " ", "_"))
+}
@@ -0,0 +1,7 @@
+module github.com/enaldo1709/budget-manager/infrastructure/adapters/memory-adapter
+
+go 1.21.1
+
+replace github.com/enaldo1709/budget-manager/domain/model => ../../../domain/model
+
+require github.com/enaldo1709/budget-manager/domain/model v0.0.0-00010101000000-000000000000
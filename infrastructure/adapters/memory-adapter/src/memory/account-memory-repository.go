@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type AccountMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewAccountMemoryAdapter(store *Store) port.AccountRepository {
+	return &AccountMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *AccountMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.accounts.exists(id), nil
+}
+
+func (r *AccountMemoryAdapter) FindByID(ctx context.Context, id int) (*model.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	account, ok := r.store.accounts.rows[id]
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("account")
+	}
+	return &account, nil
+}
+
+func (r *AccountMemoryAdapter) FindAll(ctx context.Context) ([]model.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.accounts.all(), nil
+}
+
+func (r *AccountMemoryAdapter) Save(ctx context.Context, a *model.Account) (*model.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	a.Id = r.store.accounts.nextID()
+	r.store.accounts.set(a.Id, *a)
+	return a, nil
+}
+
+func (r *AccountMemoryAdapter) Update(ctx context.Context, a *model.Account) (*model.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.accounts.exists(a.Id) {
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	r.store.accounts.set(a.Id, *a)
+	return a, nil
+}
+
+// Delete follows the foreign keys of the Postgres schema: expenses and
+// incomes lose their account, and an account with transfers can't be
+// deleted.
+func (r *AccountMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.accounts.exists(id) {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	for _, t := range r.store.transfers.rows {
+		if t.FromAccountId == id || t.ToAccountId == id {
+			return fmt.Errorf("error: account %d is referenced by transfer %d... ", id, t.Id)
+		}
+	}
+	r.store.accounts.remove(id)
+
+	for expenseId, expense := range r.store.expenses.rows {
+		if expense.AccountId == id {
+			expense.AccountId = 0
+			r.store.expenses.set(expenseId, expense)
+		}
+	}
+	for incomeId, income := range r.store.incomes.rows {
+		if income.AccountId == id {
+			income.AccountId = 0
+			r.store.incomes.set(incomeId, income)
+		}
+	}
+	for ruleId, rule := range r.store.recurring.rows {
+		if rule.AccountId == id {
+			rule.AccountId = 0
+			r.store.recurring.set(ruleId, rule)
+		}
+	}
+	return nil
+}
+
+func (r *AccountMemoryAdapter) Totals(ctx context.Context, id int) (*model.BalanceTotals, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	totals := &model.BalanceTotals{}
+	for _, i := range r.store.incomes.rows {
+		if i.AccountId == id {
+			totals.Income += i.Amount
+		}
+	}
+	for _, e := range r.store.expenses.rows {
+		if e.AccountId == id && e.Deleted == nil {
+			totals.Expenses += e.Amount
+		}
+	}
+	return totals, nil
+}
+
+func (r *AccountMemoryAdapter) HasRecords(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, e := range r.store.expenses.rows {
+		if e.AccountId == id {
+			return true, nil
+		}
+	}
+	for _, i := range r.store.incomes.rows {
+		if i.AccountId == id {
+			return true, nil
+		}
+	}
+	for _, t := range r.store.transfers.rows {
+		if t.FromAccountId == id || t.ToAccountId == id {
+			return true, nil
+		}
+	}
+	for _, rule := range r.store.recurring.rows {
+		if rule.AccountId == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
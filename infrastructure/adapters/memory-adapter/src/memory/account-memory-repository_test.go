@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+func Test_accountMemoryRepository_Totals(t *testing.T) {
+	store := NewStore()
+	accounts := NewAccountMemoryAdapter(store)
+	incomes := NewIncomeMemoryAdapter(store)
+	expenses := NewExpenseMemoryAdapter(store)
+	ctx := context.Background()
+
+	checking, _ := accounts.Save(ctx, &model.Account{Name: "Checking", Type: model.AccountBank, Currency: "USD"})
+	card, _ := accounts.Save(ctx, &model.Account{Name: "Visa", Type: model.AccountCard, Currency: "USD"})
+	incomes.Save(context.Background(), &model.Income{Amount: 10000, Created: testDate, AccountId: checking.Id})
+	incomes.Save(context.Background(), &model.Income{Amount: 7000, Created: testDate})
+	expenses.Save(ctx, &model.Expense{Amount: 1000, Created: testDate, AccountId: checking.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 2000, Created: testDate, AccountId: card.Id})
+	deleted, _ := expenses.Save(ctx, &model.Expense{Amount: 9000, Created: testDate, AccountId: checking.Id})
+	expenses.Delete(ctx, deleted.Id)
+
+	empty, _ := accounts.Save(ctx, &model.Account{Name: "Savings", Type: model.AccountBank, Currency: "USD"})
+	if has, _ := accounts.HasRecords(ctx, empty.Id); has {
+		t.Errorf("accountMemoryRepository.HasRecords() of an empty account = true, want false")
+	}
+	if has, _ := accounts.HasRecords(ctx, card.Id); !has {
+		t.Errorf("accountMemoryRepository.HasRecords() of the card = false, want true")
+	}
+
+	totals, _ := accounts.Totals(ctx, checking.Id)
+	if want := (&model.BalanceTotals{Income: 10000, Expenses: 1000}); !reflect.DeepEqual(totals, want) {
+		t.Errorf("accountMemoryRepository.Totals() = %v, want %v", totals, want)
+	}
+	onCard, _ := expenses.FindAll(ctx, model.ExpenseQuery{AccountId: card.Id})
+	if len(onCard) != 1 || onCard[0].Amount != 2000 {
+		t.Errorf("expenseMemoryRepository.FindAll() of the card = %v, want the 20.00 expense", onCard)
+	}
+
+	if err := accounts.Delete(ctx, checking.Id); err != nil {
+		t.Fatalf("accountMemoryRepository.Delete() error = %v", err)
+	}
+	for _, i := range store.incomes.rows {
+		if i.AccountId == checking.Id {
+			t.Errorf("accountMemoryRepository.Delete() left income %d in the account", i.Id)
+		}
+	}
+	for _, e := range store.expenses.rows {
+		if e.AccountId == checking.Id {
+			t.Errorf("accountMemoryRepository.Delete() left expense %d in the account", e.Id)
+		}
+	}
+}
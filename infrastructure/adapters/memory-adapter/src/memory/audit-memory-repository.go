@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type AuditMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewAuditMemoryAdapter(store *Store) port.AuditRepository {
+	return &AuditMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *AuditMemoryAdapter) Record(ctx context.Context, entry *model.AuditEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entry.Id = r.store.audit.nextID()
+	entry.Recorded = storedTime(entry.Recorded)
+	stored := *entry
+	stored.Before = slices.Clone(entry.Before)
+	stored.After = slices.Clone(entry.After)
+	r.store.audit.set(entry.Id, stored)
+	return nil
+}
+
+func (r *AuditMemoryAdapter) FindByEntity(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	entries := []model.AuditEntry{}
+	for _, e := range r.store.audit.all() {
+		if e.Entity == entity && e.EntityId == id {
+			e.Before = slices.Clone(e.Before)
+			e.After = slices.Clone(e.After)
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
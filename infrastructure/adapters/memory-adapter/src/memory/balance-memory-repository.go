@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type BalanceMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewBalanceMemoryAdapter(store *Store) port.BalanceRepository {
+	return &BalanceMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *BalanceMemoryAdapter) TotalsBefore(ctx context.Context, date time.Time,
+	currency string) ([]model.CurrencyTotals, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	totals := currencyTotals{}
+	r.movements(time.Time{}, date, totals.add)
+	totals.collapse(currency)
+	return totals.sorted(), nil
+}
+
+func (r *BalanceMemoryAdapter) DailyTotals(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	totals := currencyTotals{}
+	r.movements(from, to, totals.add)
+	return totals.sorted(), nil
+}
+
+// movements calls fn with every income and expense created in [from, to). A
+// zero from has no lower bound.
+func (r *BalanceMemoryAdapter) movements(from, to time.Time,
+	fn func(created time.Time, currency string, income, expenses model.Money)) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	from, to = storedTime(from), storedTime(to)
+	in := func(created time.Time) bool {
+		return (from.IsZero() || !created.Before(from)) && created.Before(to)
+	}
+	for _, i := range r.store.incomes.rows {
+		if in(i.Created) {
+			fn(i.Created, i.Currency, i.Amount, 0)
+		}
+	}
+	for _, e := range r.store.expenses.rows {
+		if e.Deleted == nil && in(e.Created) {
+			fn(e.Created, e.Currency, 0, e.Amount)
+		}
+	}
+}
+
+// currencyTotals sums amounts per day and currency, like the GROUP BY of the
+// SQL adapters.
+type currencyTotals map[currencyDay]*model.CurrencyTotals
+
+type currencyDay struct {
+	day      time.Time
+	currency string
+}
+
+func (c currencyTotals) add(created time.Time, currency string, income, expenses model.Money) {
+	key := currencyDay{day: storedDate(created), currency: currency}
+	totals, ok := c[key]
+	if !ok {
+		totals = &model.CurrencyTotals{Date: key.day, Currency: currency}
+		c[key] = totals
+	}
+	totals.Income += income
+	totals.Expenses += expenses
+}
+
+// collapse folds the totals of currency into one, dated on the latest of
+// their days, like the SQL adapters do for amounts needing no conversion.
+func (c currencyTotals) collapse(currency string) {
+	var sum *model.CurrencyTotals
+	for key, totals := range c {
+		if key.currency != currency {
+			continue
+		}
+		delete(c, key)
+		if sum == nil {
+			sum = totals
+			continue
+		}
+		if totals.Date.After(sum.Date) {
+			sum.Date = totals.Date
+		}
+		sum.Income += totals.Income
+		sum.Expenses += totals.Expenses
+	}
+	if sum != nil {
+		c[currencyDay{day: sum.Date, currency: currency}] = sum
+	}
+}
+
+// sorted returns the totals ordered by day, then currency.
+func (c currencyTotals) sorted() []model.CurrencyTotals {
+	totals := make([]model.CurrencyTotals, 0, len(c))
+	for _, t := range c {
+		totals = append(totals, *t)
+	}
+	slices.SortFunc(totals, func(a, b model.CurrencyTotals) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Currency, b.Currency)
+	})
+	return totals
+}
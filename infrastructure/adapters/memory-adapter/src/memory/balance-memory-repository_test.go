@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+func Test_balanceMemoryRepository(t *testing.T) {
+	store := NewStore()
+	incomes := NewIncomeMemoryAdapter(store)
+	expenses := NewExpenseMemoryAdapter(store)
+	ctx := context.Background()
+
+	incomes.Save(ctx, &model.Income{Amount: 10000, Created: testDate.AddDate(0, 0, -1)})
+	incomes.Save(ctx, &model.Income{Amount: 4000, Currency: "EUR", Created: testDate.AddDate(0, 0, -1)})
+	incomes.Save(ctx, &model.Income{Amount: 5000, Created: testDate})
+	expenses.Save(ctx, &model.Expense{Amount: 1000, Created: testDate})
+	expenses.Save(ctx, &model.Expense{Amount: 2000, Created: testDate.Add(time.Hour)})
+	expenses.Save(ctx, &model.Expense{Amount: 3000, Created: testDate.AddDate(0, 0, 2)})
+	expenses.Save(ctx, &model.Expense{Amount: 700, Currency: "EUR", Created: testDate.AddDate(0, 0, 2)})
+	deleted, _ := expenses.Save(ctx, &model.Expense{Amount: 9000, Created: testDate})
+	expenses.Delete(ctx, deleted.Id)
+
+	r := NewBalanceMemoryAdapter(store)
+	day := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	before, _ := r.TotalsBefore(ctx, day, "USD")
+	wantBefore := []model.CurrencyTotals{
+		{Date: day.AddDate(0, 0, -1), Income: 10000},
+		{Date: day.AddDate(0, 0, -1), Currency: "EUR", Income: 4000},
+	}
+	if !reflect.DeepEqual(before, wantBefore) {
+		t.Errorf("balanceMemoryRepository.TotalsBefore() = %v, want %v", before, wantBefore)
+	}
+	before, _ = r.TotalsBefore(ctx, day.AddDate(0, 0, 3), "EUR")
+	wantBefore = []model.CurrencyTotals{
+		{Date: day.AddDate(0, 0, -1), Income: 10000},
+		{Date: day, Income: 5000, Expenses: 3000},
+		{Date: day.AddDate(0, 0, 2), Expenses: 3000},
+		{Date: day.AddDate(0, 0, 2), Currency: "EUR", Income: 4000, Expenses: 700},
+	}
+	if !reflect.DeepEqual(before, wantBefore) {
+		t.Errorf("balanceMemoryRepository.TotalsBefore() of the reporting currency = %v, want %v", before, wantBefore)
+	}
+	daily, _ := r.DailyTotals(ctx, day, day.AddDate(0, 0, 3))
+	want := []model.CurrencyTotals{
+		{Date: day, Income: 5000, Expenses: 3000},
+		{Date: day.AddDate(0, 0, 2), Expenses: 3000},
+		{Date: day.AddDate(0, 0, 2), Currency: "EUR", Expenses: 700},
+	}
+	if !reflect.DeepEqual(daily, want) {
+		t.Errorf("balanceMemoryRepository.DailyTotals() = %v, want %v", daily, want)
+	}
+}
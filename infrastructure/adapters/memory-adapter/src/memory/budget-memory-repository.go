@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type BudgetMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewBudgetMemoryAdapter(store *Store) port.BudgetRepository {
+	return &BudgetMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *BudgetMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.budgets.exists(id), nil
+}
+
+func (r *BudgetMemoryAdapter) FindByID(ctx context.Context, id int) (*model.Budget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	budget, ok := r.store.budgets.rows[id]
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("budget")
+	}
+	return &budget, nil
+}
+
+func (r *BudgetMemoryAdapter) FindAll(ctx context.Context) ([]model.Budget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return sortBudgets(r.store.budgets.all()), nil
+}
+
+func (r *BudgetMemoryAdapter) FindByPeriod(ctx context.Context, period string) ([]model.Budget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	budgets := []model.Budget{}
+	for _, b := range r.store.budgets.all() {
+		if b.Period == period {
+			budgets = append(budgets, b)
+		}
+	}
+	return sortBudgets(budgets), nil
+}
+
+func (r *BudgetMemoryAdapter) Save(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.checkUnique(b); err != nil {
+		return nil, err
+	}
+	b.Id = r.store.budgets.nextID()
+	r.store.budgets.set(b.Id, *b)
+	return b, nil
+}
+
+func (r *BudgetMemoryAdapter) Update(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.budgets.exists(b.Id) {
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	if err := r.checkUnique(b); err != nil {
+		return nil, err
+	}
+	r.store.budgets.set(b.Id, *b)
+	return b, nil
+}
+
+func (r *BudgetMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.budgets.exists(id) {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	r.store.budgets.remove(id)
+	return nil
+}
+
+func (r *BudgetMemoryAdapter) Spent(ctx context.Context, categoryId int, from, to time.Time) ([]model.CurrencyTotals, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	from, to = storedTime(from), storedTime(to)
+	categories := r.store.subtree(categoryId)
+
+	spent := currencyTotals{}
+	for _, e := range r.store.expenses.rows {
+		if e.Deleted != nil || e.Created.Before(from) || !e.Created.Before(to) {
+			continue
+		}
+		if categories[e.CategoryId] {
+			spent.add(e.Created, e.Currency, 0, e.Amount)
+		}
+		for _, split := range e.Splits {
+			if categories[split.CategoryId] {
+				spent.add(e.Created, e.Currency, 0, split.Amount)
+			}
+		}
+	}
+	return spent.sorted(), nil
+}
+
+// checkUnique mirrors the unique (category_id, period) constraint of the
+// budgets table.
+func (r *BudgetMemoryAdapter) checkUnique(b *model.Budget) error {
+	for _, other := range r.store.budgets.rows {
+		if other.Id != b.Id && other.CategoryId == b.CategoryId && other.Period == b.Period {
+			return fmt.Errorf("error: budget for category %d and period %s already exists... ",
+				b.CategoryId, b.Period)
+		}
+	}
+	return nil
+}
+
+func sortBudgets(budgets []model.Budget) []model.Budget {
+	slices.SortStableFunc(budgets, func(a, b model.Budget) int {
+		if c := cmp.Compare(a.Period, b.Period); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.CategoryId, b.CategoryId)
+	})
+	return budgets
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+func Test_budgetMemoryRepository_Spent(t *testing.T) {
+	store := NewStore()
+	categories := NewCategoryMemoryAdapter(store)
+	expenses := NewExpenseMemoryAdapter(store)
+	ctx := context.Background()
+
+	food, _ := categories.Save(context.Background(), &model.Category{Name: "food"})
+	groceries, _ := categories.Save(context.Background(), &model.Category{Name: "groceries", ParentId: food.Id})
+	rent, _ := categories.Save(context.Background(), &model.Category{Name: "rent"})
+	expenses.Save(ctx, &model.Expense{Amount: 1000, Created: testDate, CategoryId: food.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 2500, Created: testDate, CategoryId: groceries.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 9000, Created: testDate, CategoryId: rent.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 400, Currency: "EUR", Created: testDate, CategoryId: groceries.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 700, Created: testDate.AddDate(0, 1, 0), CategoryId: food.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 5000, Created: testDate, Splits: []model.ExpenseSplit{
+		{CategoryId: groceries.Id, Amount: 1500}, {CategoryId: rent.Id, Amount: 3000}, {Amount: 500},
+	}})
+
+	got, err := NewBudgetMemoryAdapter(store).Spent(context.Background(), food.Id, testDate.AddDate(0, 0, -1), testDate.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("budgetMemoryRepository.Spent() error = %v", err)
+	}
+	day := storedDate(testDate)
+	want := []model.CurrencyTotals{{Date: day, Expenses: 5000}, {Date: day, Currency: "EUR", Expenses: 400}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("budgetMemoryRepository.Spent() = %v, want %v", got, want)
+	}
+}
+
+func Test_budgetMemoryRepository_Save(t *testing.T) {
+	r := NewBudgetMemoryAdapter(NewStore())
+	r.Save(context.Background(), &model.Budget{CategoryId: 2, Period: "2023-10", Limit: 1000})
+	r.Save(context.Background(), &model.Budget{CategoryId: 1, Period: "2023-10", Limit: 1000})
+	r.Save(context.Background(), &model.Budget{CategoryId: 1, Period: "2023-09", Limit: 1000})
+
+	if _, err := r.Save(context.Background(), &model.Budget{CategoryId: 1, Period: "2023-10", Limit: 500}); err == nil {
+		t.Errorf("budgetMemoryRepository.Save() error = %v, wantErr %v", err, true)
+	}
+	got, _ := r.FindAll(context.Background())
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(budgetIds(got), want) {
+		t.Errorf("budgetMemoryRepository.FindAll() = %v, want %v", budgetIds(got), want)
+	}
+}
+
+func Test_categoryMemoryRepository_Delete(t *testing.T) {
+	store := NewStore()
+	categories := NewCategoryMemoryAdapter(store)
+	food, _ := categories.Save(context.Background(), &model.Category{Name: "food"})
+	categories.Save(context.Background(), &model.Category{Name: "groceries", ParentId: food.Id})
+	NewExpenseMemoryAdapter(store).Save(context.Background(),
+		&model.Expense{Amount: 1000, Created: testDate, CategoryId: food.Id})
+	NewExpenseMemoryAdapter(store).Save(context.Background(), &model.Expense{Amount: 1000, Created: testDate,
+		Splits: []model.ExpenseSplit{{CategoryId: food.Id, Amount: 600}, {CategoryId: 2, Amount: 400}}})
+	NewBudgetMemoryAdapter(store).Save(context.Background(), &model.Budget{CategoryId: food.Id, Period: "2023-10", Limit: 1000})
+
+	if err := categories.Delete(context.Background(), food.Id); err != nil {
+		t.Fatalf("categoryMemoryRepository.Delete() error = %v", err)
+	}
+	if got, _ := categories.FindByID(context.Background(), 2); got.ParentId != 0 {
+		t.Errorf("categoryMemoryRepository.FindByID() parentId = %d, want %d", got.ParentId, 0)
+	}
+	if got, _ := NewExpenseMemoryAdapter(store).FindByID(context.Background(), 1); got.CategoryId != 0 {
+		t.Errorf("expenseMemoryRepository.FindByID() categoryId = %d, want %d", got.CategoryId, 0)
+	}
+	got, _ := NewExpenseMemoryAdapter(store).FindByID(context.Background(), 2)
+	if want := []model.ExpenseSplit{{Amount: 600}, {CategoryId: 2, Amount: 400}}; !reflect.DeepEqual(got.Splits, want) {
+		t.Errorf("expenseMemoryRepository.FindByID() splits = %v, want %v", got.Splits, want)
+	}
+	if exists, _ := NewBudgetMemoryAdapter(store).Exists(context.Background(), 1); exists {
+		t.Errorf("budgetMemoryRepository.Exists() = %v, want %v", exists, false)
+	}
+}
+
+func budgetIds(budgets []model.Budget) []int {
+	ids := []int{}
+	for _, b := range budgets {
+		ids = append(ids, b.Id)
+	}
+	return ids
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type CategoryMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewCategoryMemoryAdapter(store *Store) port.CategoryRepository {
+	return &CategoryMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *CategoryMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.categories.exists(id), nil
+}
+
+func (r *CategoryMemoryAdapter) FindByID(ctx context.Context, id int) (*model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	category, ok := r.store.categories.rows[id]
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("category")
+	}
+	return &category, nil
+}
+
+func (r *CategoryMemoryAdapter) FindAll(ctx context.Context) ([]model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.categories.all(), nil
+}
+
+func (r *CategoryMemoryAdapter) Save(ctx context.Context, c *model.Category) (*model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if c.ParentId != 0 && !r.store.categories.exists(c.ParentId) {
+		return nil, fmt.Errorf("error: parent category %d doesn't exist... ", c.ParentId)
+	}
+	c.Id = r.store.categories.nextID()
+	r.store.categories.set(c.Id, *c)
+	return c, nil
+}
+
+func (r *CategoryMemoryAdapter) Update(ctx context.Context, c *model.Category) (*model.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.categories.exists(c.Id) {
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	if c.ParentId != 0 && !r.store.categories.exists(c.ParentId) {
+		return nil, fmt.Errorf("error: parent category %d doesn't exist... ", c.ParentId)
+	}
+	r.store.categories.set(c.Id, *c)
+	return c, nil
+}
+
+// Delete follows the foreign keys of the Postgres schema: subcategories
+// become top-level, expenses and split lines lose their category and the
+// category budgets are removed.
+func (r *CategoryMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.categories.exists(id) {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	r.store.categories.remove(id)
+
+	for childId, child := range r.store.categories.rows {
+		if child.ParentId == id {
+			child.ParentId = 0
+			r.store.categories.set(childId, child)
+		}
+	}
+	for expenseId, expense := range r.store.expenses.rows {
+		if expense.CategoryId == id {
+			expense.CategoryId = 0
+			r.store.expenses.set(expenseId, expense)
+		}
+		if slices.ContainsFunc(expense.Splits, func(s model.ExpenseSplit) bool { return s.CategoryId == id }) {
+			// the stored splits may be shared with a unit of work snapshot
+			expense = ownSplits(expense)
+			for i := range expense.Splits {
+				if expense.Splits[i].CategoryId == id {
+					expense.Splits[i].CategoryId = 0
+				}
+			}
+			r.store.expenses.set(expenseId, expense)
+		}
+	}
+	for ruleId, rule := range r.store.recurring.rows {
+		if rule.CategoryId == id {
+			rule.CategoryId = 0
+			r.store.recurring.set(ruleId, rule)
+		}
+	}
+	for budgetId, budget := range r.store.budgets.rows {
+		if budget.CategoryId == id {
+			r.store.budgets.remove(budgetId)
+		}
+	}
+	return nil
+}
+
+// subtree returns the ids of the category and all of its descendants. The
+// caller must hold the store lock.
+func (s *Store) subtree(categoryId int) map[int]bool {
+	ids := map[int]bool{categoryId: true}
+	for grown := true; grown; {
+		grown = false
+		for _, c := range s.categories.rows {
+			if c.ParentId != 0 && ids[c.ParentId] && !ids[c.Id] {
+				ids[c.Id] = true
+				grown = true
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type ExchangeRateMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewExchangeRateMemoryAdapter(store *Store) port.ExchangeRateRepository {
+	return &ExchangeRateMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *ExchangeRateMemoryAdapter) Save(ctx context.Context, rates []model.ExchangeRate) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, rate := range rates {
+		rate.Date = storedDate(rate.Date)
+		key := exchangeRateKey{date: rate.Date, base: rate.Base, quote: rate.Quote}
+		setEntry(r.store.undo, r.store.rates, key, rate)
+	}
+	return nil
+}
+
+func (r *ExchangeRateMemoryAdapter) FindEffective(ctx context.Context, base, quote string,
+	date time.Time) (*model.ExchangeRate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	date = storedDate(date)
+	var effective model.ExchangeRate
+	found := false
+	for key, rate := range r.store.rates {
+		if key.base != base || key.quote != quote || key.date.After(date) {
+			continue
+		}
+		if !found || key.date.After(effective.Date) {
+			effective, found = rate, true
+		}
+	}
+	if !found {
+		return nil, customErrors.NewItemNotFoundError("exchange rate")
+	}
+	return &effective, nil
+}
+
+func (r *ExchangeRateMemoryAdapter) FindInEffect(ctx context.Context, base, quote string,
+	from, to time.Time) ([]model.ExchangeRate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	from, to = storedDate(from), storedDate(to)
+	rates := []model.ExchangeRate{}
+	var effective model.ExchangeRate
+	found := false
+	for key, rate := range r.store.rates {
+		switch {
+		case key.base != base || key.quote != quote || key.date.After(to):
+		case key.date.After(from):
+			rates = append(rates, rate)
+		case !found || key.date.After(effective.Date):
+			effective, found = rate, true
+		}
+	}
+	if found {
+		rates = append(rates, effective)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Date.Before(rates[j].Date) })
+	return rates, nil
+}
+
+// storedDate mirrors what the DATE columns of the Postgres schema keep.
+func storedDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/porttest"
+)
+
+func Test_expenseMemoryRepository_Contract(t *testing.T) {
+	porttest.TestExpenseRepository(t, func(t *testing.T) port.ExpenseRepository {
+		return NewExpenseMemoryAdapter(NewStore())
+	})
+}
@@ -0,0 +1,265 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type ExpenseMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewExpenseMemoryAdapter(store *Store) port.ExpenseRepository {
+	return &ExpenseMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *ExpenseMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	_, ok := r.active(id)
+	return ok, nil
+}
+
+func (r *ExpenseMemoryAdapter) FindByID(ctx context.Context, id int) (*model.Expense, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	expense, ok := r.active(id)
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("expense")
+	}
+	expense = ownSplits(expense)
+	return &expense, nil
+}
+
+func (r *ExpenseMemoryAdapter) FindAll(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	field := q.SortBy
+	if field == "" {
+		field = model.SortByCreated
+	}
+	var after *model.Expense
+	if q.After != nil {
+		cursor, err := cursorExpense(*q.After, field)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+	direction := -1
+	if q.Direction == model.SortAscending {
+		direction = 1
+	}
+	compare := func(a, b model.Expense) int {
+		return direction * compareExpenses(a, b, field)
+	}
+
+	r.lock.RLock()
+	expenses := []model.Expense{}
+	for _, e := range r.store.expenses.rows {
+		if e.Deleted == nil && matches(e, q) && (after == nil || compare(e, *after) > 0) {
+			expenses = append(expenses, ownSplits(e))
+		}
+	}
+	r.lock.RUnlock()
+
+	slices.SortFunc(expenses, compare)
+	if q.Limit > 0 && len(expenses) > q.Limit {
+		expenses = expenses[:q.Limit]
+	}
+	return expenses, nil
+}
+
+func (r *ExpenseMemoryAdapter) Save(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	e.Id = r.store.expenses.nextID()
+	e.Created = storedTime(e.Created)
+	e.Version = 1
+	r.store.expenses.set(e.Id, ownSplits(*e))
+	return e, nil
+}
+
+func (r *ExpenseMemoryAdapter) Update(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	stored, ok := r.active(e.Id)
+	if !ok {
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	if stored.Version != e.Version {
+		return nil, customErrors.NewConcurrentModificationError("expense")
+	}
+	e.Created = storedTime(e.Created)
+	e.Version++
+	r.store.expenses.set(e.Id, ownSplits(*e))
+	return e, nil
+}
+
+func (r *ExpenseMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	expense, ok := r.active(id)
+	if !ok {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	deleted := time.Now().UTC().Truncate(time.Second)
+	expense.Deleted = &deleted
+	r.store.expenses.set(id, expense)
+	return nil
+}
+
+func (r *ExpenseMemoryAdapter) FindDeleted(ctx context.Context) ([]model.Expense, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	expenses := []model.Expense{}
+	for _, e := range r.store.expenses.all() {
+		if e.Deleted != nil {
+			deleted := *e.Deleted
+			e.Deleted = &deleted
+			expenses = append(expenses, ownSplits(e))
+		}
+	}
+	slices.SortStableFunc(expenses, func(a, b model.Expense) int {
+		return b.Deleted.Compare(*a.Deleted)
+	})
+	return expenses, nil
+}
+
+func (r *ExpenseMemoryAdapter) Restore(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	expense, ok := r.store.expenses.rows[id]
+	if !ok || expense.Deleted == nil {
+		return customErrors.NewItemNotFoundError("deleted expense")
+	}
+	expense.Deleted = nil
+	r.store.expenses.set(id, expense)
+	return nil
+}
+
+func (r *ExpenseMemoryAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	purged := 0
+	for id, e := range r.store.expenses.rows {
+		if e.Deleted != nil && e.Deleted.Before(before) {
+			r.store.expenses.remove(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// active returns the expense with id unless it is missing or in the trash.
+func (r *ExpenseMemoryAdapter) active(id int) (model.Expense, bool) {
+	expense, ok := r.store.expenses.rows[id]
+	return expense, ok && expense.Deleted == nil
+}
+
+// ownSplits copies the splits of the expense, so that the rows of the store
+// and the expenses handed out never share them.
+func ownSplits(e model.Expense) model.Expense {
+	e.Splits = slices.Clone(e.Splits)
+	return e
+}
+
+func matches(e model.Expense, q model.ExpenseQuery) bool {
+	switch {
+	case !q.From.IsZero() && e.Created.Before(storedTime(q.From)):
+		return false
+	case !q.To.IsZero() && !e.Created.Before(storedTime(q.To)):
+		return false
+	case q.MinAmount != nil && e.Amount < *q.MinAmount:
+		return false
+	case q.MaxAmount != nil && e.Amount > *q.MaxAmount:
+		return false
+	case q.CategoryId != 0 && e.CategoryId != q.CategoryId && !slices.ContainsFunc(e.Splits,
+		func(s model.ExpenseSplit) bool { return s.CategoryId == q.CategoryId }):
+		return false
+	case q.AccountId != 0 && e.AccountId != q.AccountId:
+		return false
+	}
+	return true
+}
+
+// compareExpenses orders expenses by field and then by id, the same keyset
+// the Postgres adapter paginates on.
+func compareExpenses(a, b model.Expense, field model.ExpenseSortField) int {
+	var c int
+	switch field {
+	case model.SortByCreated:
+		c = a.Created.Compare(b.Created)
+	case model.SortByAmount:
+		c = cmp.Compare(a.Amount, b.Amount)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
+
+// cursorExpense rebuilds the sort key a cursor points to.
+func cursorExpense(cursor model.ExpenseCursor, field model.ExpenseSortField) (*model.Expense, error) {
+	e := &model.Expense{Id: cursor.Id}
+	switch field {
+	case model.SortByCreated:
+		created, err := time.Parse(time.RFC3339, cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error: invalid cursor value %s... ", cursor.Value)
+		}
+		e.Created = storedTime(created)
+	case model.SortByAmount:
+		amount, err := model.ParseMoney(cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error: invalid cursor value %s... ", cursor.Value)
+		}
+		e.Amount = amount
+	case model.SortById:
+	default:
+		return nil, fmt.Errorf("error: unknown sort field %s... ", strconv.Quote(string(field)))
+	}
+	return e, nil
+}
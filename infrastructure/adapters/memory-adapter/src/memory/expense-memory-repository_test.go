@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+)
+
+var testDate = time.Date(2023, time.October, 1, 10, 30, 0, 0, time.UTC)
+
+// newTestExpenses returns an adapter holding one expense per amount, created
+// a day apart starting at testDate.
+func newTestExpenses(t *testing.T, amounts ...model.Money) *ExpenseMemoryAdapter {
+	t.Helper()
+	r := NewExpenseMemoryAdapter(NewStore()).(*ExpenseMemoryAdapter)
+	for i, amount := range amounts {
+		e := &model.Expense{Amount: amount, Created: testDate.AddDate(0, 0, i)}
+		if _, err := r.Save(context.Background(), e); err != nil {
+			t.Fatalf("expenseMemoryRepository.Save() error = %v", err)
+		}
+	}
+	return r
+}
+
+func ids(expenses []model.Expense) []int {
+	ids := []int{}
+	for _, e := range expenses {
+		ids = append(ids, e.Id)
+	}
+	return ids
+}
+
+func Test_expenseMemoryRepository_Save(t *testing.T) {
+	r := newTestExpenses(t, 1000)
+	ctx := context.Background()
+	if err := r.Delete(ctx, 1); err != nil {
+		t.Fatalf("expenseMemoryRepository.Delete() error = %v", err)
+	}
+
+	created := time.Date(2023, time.October, 2, 8, 15, 30, 999, time.FixedZone("COT", -5*3600))
+	got, err := r.Save(ctx, &model.Expense{Amount: 2530, Created: created, CategoryId: 3})
+	if err != nil {
+		t.Fatalf("expenseMemoryRepository.Save() error = %v", err)
+	}
+	want := &model.Expense{
+		Id:         2,
+		Amount:     2530,
+		Created:    time.Date(2023, time.October, 2, 8, 15, 30, 0, time.UTC),
+		CategoryId: 3,
+		Version:    1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expenseMemoryRepository.Save() = %v, want %v", got, want)
+	}
+}
+
+func Test_expenseMemoryRepository_FindByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		want    *model.Expense
+		wantErr error
+	}{
+		{
+			name: "given an existing id, then return the expense",
+			id:   1,
+			want: &model.Expense{Id: 1, Amount: 1000, Created: testDate, Version: 1},
+		},
+		{
+			name:    "given an unknown id, then return item not found error",
+			id:      5,
+			wantErr: customErrors.NewItemNotFoundError("expense"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestExpenses(t, 1000).FindByID(context.Background(), tt.id)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("expenseMemoryRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expenseMemoryRepository.FindByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expenseMemoryRepository_CopyOnRead(t *testing.T) {
+	r := newTestExpenses(t, 1000)
+	ctx := context.Background()
+
+	found, _ := r.FindByID(ctx, 1)
+	found.Amount = 1
+	listed, _ := r.FindAll(ctx, model.ExpenseQuery{})
+	listed[0].Amount = 2
+	saved := &model.Expense{Amount: 500, Created: testDate}
+	r.Save(ctx, saved)
+	saved.Amount = 3
+
+	got, _ := r.FindByID(ctx, 1)
+	if got.Amount != 1000 {
+		t.Errorf("expenseMemoryRepository.FindByID() amount = %v, want %v", got.Amount, model.Money(1000))
+	}
+	got, _ = r.FindByID(ctx, 2)
+	if got.Amount != 500 {
+		t.Errorf("expenseMemoryRepository.FindByID() amount = %v, want %v", got.Amount, model.Money(500))
+	}
+}
+
+func Test_expenseMemoryRepository_UpdateAndDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		wantErr bool
+	}{
+		{
+			name: "given an existing expense, then update and delete it",
+			id:   1,
+		},
+		{
+			name:    "given an unknown expense, then return error",
+			id:      5,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestExpenses(t, 1000)
+			ctx := context.Background()
+
+			_, err := r.Update(ctx, &model.Expense{Id: tt.id, Amount: 700, Created: testDate, Version: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expenseMemoryRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := r.Delete(ctx, tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("expenseMemoryRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if exists, _ := r.Exists(ctx, tt.id); exists {
+				t.Errorf("expenseMemoryRepository.Exists() = %v, want %v", exists, false)
+			}
+		})
+	}
+}
+
+func Test_expenseMemoryRepository_FindAll(t *testing.T) {
+	min, max := model.Money(1500), model.Money(3000)
+	tests := []struct {
+		name    string
+		query   model.ExpenseQuery
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:  "given an empty query, then return every expense newest first",
+			query: model.ExpenseQuery{},
+			want:  []int{4, 3, 2, 1},
+		},
+		{
+			name: "given a date range and amount bounds, then return the matching expenses",
+			query: model.ExpenseQuery{
+				From:      testDate.AddDate(0, 0, 1),
+				To:        testDate.AddDate(0, 0, 3),
+				MinAmount: &min,
+				MaxAmount: &max,
+				Direction: model.SortAscending,
+			},
+			want: []int{2, 3},
+		},
+		{
+			name: "given an amount sort with a cursor and a limit, then return the next page",
+			query: model.ExpenseQuery{
+				SortBy:    model.SortByAmount,
+				Direction: model.SortDescending,
+				Limit:     2,
+				After:     &model.ExpenseCursor{SortBy: model.SortByAmount, Value: "30.00", Id: 3},
+			},
+			want: []int{4, 2},
+		},
+		{
+			name: "given an id sort with a cursor, then return the expenses after it",
+			query: model.ExpenseQuery{
+				SortBy:    model.SortById,
+				Direction: model.SortAscending,
+				After:     &model.ExpenseCursor{SortBy: model.SortById, Value: "2", Id: 2},
+			},
+			want: []int{3, 4},
+		},
+		{
+			name: "given a cursor with an invalid value, then return error",
+			query: model.ExpenseQuery{
+				After: &model.ExpenseCursor{SortBy: model.SortByCreated, Value: "yesterday", Id: 2},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestExpenses(t, 1000, 2000, 3000, 2000)
+
+			got, err := r.FindAll(context.Background(), tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expenseMemoryRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(ids(got), tt.want) {
+				t.Errorf("expenseMemoryRepository.FindAll() = %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_expenseMemoryRepository_FindAllByCategory(t *testing.T) {
+	r := newTestExpenses(t)
+	ctx := context.Background()
+	r.Save(ctx, &model.Expense{Amount: 1000, Created: testDate, CategoryId: 1})
+	r.Save(ctx, &model.Expense{Amount: 1000, Created: testDate, CategoryId: 2})
+	r.Save(ctx, &model.Expense{Amount: 1000, Created: testDate,
+		Splits: []model.ExpenseSplit{{CategoryId: 2, Amount: 400}, {CategoryId: 1, Amount: 600}}})
+
+	got, err := r.FindAll(ctx, model.ExpenseQuery{CategoryId: 1, SortBy: model.SortById, Direction: model.SortAscending})
+	if err != nil || !reflect.DeepEqual(ids(got), []int{1, 3}) {
+		t.Errorf("expenseMemoryRepository.FindAll() = %v, %v, want %v", ids(got), err, []int{1, 3})
+	}
+}
+
+func Test_expenseMemoryRepository_CancelledContext(t *testing.T) {
+	r := newTestExpenses(t, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := r.FindByID(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("expenseMemoryRepository.FindByID() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := r.Save(ctx, &model.Expense{Amount: 1000}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expenseMemoryRepository.Save() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func Test_expenseMemoryRepository_Concurrency(t *testing.T) {
+	r := newTestExpenses(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			saved, err := r.Save(ctx, &model.Expense{Amount: 100, Created: testDate})
+			if err != nil {
+				t.Errorf("expenseMemoryRepository.Save() error = %v", err)
+				return
+			}
+			r.FindAll(ctx, model.ExpenseQuery{})
+			r.Update(ctx, &model.Expense{Id: saved.Id, Amount: 200, Created: testDate, Version: saved.Version})
+		}()
+	}
+	wg.Wait()
+
+	got, _ := r.FindAll(ctx, model.ExpenseQuery{SortBy: model.SortById, Direction: model.SortAscending})
+	if len(got) != 50 {
+		t.Fatalf("expenseMemoryRepository.FindAll() returned %d expenses, want %d", len(got), 50)
+	}
+	for i, e := range got {
+		if e.Id != i+1 || e.Amount != 200 {
+			t.Errorf("expenseMemoryRepository.FindAll(context.Background())[%d] = %v, want id %d and amount 2.00", i, e, i+1)
+		}
+	}
+}
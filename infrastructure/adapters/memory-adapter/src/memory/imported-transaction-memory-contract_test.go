@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/porttest"
+)
+
+func Test_importedTransactionMemoryRepository_Contract(t *testing.T) {
+	porttest.TestImportedTransactionRepository(t, func(t *testing.T) port.ImportedTransactionRepository {
+		return NewImportedTransactionMemoryAdapter(NewStore())
+	})
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type ImportedTransactionMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewImportedTransactionMemoryAdapter(store *Store) port.ImportedTransactionRepository {
+	return &ImportedTransactionMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *ImportedTransactionMemoryAdapter) Exists(ctx context.Context, account, externalId string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.exists(account, externalId), nil
+}
+
+func (r *ImportedTransactionMemoryAdapter) Save(ctx context.Context, t *model.ImportedTransaction) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// mirrors the unique (account, external_id) constraint of the table
+	if r.exists(t.Account, t.ExternalId) {
+		return fmt.Errorf("error: transaction %s of account %s was already imported... ",
+			t.ExternalId, t.Account)
+	}
+	r.store.imports.set(r.store.imports.nextID(), *t)
+	return nil
+}
+
+func (r *ImportedTransactionMemoryAdapter) exists(account, externalId string) bool {
+	for _, t := range r.store.imports.rows {
+		if t.Account == account && t.ExternalId == externalId {
+			return true
+		}
+	}
+	return false
+}
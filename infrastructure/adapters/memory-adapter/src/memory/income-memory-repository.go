@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type IncomeMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewIncomeMemoryAdapter(store *Store) port.IncomeRepository {
+	return &IncomeMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *IncomeMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.incomes.exists(id), nil
+}
+
+func (r *IncomeMemoryAdapter) FindByID(ctx context.Context, id int) (*model.Income, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	income, ok := r.store.incomes.rows[id]
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("income")
+	}
+	return &income, nil
+}
+
+func (r *IncomeMemoryAdapter) FindAll(ctx context.Context) ([]model.Income, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.incomes.all(), nil
+}
+
+func (r *IncomeMemoryAdapter) Save(ctx context.Context, i *model.Income) (*model.Income, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	i.Id = r.store.incomes.nextID()
+	i.Created = storedTime(i.Created)
+	r.store.incomes.set(i.Id, *i)
+	return i, nil
+}
+
+func (r *IncomeMemoryAdapter) Update(ctx context.Context, i *model.Income) (*model.Income, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.incomes.exists(i.Id) {
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	i.Created = storedTime(i.Created)
+	r.store.incomes.set(i.Id, *i)
+	return i, nil
+}
+
+func (r *IncomeMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.incomes.exists(id) {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	r.store.incomes.remove(id)
+	return nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/porttest"
+)
+
+func Test_recurringRuleMemoryRepository_Contract(t *testing.T) {
+	porttest.TestRecurringRuleRepository(t, func(t *testing.T) port.RecurringRuleRepository {
+		return NewRecurringRuleMemoryAdapter(NewStore())
+	})
+}
@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type RecurringRuleMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewRecurringRuleMemoryAdapter(store *Store) port.RecurringRuleRepository {
+	return &RecurringRuleMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *RecurringRuleMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.recurring.exists(id), nil
+}
+
+func (r *RecurringRuleMemoryAdapter) FindByID(ctx context.Context, id int) (*model.RecurringRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	rule, ok := r.store.recurring.rows[id]
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("recurring rule")
+	}
+	return &rule, nil
+}
+
+func (r *RecurringRuleMemoryAdapter) FindAll(ctx context.Context) ([]model.RecurringRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.recurring.all(), nil
+}
+
+func (r *RecurringRuleMemoryAdapter) Save(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rule.Id = r.store.recurring.nextID()
+	r.store.recurring.set(rule.Id, storedRule(*rule))
+	return rule, nil
+}
+
+func (r *RecurringRuleMemoryAdapter) Update(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.recurring.exists(rule.Id) {
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	r.store.recurring.set(rule.Id, storedRule(*rule))
+	return rule, nil
+}
+
+// Delete follows the ON DELETE CASCADE of the occurrences of the Postgres
+// schema.
+func (r *RecurringRuleMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.recurring.exists(id) {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	r.store.recurring.remove(id)
+	for key := range r.store.occurrences {
+		if key.ruleId == id {
+			deleteEntry(r.store.undo, r.store.occurrences, key)
+		}
+	}
+	return nil
+}
+
+func (r *RecurringRuleMemoryAdapter) FindOccurrences(ctx context.Context, ruleId int,
+	from, to time.Time) ([]model.RecurringOccurrence, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	from, to = storedDate(from), storedDate(to)
+	occurrences := []model.RecurringOccurrence{}
+	for key, o := range r.store.occurrences {
+		if key.ruleId == ruleId && !key.date.Before(from) && !key.date.After(to) {
+			occurrences = append(occurrences, o)
+		}
+	}
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+	return occurrences, nil
+}
+
+// SaveOccurrence mirrors the upsert of the Postgres adapter, which leaves
+// created occurrences alone.
+func (r *RecurringRuleMemoryAdapter) SaveOccurrence(ctx context.Context, o *model.RecurringOccurrence) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.recurring.exists(o.RuleId) {
+		return false, fmt.Errorf("error: recurring rule %d referenced by occurrence does not exist... ", o.RuleId)
+	}
+	key := occurrenceKey{ruleId: o.RuleId, date: storedDate(o.Date)}
+	if r.store.occurrences[key].Status == model.OccurrenceCreated {
+		return false, nil
+	}
+	stored := *o
+	stored.Date = key.date
+	setEntry(r.store.undo, r.store.occurrences, key, stored)
+	return true, nil
+}
+
+func (r *RecurringRuleMemoryAdapter) LastCreated(ctx context.Context, ruleId int) (time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var overridden time.Time
+	for key, o := range r.store.occurrences {
+		if key.ruleId == ruleId && o.Status == model.OccurrenceOverridden &&
+			(overridden.IsZero() || key.date.Before(overridden)) {
+			overridden = key.date
+		}
+	}
+	var last time.Time
+	for key, o := range r.store.occurrences {
+		if key.ruleId == ruleId && o.Status == model.OccurrenceCreated && key.date.After(last) &&
+			(overridden.IsZero() || key.date.Before(overridden)) {
+			last = key.date
+		}
+	}
+	return last, nil
+}
+
+// storedRule keeps the days of the rule, like the DATE columns of the
+// Postgres schema.
+func storedRule(rule model.RecurringRule) model.RecurringRule {
+	rule.Start = storedDate(rule.Start)
+	if rule.End != nil {
+		end := storedDate(*rule.End)
+		rule.End = &end
+	}
+	return rule
+}
@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+// Store keeps every collection of the service in process memory, so it can
+// run without a database. A single lock guards all of them, so reads that
+// span collections, like a budget's spent amount, see a consistent state.
+type Store struct {
+	mu sync.RWMutex
+	// undo records how to revert the writes of the running unit of work.
+	undo *undoLog
+	tables
+}
+
+type tables struct {
+	expenses   table[model.Expense]
+	incomes    table[model.Income]
+	categories table[model.Category]
+	budgets    table[model.Budget]
+	audit      table[model.AuditEntry]
+	imports    table[model.ImportedTransaction]
+	accounts   table[model.Account]
+	transfers  table[model.Transfer]
+	recurring  table[model.RecurringRule]
+	// occurrences are keyed by rule and date, like rates by date and pair.
+	occurrences map[occurrenceKey]model.RecurringOccurrence
+	// rates are keyed by date, base and quote rather than by id.
+	rates map[exchangeRateKey]model.ExchangeRate
+}
+
+type occurrenceKey struct {
+	ruleId int
+	date   time.Time
+}
+
+type exchangeRateKey struct {
+	date        time.Time
+	base, quote string
+}
+
+func NewStore() *Store {
+	undo := &undoLog{}
+	return &Store{
+		undo: undo,
+		tables: tables{
+			expenses:    newTable[model.Expense](undo),
+			incomes:     newTable[model.Income](undo),
+			categories:  newTable[model.Category](undo),
+			budgets:     newTable[model.Budget](undo),
+			audit:       newTable[model.AuditEntry](undo),
+			imports:     newTable[model.ImportedTransaction](undo),
+			accounts:    newTable[model.Account](undo),
+			transfers:   newTable[model.Transfer](undo),
+			recurring:   newTable[model.RecurringRule](undo),
+			occurrences: map[occurrenceKey]model.RecurringOccurrence{},
+			rates:       map[exchangeRateKey]model.ExchangeRate{},
+		},
+	}
+}
+
+// undoLog keeps, while a unit of work runs, a step undoing each write it
+// made, so a failure reverts only the rows it touched. Outside of one it
+// records nothing.
+type undoLog struct {
+	recording bool
+	steps     []func()
+}
+
+func (l *undoLog) begin() {
+	l.recording = true
+}
+
+func (l *undoLog) record(step func()) {
+	if l.recording {
+		l.steps = append(l.steps, step)
+	}
+}
+
+// rollback undoes the recorded writes, latest first, and stops recording.
+func (l *undoLog) rollback() {
+	for i := len(l.steps) - 1; i >= 0; i-- {
+		l.steps[i]()
+	}
+	l.commit()
+}
+
+// commit keeps the recorded writes and stops recording.
+func (l *undoLog) commit() {
+	l.recording = false
+	l.steps = nil
+}
+
+// setEntry stores value under key in m, recording how to put back what was
+// there.
+func setEntry[K comparable, V any](undo *undoLog, m map[K]V, key K, value V) {
+	previous, existed := m[key]
+	undo.record(func() {
+		if existed {
+			m[key] = previous
+		} else {
+			delete(m, key)
+		}
+	})
+	m[key] = value
+}
+
+// deleteEntry removes key from m, recording how to put it back.
+func deleteEntry[K comparable, V any](undo *undoLog, m map[K]V, key K) {
+	previous, existed := m[key]
+	if !existed {
+		return
+	}
+	undo.record(func() { m[key] = previous })
+	delete(m, key)
+}
+
+// rwLocker is the store lock as seen by the adapters. Adapters running
+// inside a unit of work get noLock, since the unit of work already holds
+// the store lock.
+type rwLocker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+type noLock struct{}
+
+func (noLock) Lock()    {}
+func (noLock) Unlock()  {}
+func (noLock) RLock()   {}
+func (noLock) RUnlock() {}
+
+// table holds the rows of one collection by id. Rows are stored and handed
+// out by value, so callers never share memory with the store.
+type table[T any] struct {
+	seq  int
+	rows map[int]T
+	undo *undoLog
+}
+
+func newTable[T any](undo *undoLog) table[T] {
+	return table[T]{rows: map[int]T{}, undo: undo}
+}
+
+// nextID works like a database sequence: ids are never reused, even after
+// the row holding them is deleted.
+func (t *table[T]) nextID() int {
+	seq := t.seq
+	t.undo.record(func() { t.seq = seq })
+	t.seq++
+	return t.seq
+}
+
+func (t *table[T]) set(id int, row T) {
+	setEntry(t.undo, t.rows, id, row)
+}
+
+func (t *table[T]) remove(id int) {
+	deleteEntry(t.undo, t.rows, id)
+}
+
+func (t *table[T]) exists(id int) bool {
+	_, ok := t.rows[id]
+	return ok
+}
+
+// all returns a copy of every row ordered by id.
+func (t *table[T]) all() []T {
+	ids := make([]int, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	rows := make([]T, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, t.rows[id])
+	}
+	return rows
+}
+
+// storedTime mirrors what the TIMESTAMP columns of the Postgres schema keep:
+// the wall clock, to the second, without a time zone.
+func storedTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
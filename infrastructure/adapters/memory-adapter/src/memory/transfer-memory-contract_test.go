@@ -0,0 +1,15 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/porttest"
+)
+
+func Test_transferMemoryRepository_Contract(t *testing.T) {
+	porttest.TestTransferRepository(t, func(t *testing.T) (port.TransferRepository, port.AccountRepository) {
+		store := NewStore()
+		return NewTransferMemoryAdapter(store), NewAccountMemoryAdapter(store)
+	})
+}
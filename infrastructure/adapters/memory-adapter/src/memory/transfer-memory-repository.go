@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type TransferMemoryAdapter struct {
+	store *Store
+	lock  rwLocker
+}
+
+func NewTransferMemoryAdapter(store *Store) port.TransferRepository {
+	return &TransferMemoryAdapter{store: store, lock: &store.mu}
+}
+
+func (r *TransferMemoryAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.store.transfers.exists(id), nil
+}
+
+func (r *TransferMemoryAdapter) FindByID(ctx context.Context, id int) (*model.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	transfer, ok := r.store.transfers.rows[id]
+	if !ok {
+		return nil, customErrors.NewItemNotFoundError("transfer")
+	}
+	return &transfer, nil
+}
+
+func (r *TransferMemoryAdapter) FindAll(ctx context.Context, accountId int) ([]model.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	transfers := []model.Transfer{}
+	for _, t := range r.store.transfers.all() {
+		if accountId == 0 || t.FromAccountId == accountId || t.ToAccountId == accountId {
+			transfers = append(transfers, t)
+		}
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].Created.Before(transfers[j].Created)
+	})
+	return transfers, nil
+}
+
+// Save follows the foreign keys of the Postgres schema: both accounts must
+// exist.
+func (r *TransferMemoryAdapter) Save(ctx context.Context, t *model.Transfer) (*model.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, accountId := range []int{t.FromAccountId, t.ToAccountId} {
+		if !r.store.accounts.exists(accountId) {
+			return nil, fmt.Errorf("error: account %d referenced by transfer does not exist... ", accountId)
+		}
+	}
+	t.Id = r.store.transfers.nextID()
+	t.Created = storedTime(t.Created)
+	r.store.transfers.set(t.Id, *t)
+	return t, nil
+}
+
+func (r *TransferMemoryAdapter) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.store.transfers.exists(id) {
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+	r.store.transfers.remove(id)
+	return nil
+}
+
+func (r *TransferMemoryAdapter) Totals(ctx context.Context, accountId int) (*model.TransferTotals, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	totals := &model.TransferTotals{}
+	for _, t := range r.store.transfers.rows {
+		if t.ToAccountId == accountId {
+			totals.In += t.Amount
+		}
+		if t.FromAccountId == accountId {
+			totals.Out += t.Amount
+		}
+	}
+	return totals, nil
+}
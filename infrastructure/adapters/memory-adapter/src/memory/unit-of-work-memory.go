@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+type MemoryUnitOfWork struct {
+	store *Store
+}
+
+func NewMemoryUnitOfWork(store *Store) port.UnitOfWork {
+	return &MemoryUnitOfWork{store: store}
+}
+
+// Run holds the store lock while fn runs, so no other call sees its
+// partial writes, and undoes the writes of fn when it fails or panics.
+func (u *MemoryUnitOfWork) Run(ctx context.Context, fn func(repos port.Repositories) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	u.store.mu.Lock()
+	defer u.store.mu.Unlock()
+
+	u.store.undo.begin()
+	defer func() {
+		if p := recover(); p != nil {
+			u.store.undo.rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(u.repositories()); err != nil {
+		u.store.undo.rollback()
+		return err
+	}
+	u.store.undo.commit()
+	return nil
+}
+
+func (u *MemoryUnitOfWork) repositories() port.Repositories {
+	return port.Repositories{
+		Expenses:   &ExpenseMemoryAdapter{store: u.store, lock: noLock{}},
+		Incomes:    &IncomeMemoryAdapter{store: u.store, lock: noLock{}},
+		Categories: &CategoryMemoryAdapter{store: u.store, lock: noLock{}},
+		Budgets:    &BudgetMemoryAdapter{store: u.store, lock: noLock{}},
+		Audit:      &AuditMemoryAdapter{store: u.store, lock: noLock{}},
+		Imports:    &ImportedTransactionMemoryAdapter{store: u.store, lock: noLock{}},
+		Accounts:   &AccountMemoryAdapter{store: u.store, lock: noLock{}},
+		Transfers:  &TransferMemoryAdapter{store: u.store, lock: noLock{}},
+		Recurring:  &RecurringRuleMemoryAdapter{store: u.store, lock: noLock{}},
+	}
+}
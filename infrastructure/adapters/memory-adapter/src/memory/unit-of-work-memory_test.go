@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+func TestMemoryUnitOfWork_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func(ctx context.Context, repos port.Repositories) error
+		wantErr   bool
+		wantSaved int
+	}{
+		{
+			name: "given writes that succeed, then keep all of them",
+			fn: func(ctx context.Context, repos port.Repositories) error {
+				category, _ := repos.Categories.Save(context.Background(), &model.Category{Name: "food"})
+				_, err := repos.Expenses.Save(ctx, &model.Expense{Amount: 1000, Created: testDate, CategoryId: category.Id})
+				return err
+			},
+			wantSaved: 1,
+		},
+		{
+			name: "given a function that fails after writing, then undo every write",
+			fn: func(ctx context.Context, repos port.Repositories) error {
+				repos.Categories.Save(context.Background(), &model.Category{Name: "food"})
+				repos.Expenses.Save(ctx, &model.Expense{Amount: 1000, Created: testDate})
+				return errors.ErrUnsupported
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			ctx := context.Background()
+
+			err := NewMemoryUnitOfWork(store).Run(ctx, func(repos port.Repositories) error {
+				return tt.fn(ctx, repos)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MemoryUnitOfWork.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			expenses, _ := NewExpenseMemoryAdapter(store).FindAll(ctx, model.ExpenseQuery{})
+			categories, _ := NewCategoryMemoryAdapter(store).FindAll(context.Background())
+			if len(expenses) != tt.wantSaved || len(categories) != tt.wantSaved {
+				t.Errorf("MemoryUnitOfWork.Run() kept %d expenses and %d categories, want %d",
+					len(expenses), len(categories), tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestMemoryUnitOfWork_RunPanic(t *testing.T) {
+	store := NewStore()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MemoryUnitOfWork.Run() didn't propagate the panic")
+		}
+		if exists, _ := NewIncomeMemoryAdapter(store).Exists(context.Background(), 1); exists {
+			t.Errorf("MemoryUnitOfWork.Run() kept the writes of a panicking function")
+		}
+	}()
+	NewMemoryUnitOfWork(store).Run(context.Background(), func(repos port.Repositories) error {
+		repos.Incomes.Save(context.Background(), &model.Income{Amount: 1000, Created: testDate})
+		panic("boom")
+	})
+}
+
+func TestMemoryUnitOfWork_RunUndo(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	food, _ := NewCategoryMemoryAdapter(store).Save(ctx, &model.Category{Name: "food"})
+	expense, _ := NewExpenseMemoryAdapter(store).Save(ctx, &model.Expense{Amount: 1000, Created: testDate})
+	rule, _ := NewRecurringRuleMemoryAdapter(store).Save(ctx, &model.RecurringRule{
+		Kind: model.KindExpense, Amount: 1000, Frequency: model.FrequencyMonthly, Interval: 1, Start: testDate,
+	})
+	before := store.tables
+
+	err := NewMemoryUnitOfWork(store).Run(ctx, func(repos port.Repositories) error {
+		changed := *expense
+		changed.Amount = 2000
+		repos.Expenses.Update(ctx, &changed)
+		repos.Expenses.Save(ctx, &model.Expense{Amount: 3000, Created: testDate})
+		repos.Categories.Delete(ctx, food.Id)
+		repos.Recurring.SaveOccurrence(ctx, &model.RecurringOccurrence{
+			RuleId: rule.Id, Date: testDate, Status: model.OccurrenceSkipped, Amount: 1000,
+		})
+		return errors.ErrUnsupported
+	})
+	if err == nil {
+		t.Fatalf("MemoryUnitOfWork.Run() error = nil, want error")
+	}
+
+	got, _ := NewExpenseMemoryAdapter(store).FindAll(ctx, model.ExpenseQuery{})
+	if want := []model.Expense{*expense}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MemoryUnitOfWork.Run() left expenses %+v, want %+v", got, want)
+	}
+	if exists, _ := NewCategoryMemoryAdapter(store).Exists(ctx, food.Id); !exists {
+		t.Errorf("MemoryUnitOfWork.Run() kept the delete of category %d", food.Id)
+	}
+	if len(store.occurrences) != 0 {
+		t.Errorf("MemoryUnitOfWork.Run() kept occurrences %+v", store.occurrences)
+	}
+	if store.expenses.seq != before.expenses.seq {
+		t.Errorf("MemoryUnitOfWork.Run() left the expense sequence at %d, want %d",
+			store.expenses.seq, before.expenses.seq)
+	}
+
+	if err := NewMemoryUnitOfWork(store).Run(ctx, func(repos port.Repositories) error {
+		return repos.Categories.Delete(ctx, food.Id)
+	}); err != nil {
+		t.Fatalf("MemoryUnitOfWork.Run() error = %v", err)
+	}
+	if exists, _ := NewCategoryMemoryAdapter(store).Exists(ctx, food.Id); exists {
+		t.Errorf("MemoryUnitOfWork.Run() didn't keep the delete of category %d", food.Id)
+	}
+	if len(store.undo.steps) != 0 {
+		t.Errorf("MemoryUnitOfWork.Run() left %d undo steps after commit", len(store.undo.steps))
+	}
+}
@@ -2,19 +2,10 @@ module github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-a
 
 go 1.21.1
 
-require github.com/gookit/config/v2 v2.2.1
+replace github.com/enaldo1709/budget-manager/domain/model => ../../../domain/model
 
-require gopkg.in/DATA-DOG/go-sqlmock.v1 v1.3.0 // indirect
+require github.com/enaldo1709/budget-manager/domain/model v0.0.0-00010101000000-000000000000
 
-require (
-	github.com/gookit/color v1.5.2 // indirect
-	github.com/gookit/goutil v0.6.6 // indirect
-	github.com/imdario/mergo v0.3.13 // indirect
-	github.com/lib/pq v1.10.9
-	github.com/mitchellh/mapstructure v1.5.0 // indirect
-	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
-	golang.org/x/sync v0.1.0 // indirect
-	golang.org/x/sys v0.5.0 // indirect
-	golang.org/x/term v0.5.0 // indirect
-	golang.org/x/text v0.7.0 // indirect
-)
+require gopkg.in/DATA-DOG/go-sqlmock.v1 v1.3.0
+
+require github.com/lib/pq v1.10.9
@@ -0,0 +1,231 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	accountsTable = "accounts"
+)
+
+type AccountPostgresAdapter struct {
+	db             executor
+	schema         string
+	table          string
+	incomesTable   string
+	expensesTable  string
+	transfersTable string
+	rulesTable     string
+	timeout        time.Duration
+}
+
+func NewAccountPostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.AccountRepository {
+	return &AccountPostgresAdapter{
+		db:             db,
+		schema:         prop.Schema,
+		table:          accountsTable,
+		incomesTable:   incomesTable,
+		expensesTable:  expensesTable,
+		transfersTable: transfersTable,
+		rulesTable:     recurringRulesTable,
+		timeout:        prop.QueryTimeout,
+	}
+}
+
+func (r *AccountPostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s.%s t WHERE t.id = $1", r.schema, r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for account... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *AccountPostgresAdapter) FindByID(ctx context.Context, id int) (*model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, name, type, currency, opening_balance, closed FROM %s.%s "+
+		"WHERE id = $1", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for account... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanAccount(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("account")
+}
+
+func (r *AccountPostgresAdapter) FindAll(ctx context.Context) ([]model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, name, type, currency, opening_balance, closed FROM %s.%s ORDER BY id",
+		r.schema, r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for accounts... "), err)
+	}
+
+	accounts := []model.Account{}
+
+	defer res.Close()
+	for res.Next() {
+		account, err := scanAccount(res)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+
+	return accounts, nil
+}
+
+func (r *AccountPostgresAdapter) Save(ctx context.Context, a *model.Account) (*model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (name, type, currency, opening_balance, closed) "+
+		"VALUES($1, $2, $3, $4, $5) RETURNING id", r.schema, r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, a.Name, string(a.Type), a.Currency,
+		a.OpeningBalance.String(), a.Closed).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving account... "), err)
+	}
+	a.Id = id
+	return a, nil
+}
+
+func (r *AccountPostgresAdapter) Update(ctx context.Context, a *model.Account) (*model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET name=$1, type=$2, currency=$3, opening_balance=$4, closed=$5 "+
+		"WHERE id=$6", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, a.Name, string(a.Type), a.Currency,
+		a.OpeningBalance.String(), a.Closed, a.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating account... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return a, nil
+}
+
+func (r *AccountPostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id=$1", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting account... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *AccountPostgresAdapter) Totals(ctx context.Context, id int) (*model.BalanceTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT "+
+		"(SELECT COALESCE(SUM(i.amount), 0) FROM %s.%s i WHERE i.account_id = $1), "+
+		"(SELECT COALESCE(SUM(e.amount), 0) FROM %s.%s e WHERE e.deleted IS NULL AND e.account_id = $1)",
+		r.schema, r.incomesTable, r.schema, r.expensesTable)
+
+	var rawIncome, rawExpenses string
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&rawIncome, &rawExpenses); err != nil {
+		log.Println("error: error executing totals query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating account totals... "), err)
+	}
+	return parseTotals(rawIncome, rawExpenses)
+}
+
+func (r *AccountPostgresAdapter) HasRecords(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT "+
+		"EXISTS (SELECT 1 FROM %s.%s e WHERE e.account_id = $1) OR "+
+		"EXISTS (SELECT 1 FROM %s.%s i WHERE i.account_id = $1) OR "+
+		"EXISTS (SELECT 1 FROM %s.%s t WHERE t.from_account_id = $1 OR t.to_account_id = $1) OR "+
+		"EXISTS (SELECT 1 FROM %s.%s r WHERE r.account_id = $1)",
+		r.schema, r.expensesTable, r.schema, r.incomesTable, r.schema, r.transfersTable, r.schema, r.rulesTable)
+
+	var hasRecords bool
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&hasRecords); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for account records... "), err)
+	}
+	return hasRecords, nil
+}
+
+func scanAccount(res *sql.Rows) (*model.Account, error) {
+	var id int
+	var name, kind, currency, rawOpeningBalance string
+	var closed bool
+	if err := res.Scan(&id, &name, &kind, &currency, &rawOpeningBalance, &closed); err != nil {
+		log.Println("error: error building account item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building account item... "), err)
+	}
+	openingBalance, err := model.ParseMoney(rawOpeningBalance)
+	if err != nil {
+		log.Println("error: error parsing amount... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+	}
+	return &model.Account{
+		Id:             id,
+		Name:           name,
+		Type:           model.AccountType(kind),
+		Currency:       currency,
+		OpeningBalance: openingBalance,
+		Closed:         closed,
+	}, nil
+}
@@ -0,0 +1,280 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func newTestAccountAdapter(db *sql.DB) *AccountPostgresAdapter {
+	return &AccountPostgresAdapter{
+		db:             db,
+		schema:         expensesSchema,
+		table:          accountsTable,
+		incomesTable:   incomesTable,
+		expensesTable:  expensesTable,
+		transfersTable: transfersTable,
+		rulesTable:     recurringRulesTable,
+	}
+}
+
+func Test_accountPostgresRepository_FindByID(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, name, type, currency, opening_balance, closed FROM test.accounts " +
+		"WHERE id = $1")
+	columns := []string{"id", "name", "type", "currency", "opening_balance", "closed"}
+	tests := []struct {
+		name          string
+		want          *model.Account
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, then get the account",
+			want: &model.Account{
+				Id: 1, Name: "Visa", Type: model.AccountCard, Currency: "USD", OpeningBalance: -2530, Closed: true,
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Visa", "card", "USD", "-25.30", true))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a missing id, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columns))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an invalid opening balance, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Visa", "card", "USD", "test", false))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestAccountAdapter(db).FindByID(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("accountPostgresRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("accountPostgresRepository.FindByID() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_accountPostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.accounts (name, type, currency, opening_balance, closed) " +
+		"VALUES($1, $2, $3, $4, $5) RETURNING id")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an account, then get it with its id",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("Checking", "bank", "USD", "1500.00", false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			account := &model.Account{Name: "Checking", Type: model.AccountBank, Currency: "USD", OpeningBalance: 150000}
+			got, err := newTestAccountAdapter(db).Save(context.Background(), account)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("accountPostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Id != 7 {
+				t.Errorf("accountPostgresRepository.Save() id = %d, want 7", got.Id)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_accountPostgresRepository_Update(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE test.accounts SET name=$1, type=$2, currency=$3, opening_balance=$4, " +
+		"closed=$5 WHERE id=$6")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an account, then close it",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs("Checking", "bank", "USD", "0.00", true, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a missing account, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			account := &model.Account{Id: 7, Name: "Checking", Type: model.AccountBank, Currency: "USD", Closed: true}
+			if _, err := newTestAccountAdapter(db).Update(context.Background(), account); (err != nil) != tt.wantErr {
+				t.Errorf("accountPostgresRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_accountPostgresRepository_Totals(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT " +
+		"(SELECT COALESCE(SUM(i.amount), 0) FROM test.incomes i WHERE i.account_id = $1), " +
+		"(SELECT COALESCE(SUM(e.amount), 0) FROM test.expenses e WHERE e.deleted IS NULL AND e.account_id = $1)")
+	tests := []struct {
+		name          string
+		want          *model.BalanceTotals
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an account, then get its totals",
+			want: &model.BalanceTotals{Income: 150000, Expenses: 2530},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"income", "expenses"}).AddRow("1500.00", "25.30"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestAccountAdapter(db).Totals(context.Background(), 7)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("accountPostgresRepository.Totals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("accountPostgresRepository.Totals() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_accountPostgresRepository_HasRecords(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT " +
+		"EXISTS (SELECT 1 FROM test.expenses e WHERE e.account_id = $1) OR " +
+		"EXISTS (SELECT 1 FROM test.incomes i WHERE i.account_id = $1) OR " +
+		"EXISTS (SELECT 1 FROM test.transfers t WHERE t.from_account_id = $1 OR t.to_account_id = $1) OR " +
+		"EXISTS (SELECT 1 FROM test.recurring_rules r WHERE r.account_id = $1)")
+	tests := []struct {
+		name          string
+		want          bool
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an account with records, then get true",
+			want: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"has_records"}).AddRow(true))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestAccountAdapter(db).HasRecords(context.Background(), 7)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("accountPostgresRepository.HasRecords() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("accountPostgresRepository.HasRecords() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
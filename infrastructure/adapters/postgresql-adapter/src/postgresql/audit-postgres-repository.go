@@ -0,0 +1,105 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	auditTable = "audit_log"
+)
+
+type AuditPostgresAdapter struct {
+	db      executor
+	schema  string
+	table   string
+	timeout time.Duration
+}
+
+func NewAuditPostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.AuditRepository {
+	return &AuditPostgresAdapter{
+		db:      db,
+		schema:  prop.Schema,
+		table:   auditTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *AuditPostgresAdapter) Record(ctx context.Context, entry *model.AuditEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT "+
+		"INTO %s.%s (entity, entity_id, action, actor, snapshot_before, snapshot_after, recorded) "+
+		"VALUES($1, $2, $3, $4, $5, $6, TO_TIMESTAMP($7, 'YYYY-MM-DD\"T\"HH24:MI:SS')) RETURNING id",
+		r.schema, r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, entry.Entity, entry.EntityId, string(entry.Action), entry.Actor,
+		nullableJSON(entry.Before), nullableJSON(entry.After), entry.Recorded.UTC().Format(time.RFC3339)).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return errors.Join(fmt.Errorf("error: saving audit entry... "), err)
+	}
+	entry.Id = id
+	return nil
+}
+
+func (r *AuditPostgresAdapter) FindByEntity(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, entity, entity_id, action, actor, snapshot_before, snapshot_after, recorded "+
+		"FROM %s.%s WHERE entity = $1 AND entity_id = $2 ORDER BY id", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, entity, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for audit entries... "), err)
+	}
+
+	entries := []model.AuditEntry{}
+
+	defer res.Close()
+	for res.Next() {
+		var e model.AuditEntry
+		var action string
+		var before, after []byte
+		var recordedDate string
+		err = res.Scan(&e.Id, &e.Entity, &e.EntityId, &action, &e.Actor, &before, &after, &recordedDate)
+		if err != nil {
+			log.Println("error: error building audit entry... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building audit entry... "), err)
+		}
+		recorded, err := time.Parse(time.RFC3339, recordedDate)
+		if err != nil {
+			log.Println("error: error parsing recorded date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing recorded date... "), err)
+		}
+		e.Action = model.AuditAction(action)
+		e.Before = json.RawMessage(before)
+		e.After = json.RawMessage(after)
+		e.Recorded = recorded
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// nullableJSON maps an empty snapshot to a NULL column.
+func nullableJSON(raw json.RawMessage) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,174 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewAuditPostgresAdapter(t *testing.T) {
+	db, _ := NewMock()
+	defer db.Close()
+
+	prop := postgresconfig.PostgreSqlConnectionProperties{Schema: "test", QueryTimeout: time.Second}
+	want := &AuditPostgresAdapter{db: db, schema: "test", table: auditTable, timeout: time.Second}
+	if got := NewAuditPostgresAdapter(prop, db); !reflect.DeepEqual(got, port.AuditRepository(want)) {
+		t.Errorf("NewAuditPostgresAdapter() = %v, want %v", got, want)
+	}
+}
+
+func Test_auditPostgresRepository_Record(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT " +
+		"INTO test.audit_log (entity, entity_id, action, actor, snapshot_before, snapshot_after, recorded) " +
+		"VALUES($1, $2, $3, $4, $5, $6, TO_TIMESTAMP($7, 'YYYY-MM-DD\"T\"HH24:MI:SS')) RETURNING id")
+	recorded := time.Date(2023, 4, 20, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		entry         *model.AuditEntry
+		wantId        int
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a creation entry, then store it without a before snapshot and get the new id",
+			entry: &model.AuditEntry{
+				Entity: "expense", EntityId: 3, Action: model.AuditCreate, Actor: "ana",
+				After: json.RawMessage(`{"id":3}`), Recorded: recorded,
+			},
+			wantId: 9,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("expense", 3, "create", "ana", nil, `{"id":3}`, "2023-04-20T09:00:00Z").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				return db, mock
+			},
+		},
+		{
+			name: "given a database error, then get error",
+			entry: &model.AuditEntry{
+				Entity: "expense", EntityId: 3, Action: model.AuditDelete, Actor: "ana",
+				Before: json.RawMessage(`{"id":3}`), Recorded: recorded,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &AuditPostgresAdapter{db: db, schema: expensesSchema, table: auditTable}
+			err := r.Record(context.Background(), tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auditPostgresRepository.Record() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.entry.Id != tt.wantId {
+				t.Errorf("auditPostgresRepository.Record() id = %v, want %v", tt.entry.Id, tt.wantId)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_auditPostgresRepository_FindByEntity(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, entity, entity_id, action, actor, snapshot_before, snapshot_after, recorded " +
+		"FROM test.audit_log WHERE entity = $1 AND entity_id = $2 ORDER BY id")
+	columns := []string{"id", "entity", "entity_id", "action", "actor", "snapshot_before", "snapshot_after", "recorded"}
+	tests := []struct {
+		name          string
+		want          []model.AuditEntry
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given entries of the record, then get them oldest first",
+			want: []model.AuditEntry{
+				{
+					Id: 1, Entity: "expense", EntityId: 3, Action: model.AuditCreate, Actor: "ana",
+					After:    json.RawMessage(`{"id": 3}`),
+					Recorded: time.Date(2023, 4, 20, 9, 0, 0, 0, time.UTC),
+				},
+				{
+					Id: 4, Entity: "expense", EntityId: 3, Action: model.AuditDelete, Actor: "luis",
+					Before:   json.RawMessage(`{"id": 3}`),
+					Recorded: time.Date(2023, 4, 21, 9, 0, 0, 0, time.UTC),
+				},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("expense", 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "expense", 3, "create", "ana", nil, []byte(`{"id": 3}`), "2023-04-20T09:00:00Z").
+						AddRow(4, "expense", 3, "delete", "luis", []byte(`{"id": 3}`), nil, "2023-04-21T09:00:00Z"))
+				return db, mock
+			},
+		},
+		{
+			name: "given no entries, then get an empty list",
+			want: []model.AuditEntry{},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("expense", 3).WillReturnRows(sqlmock.NewRows(columns))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an invalid recorded date, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("expense", 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "expense", 3, "create", "ana", nil, []byte(`{}`), "test"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &AuditPostgresAdapter{db: db, schema: expensesSchema, table: auditTable}
+			got, err := r.FindByEntity(context.Background(), "expense", 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auditPostgresRepository.FindByEntity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("auditPostgresRepository.FindByEntity() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+type BalancePostgresAdapter struct {
+	db            executor
+	schema        string
+	incomesTable  string
+	expensesTable string
+	timeout       time.Duration
+}
+
+func NewBalancePostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.BalanceRepository {
+	return &BalancePostgresAdapter{
+		db:            db,
+		schema:        prop.Schema,
+		incomesTable:  incomesTable,
+		expensesTable: expensesTable,
+		timeout:       prop.QueryTimeout,
+	}
+}
+
+func (r *BalancePostgresAdapter) TotalsBefore(ctx context.Context, date time.Time,
+	currency string) ([]model.CurrencyTotals, error) {
+	return r.totals(ctx, "CASE WHEN m.currency = $2 THEN NULL ELSE CAST(m.created AS DATE) END",
+		"m.created < TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS')",
+		date.Format(time.RFC3339), currency)
+}
+
+func (r *BalancePostgresAdapter) DailyTotals(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error) {
+	return r.totals(ctx, "CAST(m.created AS DATE)",
+		"m.created >= TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS') "+
+			"AND m.created < TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS')",
+		from.Format(time.RFC3339), to.Format(time.RFC3339))
+}
+
+// totals sums the incomes and expenses matching condition per currency and
+// group, each row dated on the latest day of its group. condition and group
+// refer to either table as m.
+func (r *BalancePostgresAdapter) totals(ctx context.Context, group, condition string,
+	args ...any) ([]model.CurrencyTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT MAX(t.day), t.currency, SUM(t.income), SUM(t.expenses) FROM ("+
+		"SELECT CAST(m.created AS DATE) AS day, %s AS grp, m.currency, m.amount AS income, 0 AS expenses "+
+		"FROM %s.%s m WHERE %s "+
+		"UNION ALL "+
+		"SELECT CAST(m.created AS DATE) AS day, %s AS grp, m.currency, 0 AS income, m.amount AS expenses "+
+		"FROM %s.%s m WHERE m.deleted IS NULL AND %s"+
+		") t GROUP BY t.grp, t.currency ORDER BY MAX(t.day), t.currency",
+		group, r.schema, r.incomesTable, condition, group, r.schema, r.expensesTable, condition)
+
+	res, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("error: error executing daily totals query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating daily totals... "), err)
+	}
+
+	daily := []model.CurrencyTotals{}
+
+	defer res.Close()
+	for res.Next() {
+		var day, rawIncome, rawExpenses string
+		var currency sql.NullString
+		if err = res.Scan(&day, &currency, &rawIncome, &rawExpenses); err != nil {
+			log.Println("error: error reading daily totals... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error reading daily totals... "), err)
+		}
+		totals, err := parseCurrencyTotals(day, currency, rawIncome, rawExpenses)
+		if err != nil {
+			return nil, err
+		}
+		daily = append(daily, *totals)
+	}
+
+	return daily, nil
+}
+
+func parseCurrencyTotals(day string, currency sql.NullString, rawIncome, rawExpenses string) (*model.CurrencyTotals, error) {
+	date, err := time.Parse(time.RFC3339, day)
+	if err != nil {
+		log.Println("error: error parsing daily totals date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing daily totals date... "), err)
+	}
+	totals, err := parseTotals(rawIncome, rawExpenses)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CurrencyTotals{
+		Date:     date,
+		Currency: currency.String,
+		Income:   totals.Income,
+		Expenses: totals.Expenses,
+	}, nil
+}
+
+func parseTotals(rawIncome, rawExpenses string) (*model.BalanceTotals, error) {
+	income, err := model.ParseMoney(rawIncome)
+	if err != nil {
+		log.Println("error: error parsing income total... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing income total... "), err)
+	}
+	expenses, err := model.ParseMoney(rawExpenses)
+	if err != nil {
+		log.Println("error: error parsing expenses total... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing expenses total... "), err)
+	}
+	return &model.BalanceTotals{Income: income, Expenses: expenses}, nil
+}
@@ -0,0 +1,176 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const (
+	balanceSchema = "test"
+)
+
+func newTestBalanceAdapter(db *sql.DB) *BalancePostgresAdapter {
+	return &BalancePostgresAdapter{
+		db:            db,
+		schema:        balanceSchema,
+		incomesTable:  incomesTable,
+		expensesTable: expensesTable,
+	}
+}
+
+func Test_balancePostgresRepository_TotalsBefore(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT MAX(t.day), t.currency, SUM(t.income), SUM(t.expenses) FROM (" +
+		"SELECT CAST(m.created AS DATE) AS day, " +
+		"CASE WHEN m.currency = $2 THEN NULL ELSE CAST(m.created AS DATE) END AS grp, " +
+		"m.currency, m.amount AS income, 0 AS expenses " +
+		"FROM test.incomes m WHERE m.created < TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS') " +
+		"UNION ALL " +
+		"SELECT CAST(m.created AS DATE) AS day, " +
+		"CASE WHEN m.currency = $2 THEN NULL ELSE CAST(m.created AS DATE) END AS grp, " +
+		"m.currency, 0 AS income, m.amount AS expenses " +
+		"FROM test.expenses m WHERE m.deleted IS NULL AND m.created < TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS')" +
+		") t GROUP BY t.grp, t.currency ORDER BY MAX(t.day), t.currency")
+	date := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          []model.CurrencyTotals
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a date, then get one total of the reporting currency and daily ones of the others",
+			want: []model.CurrencyTotals{
+				{Date: time.Date(2023, 3, 29, 0, 0, 0, 0, time.UTC), Currency: "EUR", Expenses: 1000},
+				{Date: time.Date(2023, 3, 30, 0, 0, 0, 0, time.UTC), Currency: "USD", Income: 150000, Expenses: 32050},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("2023-04-01T00:00:00Z", "USD").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "currency", "income", "expenses"}).
+						AddRow("2023-03-29T00:00:00Z", "EUR", "0", "10.00").
+						AddRow("2023-03-30T00:00:00Z", "USD", "1500.00", "320.50"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a date, when the query fails, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("2023-04-01T00:00:00Z", "USD").
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestBalanceAdapter(db).TotalsBefore(context.Background(), date, "USD")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("balancePostgresRepository.TotalsBefore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("balancePostgresRepository.TotalsBefore() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_balancePostgresRepository_DailyTotals(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT MAX(t.day), t.currency, SUM(t.income), SUM(t.expenses) FROM (") + ".*" +
+		regexp.QuoteMeta(") t GROUP BY t.grp, t.currency ORDER BY MAX(t.day), t.currency")
+	from := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          []model.CurrencyTotals
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a date range, then get the totals per day",
+			want: []model.CurrencyTotals{
+				{Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Income: 10000, Expenses: 2000},
+				{Date: time.Date(2023, 4, 9, 0, 0, 0, 0, time.UTC), Currency: "USD", Expenses: 4500},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "currency", "income", "expenses"}).
+						AddRow("2023-04-03T00:00:00Z", nil, "100.00", "20.00").
+						AddRow("2023-04-09T00:00:00Z", "USD", "0", "45.00"))
+				return db, mock
+			},
+		},
+		{
+			name: "given a date range, when there are no movements, then get an empty response",
+			want: []model.CurrencyTotals{},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "currency", "income", "expenses"}))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a date range, when get an invalid day, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "currency", "income", "expenses"}).
+						AddRow("test", nil, 100, 20))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a date range, when the query fails, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs("2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z").
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestBalanceAdapter(db).DailyTotals(context.Background(), from, to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("balancePostgresRepository.DailyTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("balancePostgresRepository.DailyTotals() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
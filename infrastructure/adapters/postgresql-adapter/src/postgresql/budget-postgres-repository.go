@@ -0,0 +1,232 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	budgetsTable = "budgets"
+)
+
+type BudgetPostgresAdapter struct {
+	db              executor
+	schema          string
+	table           string
+	categoriesTable string
+	expensesTable   string
+	splitsTable     string
+	timeout         time.Duration
+}
+
+func NewBudgetPostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.BudgetRepository {
+	return &BudgetPostgresAdapter{
+		db:              db,
+		schema:          prop.Schema,
+		table:           budgetsTable,
+		categoriesTable: categoriesTable,
+		expensesTable:   expensesTable,
+		splitsTable:     expenseSplitsTable,
+		timeout:         prop.QueryTimeout,
+	}
+}
+
+func (r *BudgetPostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("select count(t.id) from %s.%s t where t.id = $1", r.schema, r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for budget... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *BudgetPostgresAdapter) FindByID(ctx context.Context, id int) (*model.Budget, error) {
+	query := fmt.Sprintf("SELECT id, category_id, period, limit_amount FROM %s.%s WHERE id = $1",
+		r.schema, r.table)
+
+	budgets, err := r.find(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return nil, customErrors.NewItemNotFoundError("budget")
+	}
+	return &budgets[0], nil
+}
+
+func (r *BudgetPostgresAdapter) FindAll(ctx context.Context) ([]model.Budget, error) {
+	query := fmt.Sprintf("SELECT id, category_id, period, limit_amount FROM %s.%s "+
+		"ORDER BY period, category_id", r.schema, r.table)
+	return r.find(ctx, query)
+}
+
+func (r *BudgetPostgresAdapter) FindByPeriod(ctx context.Context, period string) ([]model.Budget, error) {
+	query := fmt.Sprintf("SELECT id, category_id, period, limit_amount FROM %s.%s "+
+		"WHERE period = $1 ORDER BY category_id", r.schema, r.table)
+	return r.find(ctx, query, period)
+}
+
+func (r *BudgetPostgresAdapter) Save(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (category_id, period, limit_amount) "+
+		"VALUES($1, $2, $3) RETURNING id", r.schema, r.table)
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, b.CategoryId, b.Period, b.Limit.String()).Scan(&id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving budget... "), err)
+	}
+	b.Id = id
+	return b, nil
+}
+
+func (r *BudgetPostgresAdapter) Update(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET category_id=$1, period=$2, limit_amount=$3 WHERE id=$4",
+		r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, b.CategoryId, b.Period, b.Limit.String(), b.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating budget... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return b, nil
+}
+
+func (r *BudgetPostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id=$1", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting budget... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+// Spent walks the category tree below categoryId with a recursive query so
+// expenses filed under subcategories count towards the budget. The walk uses
+// UNION so a category already in the tree isn't visited again, ending it on
+// a cycle in the hierarchy. The split lines in the tree count with the date
+// and currency of their expense.
+func (r *BudgetPostgresAdapter) Spent(ctx context.Context, categoryId int, from, to time.Time) ([]model.CurrencyTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("WITH RECURSIVE tree AS ("+
+		"SELECT c.id FROM %s.%s c WHERE c.id = $1 "+
+		"UNION "+
+		"SELECT c.id FROM %s.%s c JOIN tree t ON c.parent_id = t.id"+
+		"), spent AS ("+
+		"SELECT e.created, e.currency, e.amount FROM %s.%s e "+
+		"WHERE e.category_id IN (SELECT id FROM tree) AND e.deleted IS NULL "+
+		"UNION ALL "+
+		"SELECT e.created, e.currency, s.amount FROM %s.%s s JOIN %s.%s e ON e.id = s.expense_id "+
+		"WHERE s.category_id IN (SELECT id FROM tree) AND e.deleted IS NULL"+
+		") SELECT CAST(created AS DATE) AS day, currency, 0, SUM(amount) FROM spent "+
+		"WHERE created >= TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS') "+
+		"AND created < TO_TIMESTAMP($3, 'YYYY-MM-DD\"T\"HH24:MI:SS') "+
+		"GROUP BY day, currency ORDER BY day, currency",
+		r.schema, r.categoriesTable, r.schema, r.categoriesTable, r.schema, r.expensesTable,
+		r.schema, r.splitsTable, r.schema, r.expensesTable)
+
+	res, err := r.db.QueryContext(ctx, query, categoryId, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	if err != nil {
+		log.Println("error: error executing spent query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating budget spent... "), err)
+	}
+
+	spent := []model.CurrencyTotals{}
+
+	defer res.Close()
+	for res.Next() {
+		var day, rawIncome, rawSpent string
+		var currency sql.NullString
+		if err := res.Scan(&day, &currency, &rawIncome, &rawSpent); err != nil {
+			log.Println("error: error reading budget spent... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error reading budget spent... "), err)
+		}
+		totals, err := parseCurrencyTotals(day, currency, rawIncome, rawSpent)
+		if err != nil {
+			return nil, err
+		}
+		spent = append(spent, *totals)
+	}
+	return spent, nil
+}
+
+func (r *BudgetPostgresAdapter) find(ctx context.Context, query string, args ...any) ([]model.Budget, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for budgets... "), err)
+	}
+
+	budgets := []model.Budget{}
+
+	defer res.Close()
+	for res.Next() {
+		var id, categoryId int
+		var period, rawLimit string
+		if err := res.Scan(&id, &categoryId, &period, &rawLimit); err != nil {
+			log.Println("error: error building budget item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building budget item... "), err)
+		}
+		limit, err := model.ParseMoney(rawLimit)
+		if err != nil {
+			log.Println("error: error parsing budget limit... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing budget limit... "), err)
+		}
+		budgets = append(budgets, model.Budget{
+			Id:         id,
+			CategoryId: categoryId,
+			Period:     period,
+			Limit:      limit,
+		})
+	}
+
+	return budgets, nil
+}
@@ -0,0 +1,281 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const (
+	budgetsSchema = "test"
+)
+
+func newTestBudgetAdapter(db *sql.DB) *BudgetPostgresAdapter {
+	return &BudgetPostgresAdapter{
+		db:              db,
+		schema:          budgetsSchema,
+		table:           budgetsTable,
+		categoriesTable: categoriesTable,
+		expensesTable:   expensesTable,
+		splitsTable:     expenseSplitsTable,
+	}
+}
+
+func Test_budgetPostgresRepository_FindByID(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, category_id, period, limit_amount FROM test.budgets WHERE id = $1")
+	tests := []struct {
+		name          string
+		want          *model.Budget
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, then get a budget",
+			want: &model.Budget{Id: 1, CategoryId: 2, Period: "2023-04", Limit: 50000},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "period", "limit_amount"}).
+						AddRow(1, 2, "2023-04", "500.00"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an id, when budget is not found, then get an error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "period", "limit_amount"}))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an id, when get an invalid limit, then get an error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "period", "limit_amount"}).
+						AddRow(1, 2, "2023-04", "test"))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestBudgetAdapter(db).FindByID(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("budgetPostgresRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("budgetPostgresRepository.FindByID() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_budgetPostgresRepository_FindByPeriod(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, category_id, period, limit_amount FROM test.budgets " +
+		"WHERE period = $1 ORDER BY category_id")
+	db, mock := NewMock()
+	defer db.Close()
+	mock.ExpectQuery(query).WithArgs("2023-04").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "period", "limit_amount"}).
+			AddRow(1, 2, "2023-04", "500.00").
+			AddRow(3, 4, "2023-04", "80.50"))
+
+	want := []model.Budget{
+		{Id: 1, CategoryId: 2, Period: "2023-04", Limit: 50000},
+		{Id: 3, CategoryId: 4, Period: "2023-04", Limit: 8050},
+	}
+	got, err := newTestBudgetAdapter(db).FindByPeriod(context.Background(), "2023-04")
+	if err != nil {
+		t.Fatalf("budgetPostgresRepository.FindByPeriod() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("budgetPostgresRepository.FindByPeriod() = %v, want %v", got, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func Test_budgetPostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.budgets (category_id, period, limit_amount) " +
+		"VALUES($1, $2, $3) RETURNING id")
+	tests := []struct {
+		name          string
+		want          *model.Budget
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a budget, then save it",
+			want: &model.Budget{Id: 5, CategoryId: 2, Period: "2023-04", Limit: 50000},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(2, "2023-04", "500.00").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a budget, when get an error saving, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(2, "2023-04", "500.00").
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			budget := &model.Budget{CategoryId: 2, Period: "2023-04", Limit: 50000}
+			got, err := newTestBudgetAdapter(db).Save(context.Background(), budget)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("budgetPostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("budgetPostgresRepository.Save() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_budgetPostgresRepository_Update(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE test.budgets SET category_id=$1, period=$2, limit_amount=$3 WHERE id=$4")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a budget, then update it",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(2, "2023-04", "600.00", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a budget, when no rows are updated, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(2, "2023-04", "600.00", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			budget := &model.Budget{Id: 1, CategoryId: 2, Period: "2023-04", Limit: 60000}
+			if _, err := newTestBudgetAdapter(db).Update(context.Background(), budget); (err != nil) != tt.wantErr {
+				t.Errorf("budgetPostgresRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_budgetPostgresRepository_Spent(t *testing.T) {
+	query := regexp.QuoteMeta("WITH RECURSIVE tree AS (" +
+		"SELECT c.id FROM test.categories c WHERE c.id = $1 " +
+		"UNION " +
+		"SELECT c.id FROM test.categories c JOIN tree t ON c.parent_id = t.id" +
+		"), spent AS (" +
+		"SELECT e.created, e.currency, e.amount FROM test.expenses e " +
+		"WHERE e.category_id IN (SELECT id FROM tree) AND e.deleted IS NULL " +
+		"UNION ALL " +
+		"SELECT e.created, e.currency, s.amount FROM test.expense_splits s JOIN test.expenses e ON e.id = s.expense_id " +
+		"WHERE s.category_id IN (SELECT id FROM tree) AND e.deleted IS NULL" +
+		") SELECT CAST(created AS DATE) AS day, currency, 0, SUM(amount) FROM spent " +
+		"WHERE created >= TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS') " +
+		"AND created < TO_TIMESTAMP($3, 'YYYY-MM-DD\"T\"HH24:MI:SS') " +
+		"GROUP BY day, currency ORDER BY day, currency")
+	from := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          []model.CurrencyTotals
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a category and a period, then get the amount spent per day and currency",
+			want: []model.CurrencyTotals{
+				{Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Expenses: 32050},
+				{Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Currency: "EUR", Expenses: 1000},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs(2, "2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "currency", "income", "spent"}).
+						AddRow("2023-04-03T00:00:00Z", nil, "0", "320.50").
+						AddRow("2023-04-03T00:00:00Z", "EUR", "0", "10.00"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a category and a period, when the query fails, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WithArgs(2, "2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z").
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestBudgetAdapter(db).Spent(context.Background(), 2, from, to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("budgetPostgresRepository.Spent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("budgetPostgresRepository.Spent() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	categoriesTable = "categories"
+)
+
+type CategoryPostgresAdapter struct {
+	db      executor
+	schema  string
+	table   string
+	timeout time.Duration
+}
+
+func NewCategoryPostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.CategoryRepository {
+	return &CategoryPostgresAdapter{
+		db:      db,
+		schema:  prop.Schema,
+		table:   categoriesTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *CategoryPostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("select count(t.id) from %s.%s t where t.id = $1", r.schema, r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for category... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *CategoryPostgresAdapter) FindByID(ctx context.Context, id int) (*model.Category, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, name, parent_id FROM %s.%s WHERE id = $1", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for category... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanCategory(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("category")
+}
+
+func (r *CategoryPostgresAdapter) FindAll(ctx context.Context) ([]model.Category, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, name, parent_id FROM %s.%s ORDER BY id", r.schema, r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for categories... "), err)
+	}
+
+	categories := []model.Category{}
+
+	defer res.Close()
+	for res.Next() {
+		category, err := scanCategory(res)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *category)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryPostgresAdapter) Save(ctx context.Context, c *model.Category) (*model.Category, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (name, parent_id) VALUES($1, $2) RETURNING id",
+		r.schema, r.table)
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, c.Name, nullableID(c.ParentId)).Scan(&id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving category... "), err)
+	}
+	c.Id = id
+	return c, nil
+}
+
+func (r *CategoryPostgresAdapter) Update(ctx context.Context, c *model.Category) (*model.Category, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET name=$1, parent_id=$2 WHERE id=$3", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, c.Name, nullableID(c.ParentId), c.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating category... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return c, nil
+}
+
+func (r *CategoryPostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id=$1", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting category... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func scanCategory(res *sql.Rows) (*model.Category, error) {
+	var id int
+	var name string
+	var parentId sql.NullInt64
+	if err := res.Scan(&id, &name, &parentId); err != nil {
+		log.Println("error: error building category item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building category item... "), err)
+	}
+	return &model.Category{Id: id, Name: name, ParentId: idFromNullable(parentId)}, nil
+}
@@ -0,0 +1,323 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const (
+	categoriesSchema = "test"
+)
+
+func newTestCategoryAdapter(db *sql.DB) *CategoryPostgresAdapter {
+	return &CategoryPostgresAdapter{
+		db:     db,
+		schema: categoriesSchema,
+		table:  categoriesTable,
+	}
+}
+
+func Test_categoryPostgresRepository_Exists(t *testing.T) {
+	query := regexp.QuoteMeta("select count(t.id) from test.categories t where t.id = $1")
+	tests := []struct {
+		name          string
+		want          bool
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, when the category exists in database, then return true",
+			want: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an id, when check if the category exists in database, then return error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestCategoryAdapter(db).Exists(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("categoryPostgresRepository.Exists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("categoryPostgresRepository.Exists() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_categoryPostgresRepository_FindByID(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, name, parent_id FROM test.categories WHERE id = $1")
+	tests := []struct {
+		name          string
+		want          *model.Category
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, then get a subcategory",
+			want: &model.Category{Id: 2, Name: "groceries", ParentId: 1},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id"}).
+						AddRow(2, "groceries", 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an id, when category is not found, then get an error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id"}))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestCategoryAdapter(db).FindByID(context.Background(), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("categoryPostgresRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("categoryPostgresRepository.FindByID() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_categoryPostgresRepository_FindAll(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, name, parent_id FROM test.categories ORDER BY id")
+	tests := []struct {
+		name          string
+		want          []model.Category
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a get all categories request, then get the hierarchy rows",
+			want: []model.Category{
+				{Id: 1, Name: "food"},
+				{Id: 2, Name: "groceries", ParentId: 1},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id"}).
+						AddRow(1, "food", nil).
+						AddRow(2, "groceries", 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a get all categories request, when get an invalid value, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id"}).
+						AddRow("test", "food", nil))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestCategoryAdapter(db).FindAll(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("categoryPostgresRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("categoryPostgresRepository.FindAll() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_categoryPostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.categories (name, parent_id) VALUES($1, $2) RETURNING id")
+	tests := []struct {
+		name          string
+		category      *model.Category
+		want          *model.Category
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name:     "given a top-level category, then save it with a null parent",
+			category: &model.Category{Name: "food"},
+			want:     &model.Category{Id: 5, Name: "food"},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("food", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				return db, mock
+			},
+		},
+		{
+			name:     "given a subcategory, then save it with its parent",
+			category: &model.Category{Name: "groceries", ParentId: 5},
+			want:     &model.Category{Id: 6, Name: "groceries", ParentId: 5},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("groceries", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+				return db, mock
+			},
+		},
+		{
+			name:     "given a category, when get an error saving, then get error",
+			category: &model.Category{Name: "food"},
+			wantErr:  true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("food", nil).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestCategoryAdapter(db).Save(context.Background(), tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("categoryPostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("categoryPostgresRepository.Save() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_categoryPostgresRepository_Update(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE test.categories SET name=$1, parent_id=$2 WHERE id=$3")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a category, then update it",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs("groceries", 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a category, when no rows are updated, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs("groceries", 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			category := &model.Category{Id: 2, Name: "groceries", ParentId: 1}
+			_, err := newTestCategoryAdapter(db).Update(context.Background(), category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("categoryPostgresRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_categoryPostgresRepository_Delete(t *testing.T) {
+	query := regexp.QuoteMeta("DELETE FROM test.categories WHERE id=$1")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, then delete the category",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an id, when get an error deleting, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(1).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			if err := newTestCategoryAdapter(db).Delete(context.Background(), 1); (err != nil) != tt.wantErr {
+				t.Errorf("categoryPostgresRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
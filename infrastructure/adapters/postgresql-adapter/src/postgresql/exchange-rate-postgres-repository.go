@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"github.com/lib/pq"
+)
+
+const (
+	exchangeRatesTable = "exchange_rates"
+)
+
+type ExchangeRatePostgresAdapter struct {
+	db      executor
+	schema  string
+	table   string
+	timeout time.Duration
+}
+
+func NewExchangeRatePostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.ExchangeRateRepository {
+	return &ExchangeRatePostgresAdapter{
+		db:      db,
+		schema:  prop.Schema,
+		table:   exchangeRatesTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+// Save writes every rate with a single statement, so a file is stored
+// whole or not at all.
+func (r *ExchangeRatePostgresAdapter) Save(ctx context.Context, rates []model.ExchangeRate) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// ON CONFLICT can't touch a row twice in one statement, so the last of
+	// the repeated rates wins here.
+	index := map[exchangeRateKey]int{}
+	var dates, bases, quotes, values []string
+	for _, rate := range rates {
+		key := exchangeRateKey{date: rate.Date.Format(time.DateOnly), base: rate.Base, quote: rate.Quote}
+		value := rate.Rate.String()
+		if i, ok := index[key]; ok {
+			values[i] = value
+			continue
+		}
+		index[key] = len(dates)
+		dates = append(dates, key.date)
+		bases = append(bases, key.base)
+		quotes = append(quotes, key.quote)
+		values = append(values, value)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (date, base, quote, rate) "+
+		"SELECT * FROM UNNEST(CAST($1 AS DATE[]), CAST($2 AS CHAR(3)[]), CAST($3 AS CHAR(3)[]), "+
+		"CAST($4 AS NUMERIC[])) "+
+		"ON CONFLICT (base, quote, date) DO UPDATE SET rate = EXCLUDED.rate", r.schema, r.table)
+
+	_, err := r.db.ExecContext(ctx, query, pq.Array(dates), pq.Array(bases), pq.Array(quotes), pq.Array(values))
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return errors.Join(fmt.Errorf("error: saving exchange rates... "), err)
+	}
+	return nil
+}
+
+func (r *ExchangeRatePostgresAdapter) FindEffective(ctx context.Context, base, quote string,
+	date time.Time) (*model.ExchangeRate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT date, base, quote, rate FROM %s.%s "+
+		"WHERE base = $1 AND quote = $2 AND date <= TO_DATE($3, 'YYYY-MM-DD') "+
+		"ORDER BY date DESC LIMIT 1", r.schema, r.table)
+
+	var rawDate, rawRate string
+	rate := &model.ExchangeRate{}
+	err := r.db.QueryRowContext(ctx, query, base, quote, date.Format(time.DateOnly)).
+		Scan(&rawDate, &rate.Base, &rate.Quote, &rawRate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.NewItemNotFoundError("exchange rate")
+	}
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for exchange rate... "), err)
+	}
+	if rate.Date, err = time.Parse(time.RFC3339, rawDate); err != nil {
+		log.Println("error: error parsing exchange rate date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing exchange rate date... "), err)
+	}
+	if rate.Rate, err = model.ParseRate(rawRate); err != nil {
+		log.Println("error: error parsing exchange rate... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing exchange rate... "), err)
+	}
+	return rate, nil
+}
+
+// FindInEffect reads the rates of the period with one query; the first is
+// the latest one on or before from.
+func (r *ExchangeRatePostgresAdapter) FindInEffect(ctx context.Context, base, quote string,
+	from, to time.Time) ([]model.ExchangeRate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT date, base, quote, rate FROM %s.%s "+
+		"WHERE base = $1 AND quote = $2 AND date <= TO_DATE($4, 'YYYY-MM-DD') AND date >= COALESCE("+
+		"(SELECT MAX(date) FROM %s.%s WHERE base = $1 AND quote = $2 AND date <= TO_DATE($3, 'YYYY-MM-DD')), "+
+		"TO_DATE($3, 'YYYY-MM-DD')) ORDER BY date", r.schema, r.table, r.schema, r.table)
+
+	rows, err := r.db.QueryContext(ctx, query, base, quote, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for exchange rates... "), err)
+	}
+	defer rows.Close()
+
+	rates := []model.ExchangeRate{}
+	for rows.Next() {
+		var rawDate, rawRate string
+		rate := model.ExchangeRate{}
+		if err := rows.Scan(&rawDate, &rate.Base, &rate.Quote, &rawRate); err != nil {
+			log.Println("error: error scanning exchange rate... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error searching for exchange rates... "), err)
+		}
+		if rate.Date, err = time.Parse(time.RFC3339, rawDate); err != nil {
+			log.Println("error: error parsing exchange rate date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing exchange rate date... "), err)
+		}
+		if rate.Rate, err = model.ParseRate(rawRate); err != nil {
+			log.Println("error: error parsing exchange rate... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing exchange rate... "), err)
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println("error: error reading exchange rates... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for exchange rates... "), err)
+	}
+	return rates, nil
+}
+
+type exchangeRateKey struct {
+	date, base, quote string
+}
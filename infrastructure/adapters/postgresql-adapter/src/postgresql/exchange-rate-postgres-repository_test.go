@@ -0,0 +1,216 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/lib/pq"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func newTestExchangeRateAdapter(db *sql.DB) *ExchangeRatePostgresAdapter {
+	return &ExchangeRatePostgresAdapter{db: db, schema: expensesSchema, table: exchangeRatesTable}
+}
+
+func Test_exchangeRatePostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.exchange_rates (date, base, quote, rate) " +
+		"SELECT * FROM UNNEST(CAST($1 AS DATE[]), CAST($2 AS CHAR(3)[]), CAST($3 AS CHAR(3)[]), " +
+		"CAST($4 AS NUMERIC[])) " +
+		"ON CONFLICT (base, quote, date) DO UPDATE SET rate = EXCLUDED.rate")
+	date := time.Date(2023, time.April, 14, 0, 0, 0, 0, time.UTC)
+	rates := []model.ExchangeRate{
+		{Date: date, Base: "EUR", Quote: "USD", Rate: 109810000},
+		{Date: date, Base: "EUR", Quote: "GBP", Rate: 88380000},
+		{Date: date, Base: "EUR", Quote: "USD", Rate: 109750000},
+	}
+	array := func(values ...string) any {
+		v, _ := pq.Array(values).Value()
+		return v
+	}
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given rates, then upsert them in one statement with the last of the repeated ones",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).
+					WithArgs(array("2023-04-14", "2023-04-14"), array("EUR", "EUR"), array("USD", "GBP"),
+						array("1.0975", "0.8838")).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				return db, mock
+			},
+		},
+		{
+			name:    "given rates, when the insert fails, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			err := newTestExchangeRateAdapter(db).Save(context.Background(), rates)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("exchangeRatePostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_exchangeRatePostgresRepository_FindEffective(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT date, base, quote, rate FROM test.exchange_rates " +
+		"WHERE base = $1 AND quote = $2 AND date <= TO_DATE($3, 'YYYY-MM-DD') ORDER BY date DESC LIMIT 1")
+	columns := []string{"date", "base", "quote", "rate"}
+	date := time.Date(2023, time.April, 16, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          *model.ExchangeRate
+		wantErr       error
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a pair and a date, then get the latest rate on or before it",
+			want: &model.ExchangeRate{
+				Date: time.Date(2023, time.April, 14, 0, 0, 0, 0, time.UTC), Base: "EUR", Quote: "USD", Rate: 109810000,
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("EUR", "USD", "2023-04-16").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("2023-04-14T00:00:00Z", "EUR", "USD", "1.0981"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a pair without rates, then get not found",
+			wantErr: &customErrors.ItemNotFound{},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("EUR", "USD", "2023-04-16").
+					WillReturnRows(sqlmock.NewRows(columns))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a pair, when get an invalid rate, then get error",
+			wantErr: errors.ErrUnsupported,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("EUR", "USD", "2023-04-16").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("2023-04-14T00:00:00Z", "EUR", "USD", "test"))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestExchangeRateAdapter(db).FindEffective(context.Background(), "EUR", "USD", date)
+			if tt.wantErr != nil {
+				var notFound *customErrors.ItemNotFound
+				if err == nil || errors.As(tt.wantErr, &notFound) != errors.As(err, &notFound) {
+					t.Errorf("exchangeRatePostgresRepository.FindEffective() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exchangeRatePostgresRepository.FindEffective() = %+v, %v, want %+v", got, err, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_exchangeRatePostgresRepository_FindInEffect(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT date, base, quote, rate FROM test.exchange_rates " +
+		"WHERE base = $1 AND quote = $2 AND date <= TO_DATE($4, 'YYYY-MM-DD') AND date >= COALESCE(" +
+		"(SELECT MAX(date) FROM test.exchange_rates WHERE base = $1 AND quote = $2 AND date <= TO_DATE($3, 'YYYY-MM-DD')), " +
+		"TO_DATE($3, 'YYYY-MM-DD')) ORDER BY date")
+	columns := []string{"date", "base", "quote", "rate"}
+	from := time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, time.April, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          []model.ExchangeRate
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a period, then get the rates in effect over it in one query",
+			want: []model.ExchangeRate{
+				{Date: time.Date(2023, time.April, 14, 0, 0, 0, 0, time.UTC), Base: "EUR", Quote: "USD", Rate: 109810000},
+				{Date: time.Date(2023, time.April, 17, 0, 0, 0, 0, time.UTC), Base: "EUR", Quote: "USD", Rate: 109750000},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("EUR", "USD", "2023-04-15", "2023-04-18").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("2023-04-14T00:00:00Z", "EUR", "USD", "1.09810000").
+						AddRow("2023-04-17T00:00:00Z", "EUR", "USD", "1.09750000"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a period, when the query fails, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("EUR", "USD", "2023-04-15", "2023-04-18").
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+		{
+			name:    "given a period, when get an invalid rate, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("EUR", "USD", "2023-04-15", "2023-04-18").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("2023-04-14T00:00:00Z", "EUR", "USD", "NaN"))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestExchangeRateAdapter(db).FindInEffect(context.Background(), "EUR", "USD", from, to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("exchangeRatePostgresRepository.FindInEffect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exchangeRatePostgresRepository.FindInEffect() = %+v, want %+v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/porttest"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/migrations"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+// contractDatabaseEnv names the variable holding the connection string of a
+// Postgres database the contract tests can create schemas in, for example
+// "host=localhost port=50000 user=cnxuser password=cnxpass dbname=budgetdb sslmode=disable".
+const contractDatabaseEnv = "BUDGET_MANAGER_TEST_POSTGRES"
+
+func Test_expensePostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestExpenseRepository(t, func(t *testing.T) port.ExpenseRepository {
+		props := newContractSchema(t, db, "contract")
+		return NewExpensePostgresAdapter(props, db)
+	})
+}
+
+func Test_auditPostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestAuditRepository(t, func(t *testing.T) port.AuditRepository {
+		props := newContractSchema(t, db, "contract_audit")
+		return NewAuditPostgresAdapter(props, db)
+	})
+}
+
+func Test_importedTransactionPostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestImportedTransactionRepository(t, func(t *testing.T) port.ImportedTransactionRepository {
+		props := newContractSchema(t, db, "contract_imports")
+		return NewImportedTransactionPostgresAdapter(props, db)
+	})
+}
+
+func Test_accountPostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestAccountRepository(t, func(t *testing.T) port.AccountRepository {
+		props := newContractSchema(t, db, "contract_accounts")
+		return NewAccountPostgresAdapter(props, db)
+	})
+}
+
+func Test_transferPostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestTransferRepository(t, func(t *testing.T) (port.TransferRepository, port.AccountRepository) {
+		props := newContractSchema(t, db, "contract_transfers")
+		return NewTransferPostgresAdapter(props, db), NewAccountPostgresAdapter(props, db)
+	})
+}
+
+func Test_exchangeRatePostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestExchangeRateRepository(t, func(t *testing.T) port.ExchangeRateRepository {
+		props := newContractSchema(t, db, "contract_rates")
+		return NewExchangeRatePostgresAdapter(props, db)
+	})
+}
+
+func Test_recurringRulePostgresRepository_Contract(t *testing.T) {
+	db := contractDatabase(t)
+	porttest.TestRecurringRuleRepository(t, func(t *testing.T) port.RecurringRuleRepository {
+		props := newContractSchema(t, db, "contract_recurring")
+		return NewRecurringRulePostgresAdapter(props, db)
+	})
+}
+
+// contractSchemas numbers the schemas created by a test run, so that every
+// test gets its own.
+var contractSchemas atomic.Int64
+
+// contractDatabase opens the database named by contractDatabaseEnv, skipping
+// the test when it isn't set.
+func contractDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(contractDatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set, skipping the tests against a real database", contractDatabaseEnv)
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newContractSchema creates an empty, migrated schema named after prefix that
+// is dropped when the test ends, and returns the properties that point to it.
+func newContractSchema(t *testing.T, db *sql.DB, prefix string) postgresconfig.PostgreSqlConnectionProperties {
+	t.Helper()
+	props := postgresconfig.PostgreSqlConnectionProperties{
+		Schema:       fmt.Sprintf("%s_%d_%d", prefix, time.Now().Unix(), contractSchemas.Add(1)),
+		QueryTimeout: 5 * time.Second,
+	}
+	if _, err := db.Exec(fmt.Sprintf("CREATE SCHEMA %s", props.Schema)); err != nil {
+		t.Fatalf("error creating schema %s: %v", props.Schema, err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", props.Schema)); err != nil {
+			t.Errorf("error dropping schema %s: %v", props.Schema, err)
+		}
+	})
+
+	migrator, err := migrations.NewMigrator(props, db, "USD")
+	if err != nil {
+		t.Fatalf("error loading migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("error migrating schema %s: %v", props.Schema, err)
+	}
+	return props
+}
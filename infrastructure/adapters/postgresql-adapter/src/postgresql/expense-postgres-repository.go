@@ -1,45 +1,72 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/enaldo1709/budget-manager/domain/model/src/model"
 	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
 	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
 	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"github.com/lib/pq"
 )
 
 const (
-	expensesTable = "expenses"
+	expensesTable      = "expenses"
+	expenseSplitsTable = "expense_splits"
+)
+
+// expenseSortColumns whitelists the columns a listing can be sorted by, and
+// expenseCursorParams how a cursor value is compared against each of them.
+var (
+	expenseSortColumns = map[model.ExpenseSortField]string{
+		model.SortByCreated: "created",
+		model.SortByAmount:  "amount",
+		model.SortById:      "id",
+	}
+	expenseCursorParams = map[model.ExpenseSortField]string{
+		model.SortByCreated: "TO_TIMESTAMP($%d, 'YYYY-MM-DD\"T\"HH24:MI:SS')",
+		model.SortByAmount:  "CAST($%d AS NUMERIC)",
+	}
 )
 
 type ExpensePostgresAdapter struct {
-	db     *sql.DB
-	schema string
-	table  string
+	db          executor
+	schema      string
+	table       string
+	splitsTable string
+	timeout     time.Duration
 }
 
 func NewExpensePostgresAdapter(
 	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.ExpenseRepository {
 	return &ExpensePostgresAdapter{
-		db:     db,
-		schema: prop.Schema,
-		table:  expensesTable,
+		db:          db,
+		schema:      prop.Schema,
+		table:       expensesTable,
+		splitsTable: expenseSplitsTable,
+		timeout:     prop.QueryTimeout,
 	}
 }
 
-func (r *ExpensePostgresAdapter) Exists(id int) (bool, error) {
-	query := fmt.Sprintf("select count(t.id) from %s.%s t where t.id = $1", r.schema, r.table)
+func (r *ExpensePostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("select count(t.id) from %s.%s t where t.id = $1 and t.deleted is null", r.schema, r.table)
 
-	res, err := r.db.Query(query, id)
+	res, err := r.db.QueryContext(ctx, query, id)
 	if err != nil {
 		log.Println("error: error executing query... ", err)
 		return false, errors.Join(fmt.Errorf("error: error searching for expense... "), err)
 	}
+	defer res.Close()
+
 	var count int
 	if res.Next() {
 		if err = res.Scan(&count); err != nil {
@@ -51,11 +78,14 @@ func (r *ExpensePostgresAdapter) Exists(id int) (bool, error) {
 	return count > 0, nil
 }
 
-func (r *ExpensePostgresAdapter) FindByID(id int) (*model.Expense, error) {
-	query := fmt.Sprintf("SELECT id, amount, created FROM %s.%s "+
-		"WHERE id = $1", r.schema, r.table)
+func (r *ExpensePostgresAdapter) FindByID(ctx context.Context, id int) (*model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	res, err := r.db.Query(query, id)
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version FROM %s.%s "+
+		"WHERE id = $1 AND deleted IS NULL", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
 	if err != nil {
 		log.Println("error: error executing select query... ", err)
 		return nil, errors.Join(fmt.Errorf("error: error searching for expense... "), err)
@@ -65,27 +95,51 @@ func (r *ExpensePostgresAdapter) FindByID(id int) (*model.Expense, error) {
 
 	if res.Next() {
 		var retId int
-		var amount float64
+		var rawAmount string
 		var createdDate string
-		err = res.Scan(&retId, &amount, &createdDate)
+		var categoryId, accountId sql.NullInt64
+		var currency sql.NullString
+		var version int
+		err = res.Scan(&retId, &rawAmount, &createdDate, &categoryId, &accountId, &currency, &version)
 		if err != nil {
 			log.Println("error: error building expense item... ", err)
 			return nil, errors.Join(fmt.Errorf("error: error building expense item... "), err)
 		}
+		amount, err := model.ParseMoney(rawAmount)
+		if err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
 		date, err := time.Parse(time.RFC3339, createdDate)
 		if err != nil {
 			log.Println("error: error parsing created date... ", err)
 			return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
 		}
-		return &model.Expense{Id: retId, Amount: amount, Created: date}, nil
+		expenses := []model.Expense{{
+			Id:         retId,
+			Amount:     amount,
+			Created:    date,
+			CategoryId: idFromNullable(categoryId),
+			AccountId:  idFromNullable(accountId),
+			Currency:   currency.String,
+			Version:    version,
+		}}
+		res.Close()
+		if err := r.findSplits(ctx, expenses); err != nil {
+			return nil, err
+		}
+		return &expenses[0], nil
 	}
 
 	return nil, customErrors.NewItemNotFoundError("expense")
 }
 
-func (r *ExpensePostgresAdapter) FindAll() ([]model.Expense, error) {
-	query := fmt.Sprintf("SELECT id, amount, created FROM %s.%s", r.schema, r.table)
-	res, err := r.db.Query(query)
+func (r *ExpensePostgresAdapter) FindAll(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query, args := r.findAllQuery(q)
+	res, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Println("error: error executing select query... ", err)
 		return nil, errors.Join(fmt.Errorf("error: error searching for expenses... "), err)
@@ -96,60 +150,78 @@ func (r *ExpensePostgresAdapter) FindAll() ([]model.Expense, error) {
 	defer res.Close()
 	for res.Next() {
 		var retId int
-		var amount float64
+		var rawAmount string
 		var createdDate string
-		err = res.Scan(&retId, &amount, &createdDate)
+		var categoryId, accountId sql.NullInt64
+		var currency sql.NullString
+		var version int
+		err = res.Scan(&retId, &rawAmount, &createdDate, &categoryId, &accountId, &currency, &version)
 		if err != nil {
 			log.Println("error: error building expense item... ", err)
 			return nil, errors.Join(fmt.Errorf("error: error building expense item... "), err)
 		}
+		amount, err := model.ParseMoney(rawAmount)
+		if err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
 		date, err := time.Parse(time.RFC3339, createdDate)
 		if err != nil {
 			log.Println("error: error parsing created date... ", err)
 			return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
 		}
-		expenses = append(expenses, model.Expense{Id: retId, Amount: amount, Created: date})
+		expenses = append(expenses, model.Expense{
+			Id:         retId,
+			Amount:     amount,
+			Created:    date,
+			CategoryId: idFromNullable(categoryId),
+			AccountId:  idFromNullable(accountId),
+			Currency:   currency.String,
+			Version:    version,
+		})
 	}
+	res.Close()
 
+	if err := r.findSplits(ctx, expenses); err != nil {
+		return nil, err
+	}
 	return expenses, nil
 }
 
-func (r *ExpensePostgresAdapter) Save(e *model.Expense) (*model.Expense, error) {
-	var nextVal int
-	err := r.db.
-		QueryRow(fmt.Sprintf("select nextval('%s.%s_id_seq'::regclass)", r.schema, r.table)).
-		Scan(&nextVal)
-	if err != nil {
-		return nil, err
-	}
+func (r *ExpensePostgresAdapter) Save(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
 	query := fmt.Sprintf("INSERT "+
-		"INTO %s.%s (id, amount, created) "+
-		"VALUES($1, $2, TO_TIMESTAMP($3, 'YYYY-MM-DD\"T\"HH24:MI:SS'))",
+		"INTO %s.%s (amount, created, category_id, account_id, currency) "+
+		"VALUES($1, TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $3, $4, $5) RETURNING id",
 		r.schema, r.table)
 
-	res, err := r.db.Exec(query, nextVal, e.Amount, e.Created.Format(time.RFC3339))
+	var id int
+	err := r.db.QueryRowContext(ctx, query, e.Amount.String(), e.Created.Format(time.RFC3339),
+		nullableID(e.CategoryId), nullableID(e.AccountId), nullableString(e.Currency)).Scan(&id)
 	if err != nil {
 		log.Println("error: error executing insert query... ", err)
 		return nil, errors.Join(fmt.Errorf("error: saving expense... "), err)
 	}
-	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
-		if err != nil {
-			log.Println("error: error reading save result... ", err)
-			return nil, errors.Join(fmt.Errorf("error: unknown save operation result... "), err)
-		}
-		log.Printf("error: error executing save query... %d items inserted\n", nr)
-		return nil, fmt.Errorf("error: 0 items inserted on operation... ")
+	e.Id = id
+	if err := r.saveSplits(ctx, e); err != nil {
+		return nil, err
 	}
-	e.Id = nextVal
+	e.Version = 1
 	return e, nil
 }
 
-func (r *ExpensePostgresAdapter) Update(e *model.Expense) (*model.Expense, error) {
+func (r *ExpensePostgresAdapter) Update(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := fmt.Sprintf("UPDATE %s.%s SET amount=$1, "+
-		"created=TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS') WHERE id=$3", r.schema, r.table)
+		"created=TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS'), category_id=$3, account_id=$4, "+
+		"currency=$5, version=version+1 WHERE id=$6 AND version=$7 AND deleted IS NULL", r.schema, r.table)
 
-	res, err := r.db.Exec(query, e.Amount, e.Created.Format(time.RFC3339), e.Id)
+	res, err := r.db.ExecContext(ctx, query, e.Amount.String(), e.Created.Format(time.RFC3339),
+		nullableID(e.CategoryId), nullableID(e.AccountId), nullableString(e.Currency), e.Id, e.Version)
 	if err != nil {
 		log.Println("error: error executing update query... ", err)
 		return nil, errors.Join(fmt.Errorf("error: updating expense... "), err)
@@ -159,16 +231,34 @@ func (r *ExpensePostgresAdapter) Update(e *model.Expense) (*model.Expense, error
 			log.Println("error: error reading update result... ", err)
 			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
 		}
+		if exists, err := r.Exists(ctx, e.Id); err == nil && exists {
+			log.Printf("error: expense %d changed since version %d\n", e.Id, e.Version)
+			return nil, customErrors.NewConcurrentModificationError("expense")
+		}
 		log.Printf("error: error executing update query... %d items updated\n", nr)
 		return nil, fmt.Errorf("error: 0 items updated on operation... ")
 	}
+
+	query = fmt.Sprintf("DELETE FROM %s.%s WHERE expense_id=$1", r.schema, r.splitsTable)
+	if _, err := r.db.ExecContext(ctx, query, e.Id); err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating expense splits... "), err)
+	}
+	if err := r.saveSplits(ctx, e); err != nil {
+		return nil, err
+	}
+	e.Version++
 	return e, nil
 }
 
-func (r *ExpensePostgresAdapter) Delete(id int) error {
-	query := fmt.Sprintf("DELETE fROM %s.%s WHERE id=$1", r.schema, r.table)
+func (r *ExpensePostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET deleted=TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS') "+
+		"WHERE id=$2 AND deleted IS NULL", r.schema, r.table)
 
-	res, err := r.db.Exec(query, id)
+	res, err := r.db.ExecContext(ctx, query, time.Now().UTC().Format(time.RFC3339), id)
 	if err != nil {
 		log.Println("error: error executing delete query... ", err)
 		return errors.Join(fmt.Errorf("error: deleting expense... "), err)
@@ -184,3 +274,250 @@ func (r *ExpensePostgresAdapter) Delete(id int) error {
 
 	return nil
 }
+
+func (r *ExpensePostgresAdapter) FindDeleted(ctx context.Context) ([]model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version, deleted FROM %s.%s "+
+		"WHERE deleted IS NOT NULL ORDER BY deleted DESC, id DESC", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for deleted expenses... "), err)
+	}
+
+	expenses := []model.Expense{}
+
+	defer res.Close()
+	for res.Next() {
+		var retId int
+		var rawAmount string
+		var createdDate string
+		var categoryId, accountId sql.NullInt64
+		var currency sql.NullString
+		var version int
+		var deletedDate string
+		err = res.Scan(&retId, &rawAmount, &createdDate, &categoryId, &accountId, &currency, &version, &deletedDate)
+		if err != nil {
+			log.Println("error: error building expense item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building expense item... "), err)
+		}
+		amount, err := model.ParseMoney(rawAmount)
+		if err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
+		date, err := time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			log.Println("error: error parsing created date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+		}
+		deleted, err := time.Parse(time.RFC3339, deletedDate)
+		if err != nil {
+			log.Println("error: error parsing deleted date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing deleted date... "), err)
+		}
+		expenses = append(expenses, model.Expense{
+			Id:         retId,
+			Amount:     amount,
+			Created:    date,
+			CategoryId: idFromNullable(categoryId),
+			AccountId:  idFromNullable(accountId),
+			Currency:   currency.String,
+			Version:    version,
+			Deleted:    &deleted,
+		})
+	}
+	res.Close()
+
+	if err := r.findSplits(ctx, expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *ExpensePostgresAdapter) Restore(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET deleted=NULL WHERE id=$1 AND deleted IS NOT NULL",
+		r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing restore query... ", err)
+		return errors.Join(fmt.Errorf("error: restoring expense... "), err)
+	}
+	nr, err := res.RowsAffected()
+	if err != nil {
+		log.Println("error: error reading restore result... ", err)
+		return errors.Join(fmt.Errorf("error: unknown restore operation result... "), err)
+	}
+	if nr == 0 {
+		return customErrors.NewItemNotFoundError("deleted expense")
+	}
+
+	return nil
+}
+
+func (r *ExpensePostgresAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE deleted IS NOT NULL "+
+		"AND deleted < TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS')", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, before.UTC().Format(time.RFC3339))
+	if err != nil {
+		log.Println("error: error executing purge query... ", err)
+		return 0, errors.Join(fmt.Errorf("error: purging deleted expenses... "), err)
+	}
+	nr, err := res.RowsAffected()
+	if err != nil {
+		log.Println("error: error reading purge result... ", err)
+		return 0, errors.Join(fmt.Errorf("error: unknown purge operation result... "), err)
+	}
+
+	return int(nr), nil
+}
+
+// findSplits fills in the splits of expenses with a single query. The
+// caller must have closed its own rows, since a transaction runs one query
+// at a time.
+func (r *ExpensePostgresAdapter) findSplits(ctx context.Context, expenses []model.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+	index := make(map[int]int, len(expenses))
+	ids := make([]int64, 0, len(expenses))
+	for i, e := range expenses {
+		index[e.Id] = i
+		ids = append(ids, int64(e.Id))
+	}
+
+	query := fmt.Sprintf("SELECT expense_id, category_id, amount, note FROM %s.%s "+
+		"WHERE expense_id = ANY($1) ORDER BY expense_id, position", r.schema, r.splitsTable)
+	res, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return errors.Join(fmt.Errorf("error: error searching for expense splits... "), err)
+	}
+
+	defer res.Close()
+	for res.Next() {
+		var expenseId int
+		var categoryId sql.NullInt64
+		var rawAmount, note string
+		if err := res.Scan(&expenseId, &categoryId, &rawAmount, &note); err != nil {
+			log.Println("error: error building expense split item... ", err)
+			return errors.Join(fmt.Errorf("error: error building expense split item... "), err)
+		}
+		amount, err := model.ParseMoney(rawAmount)
+		if err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
+		e := &expenses[index[expenseId]]
+		e.Splits = append(e.Splits, model.ExpenseSplit{
+			CategoryId: idFromNullable(categoryId),
+			Amount:     amount,
+			Note:       note,
+		})
+	}
+	return nil
+}
+
+// saveSplits stores the splits of e, numbered in their order.
+func (r *ExpensePostgresAdapter) saveSplits(ctx context.Context, e *model.Expense) error {
+	if len(e.Splits) == 0 {
+		return nil
+	}
+	categories := make([]int64, 0, len(e.Splits))
+	amounts := make([]string, 0, len(e.Splits))
+	notes := make([]string, 0, len(e.Splits))
+	for _, split := range e.Splits {
+		categories = append(categories, int64(split.CategoryId))
+		amounts = append(amounts, split.Amount.String())
+		notes = append(notes, split.Note)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (expense_id, position, category_id, amount, note) "+
+		"SELECT $1, s.position, NULLIF(s.category_id, 0), s.amount, s.note "+
+		"FROM UNNEST(CAST($2 AS INTEGER[]), CAST($3 AS NUMERIC[]), CAST($4 AS VARCHAR[])) "+
+		"WITH ORDINALITY AS s(category_id, amount, note, position)", r.schema, r.splitsTable)
+
+	_, err := r.db.ExecContext(ctx, query, e.Id, pq.Array(categories), pq.Array(amounts), pq.Array(notes))
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return errors.Join(fmt.Errorf("error: saving expense splits... "), err)
+	}
+	return nil
+}
+
+// findAllQuery builds the parameterized select for q. Every value travels as
+// a query argument; only whitelisted column names are written into the SQL.
+func (r *ExpensePostgresAdapter) findAllQuery(q model.ExpenseQuery) (string, []any) {
+	conditions := []string{"deleted IS NULL"}
+	args := []any{}
+	param := func(value any) int {
+		args = append(args, value)
+		return len(args)
+	}
+
+	if !q.From.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(
+			"created >= TO_TIMESTAMP($%d, 'YYYY-MM-DD\"T\"HH24:MI:SS')", param(q.From.Format(time.RFC3339))))
+	}
+	if !q.To.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(
+			"created < TO_TIMESTAMP($%d, 'YYYY-MM-DD\"T\"HH24:MI:SS')", param(q.To.Format(time.RFC3339))))
+	}
+	if q.MinAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("amount >= $%d", param(q.MinAmount.String())))
+	}
+	if q.MaxAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("amount <= $%d", param(q.MaxAmount.String())))
+	}
+	if q.CategoryId != 0 {
+		n := param(q.CategoryId)
+		conditions = append(conditions, fmt.Sprintf(
+			"(category_id = $%d OR id IN (SELECT expense_id FROM %s.%s WHERE category_id = $%d))",
+			n, r.schema, r.splitsTable, n))
+	}
+	if q.AccountId != 0 {
+		conditions = append(conditions, fmt.Sprintf("account_id = $%d", param(q.AccountId)))
+	}
+
+	column, ok := expenseSortColumns[q.SortBy]
+	if !ok {
+		column = expenseSortColumns[model.SortByCreated]
+	}
+	direction, comparison := "DESC", "<"
+	if q.Direction == model.SortAscending {
+		direction, comparison = "ASC", ">"
+	}
+
+	if q.After != nil {
+		if column == "id" {
+			conditions = append(conditions, fmt.Sprintf("id %s $%d", comparison, param(q.After.Id)))
+		} else {
+			value := fmt.Sprintf(expenseCursorParams[q.SortBy], param(q.After.Value))
+			conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, $%d)",
+				column, comparison, value, param(q.After.Id)))
+		}
+	}
+
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version FROM %s.%s WHERE %s",
+		r.schema, r.table, strings.Join(conditions, " AND "))
+	if column == "id" {
+		query += fmt.Sprintf(" ORDER BY id %s", direction)
+	} else {
+		query += fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+	}
+	if q.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", param(q.Limit))
+	}
+	return query, args
+}
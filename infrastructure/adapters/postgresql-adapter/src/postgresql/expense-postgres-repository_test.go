@@ -1,15 +1,19 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
 	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
 	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -28,6 +32,12 @@ func NewMock() (*sql.DB, sqlmock.Sqlmock) {
 	return db, mock
 }
 
+var (
+	expenseSplitsQuery = regexp.QuoteMeta("SELECT expense_id, category_id, amount, note FROM test.expense_splits " +
+		"WHERE expense_id = ANY($1) ORDER BY expense_id, position")
+	expenseSplitColumns = []string{"expense_id", "category_id", "amount", "note"}
+)
+
 func TestNewExpensePostgresAdapter(t *testing.T) {
 	db, _ := NewMock()
 	defer db.Close()
@@ -48,9 +58,24 @@ func TestNewExpensePostgresAdapter(t *testing.T) {
 				db:   db,
 			},
 			want: &ExpensePostgresAdapter{
-				db:     db,
-				schema: "test",
-				table:  expensesTable,
+				db:          db,
+				schema:      "test",
+				table:       expensesTable,
+				splitsTable: expenseSplitsTable,
+			},
+		},
+		{
+			name: "given a query timeout, then get a repository instance bounded by it",
+			args: args{
+				prop: postgresconfig.PostgreSqlConnectionProperties{Schema: "test", QueryTimeout: time.Second},
+				db:   db,
+			},
+			want: &ExpensePostgresAdapter{
+				db:          db,
+				schema:      "test",
+				table:       expensesTable,
+				splitsTable: expenseSplitsTable,
+				timeout:     time.Second,
 			},
 		},
 	}
@@ -176,11 +201,12 @@ func Test_expensePostgresRepository_Exists(t *testing.T) {
 			defer db.Close()
 
 			r := &ExpensePostgresAdapter{
-				db:     db,
-				schema: tt.fields.schema,
-				table:  tt.fields.table,
+				db:          db,
+				schema:      tt.fields.schema,
+				table:       tt.fields.table,
+				splitsTable: expenseSplitsTable,
 			}
-			got, err := r.Exists(tt.args.id)
+			got, err := r.Exists(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("expensePostgresRepository.Exists() error = %v, wantErr %v",
 					err, tt.wantErr)
@@ -196,7 +222,7 @@ func Test_expensePostgresRepository_Exists(t *testing.T) {
 }
 
 func Test_expensePostgresRepository_FindByID(t *testing.T) {
-	query := fmt.Sprintf("[SELECT id, amount, created FROM %s.%s WHERE id = $1]",
+	query := fmt.Sprintf("[SELECT id, amount, created, category_id, account_id, currency, version FROM %s.%s WHERE id = $1]",
 		expensesSchema, expensesTable)
 	type fields struct {
 		schema string
@@ -224,8 +250,9 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 			},
 			want: &model.Expense{
 				Id:      1,
-				Amount:  150,
+				Amount:  15000,
 				Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				Version: 1,
 			},
 			wantErr: false,
 			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
@@ -233,8 +260,39 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 
 				mock.ExpectQuery(query).
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}).
-						AddRow(1, 150, "2023-04-12T8:22:15Z"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, 150, "2023-04-12T8:22:15Z", nil, nil, nil, 1))
+				mock.ExpectQuery(expenseSplitsQuery).
+					WillReturnRows(sqlmock.NewRows(expenseSplitColumns))
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when the expense has a category, then get it in the response",
+			fields: fields{
+				schema: expensesSchema,
+				table:  expensesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			want: &model.Expense{
+				Id:         1,
+				Amount:     15000,
+				Created:    time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				CategoryId: 4,
+				Version:    1,
+			},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, 150, "2023-04-12T8:22:15Z", 4, nil, nil, 1))
+				mock.ExpectQuery(expenseSplitsQuery).
+					WillReturnRows(sqlmock.NewRows(expenseSplitColumns))
 				return db, mock
 			},
 		},
@@ -272,8 +330,8 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 
 				mock.ExpectQuery(query).
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}).
-						AddRow(1, "test", "2023-04-12T8:22:15Z"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, "test", "2023-04-12T8:22:15Z", nil, nil, nil, 1))
 				return db, mock
 			},
 		},
@@ -292,8 +350,8 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 
 				mock.ExpectQuery(query).
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}).
-						AddRow(1, 150, "test"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, 150, "test", nil, nil, nil, 1))
 				return db, mock
 			},
 		},
@@ -312,7 +370,7 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 
 				mock.ExpectQuery(query).
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}))
 				return db, mock
 			},
 		},
@@ -328,11 +386,12 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 			defer db.Close()
 
 			r := &ExpensePostgresAdapter{
-				db:     db,
-				schema: tt.fields.schema,
-				table:  tt.fields.table,
+				db:          db,
+				schema:      tt.fields.schema,
+				table:       tt.fields.table,
+				splitsTable: expenseSplitsTable,
 			}
-			got, err := r.FindByID(tt.args.id)
+			got, err := r.FindByID(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("expensePostgresRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -348,7 +407,7 @@ func Test_expensePostgresRepository_FindByID(t *testing.T) {
 }
 
 func Test_expensePostgresRepository_FindAll(t *testing.T) {
-	query := fmt.Sprintf("SELECT id, amount, created FROM %s.%s", expensesSchema, expensesTable)
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version FROM %s.%s", expensesSchema, expensesTable)
 	type fields struct {
 		schema string
 		table  string
@@ -369,18 +428,21 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 			want: []model.Expense{
 				{
 					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 1,
 				},
 				{
 					Id:      2,
-					Amount:  230,
+					Amount:  23000,
 					Created: time.Date(2023, 4, 12, 8, 26, 43, 0, time.UTC),
+					Version: 1,
 				},
 				{
 					Id:      3,
-					Amount:  485,
+					Amount:  48500,
 					Created: time.Date(2023, 4, 12, 8, 33, 12, 0, time.UTC),
+					Version: 1,
 				},
 			},
 			wantErr: false,
@@ -388,10 +450,12 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectQuery(query).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}).
-						AddRow(1, 510, "2023-04-12T8:22:15Z").
-						AddRow(2, 230, "2023-04-12T8:26:43Z").
-						AddRow(3, 485, "2023-04-12T8:33:12Z"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, 510, "2023-04-12T8:22:15Z", nil, nil, nil, 1).
+						AddRow(2, 230, "2023-04-12T8:26:43Z", nil, nil, nil, 1).
+						AddRow(3, 485, "2023-04-12T8:33:12Z", nil, nil, nil, 1))
+				mock.ExpectQuery(expenseSplitsQuery).
+					WillReturnRows(sqlmock.NewRows(expenseSplitColumns))
 				return db, mock
 			},
 		},
@@ -423,7 +487,7 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectQuery(query).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}))
 				return db, mock
 			},
 		},
@@ -438,8 +502,8 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectQuery(query).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}).
-						AddRow(1, "test", "2023-04-12T8:22:15Z"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, "test", "2023-04-12T8:22:15Z", nil, nil, nil, 1))
 				return db, mock
 			},
 		},
@@ -455,8 +519,8 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectQuery(query).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created"}).
-						AddRow(1, 510, "test"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}).
+						AddRow(1, 510, "test", nil, nil, nil, 1))
 				return db, mock
 			},
 		},
@@ -472,11 +536,12 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 			defer db.Close()
 
 			r := &ExpensePostgresAdapter{
-				db:     db,
-				schema: tt.fields.schema,
-				table:  tt.fields.table,
+				db:          db,
+				schema:      tt.fields.schema,
+				table:       tt.fields.table,
+				splitsTable: expenseSplitsTable,
 			}
-			got, err := r.FindAll()
+			got, err := r.FindAll(context.Background(), model.ExpenseQuery{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("expensePostgresRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -491,13 +556,86 @@ func Test_expensePostgresRepository_FindAll(t *testing.T) {
 	}
 }
 
+func Test_expensePostgresRepository_FindAllQuery(t *testing.T) {
+	minAmount, maxAmount := model.Money(1000), model.Money(50000)
+	tests := []struct {
+		name      string
+		query     model.ExpenseQuery
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name: "given an empty query, then select every expense newest first",
+			wantQuery: "SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses " +
+				"WHERE deleted IS NULL ORDER BY created DESC, id DESC",
+			wantArgs: []any{},
+		},
+		{
+			name: "given every filter, then get a parameterized query",
+			query: model.ExpenseQuery{
+				From:       time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:         time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+				MinAmount:  &minAmount,
+				MaxAmount:  &maxAmount,
+				CategoryId: 3,
+				AccountId:  2,
+				SortBy:     model.SortByAmount,
+				Direction:  model.SortAscending,
+				Limit:      21,
+			},
+			wantQuery: "SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses WHERE " +
+				"deleted IS NULL AND created >= TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS') AND " +
+				"created < TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS') AND " +
+				"amount >= $3 AND amount <= $4 AND " +
+				"(category_id = $5 OR id IN (SELECT expense_id FROM test.expense_splits WHERE category_id = $5)) AND " +
+				"account_id = $6 " +
+				"ORDER BY amount ASC, id ASC LIMIT $7",
+			wantArgs: []any{"2023-04-01T00:00:00Z", "2023-05-01T00:00:00Z", "10.00", "500.00", 3, 2, 21},
+		},
+		{
+			name: "given a cursor, then continue after the sorted value and id",
+			query: model.ExpenseQuery{
+				SortBy:    model.SortByCreated,
+				Direction: model.SortDescending,
+				Limit:     11,
+				After:     &model.ExpenseCursor{SortBy: model.SortByCreated, Value: "2023-04-16T00:00:00Z", Id: 2},
+			},
+			wantQuery: "SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses WHERE " +
+				"deleted IS NULL AND (created, id) < (TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $2) " +
+				"ORDER BY created DESC, id DESC LIMIT $3",
+			wantArgs: []any{"2023-04-16T00:00:00Z", 2, 11},
+		},
+		{
+			name: "given a cursor on ids, then compare the id alone",
+			query: model.ExpenseQuery{
+				SortBy:    model.SortById,
+				Direction: model.SortAscending,
+				After:     &model.ExpenseCursor{SortBy: model.SortById, Value: "7", Id: 7},
+			},
+			wantQuery: "SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses " +
+				"WHERE deleted IS NULL AND id > $1 ORDER BY id ASC",
+			wantArgs: []any{7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ExpensePostgresAdapter{schema: expensesSchema, table: expensesTable, splitsTable: expenseSplitsTable}
+			gotQuery, gotArgs := r.findAllQuery(tt.query)
+			if gotQuery != tt.wantQuery {
+				t.Errorf("expensePostgresRepository.findAllQuery() query = %s, want %s", gotQuery, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("expensePostgresRepository.findAllQuery() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
 func Test_expensePostgresRepository_Save(t *testing.T) {
-	querySeq := fmt.
-		Sprintf("[select nextval('%s.%s_id_seq'::regclass)]", expensesSchema, expensesTable)
-	query := fmt.Sprintf("[INSERT "+
-		"INTO %s.%s (id, amount, created) "+
-		"VALUES($1, $2, TO_TIMESTAMP($3, 'YYYY\\-MM\\-DD\"T\"HH24:MI:SS'))]",
-		expensesSchema, expensesTable)
+	query := regexp.QuoteMeta(fmt.Sprintf("INSERT "+
+		"INTO %s.%s (amount, created, category_id, account_id, currency) "+
+		"VALUES($1, TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $3, $4, $5) RETURNING id",
+		expensesSchema, expensesTable))
 	type fields struct {
 		schema string
 		table  string
@@ -514,55 +652,61 @@ func Test_expensePostgresRepository_Save(t *testing.T) {
 		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
 	}{
 		{
-			name: "given an expense, when save with success in database, then get a expense",
+			name: "given an expense, when save with success in database, then get a expense with the new id",
 			fields: fields{
 				schema: expensesSchema,
 				table:  expensesTable,
 			},
 			args: args{
 				e: &model.Expense{
-					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
 				},
 			},
 			want: &model.Expense{
-				Id:      1,
-				Amount:  510,
+				Id:      7,
+				Amount:  51000,
 				Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				Version: 1,
 			},
 			wantErr: false,
 			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
 				db, mock := NewMock()
 
-				mock.ExpectQuery(querySeq).
-					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
-				mock.ExpectExec(query).
-					WithArgs(1, 510.0, "2023-04-12T08:22:15Z").
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
 
 				return db, mock
 			},
 		},
 		{
-			name: "given an expense, when get an error checking next id, then get error",
+			name: "given an expense with category, when save with success in database, then store the category",
 			fields: fields{
 				schema: expensesSchema,
 				table:  expensesTable,
 			},
 			args: args{
 				e: &model.Expense{
-					Id:      1,
-					Amount:  510,
-					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Amount:     51000,
+					Created:    time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					CategoryId: 3,
 				},
 			},
-			wantErr: true,
+			want: &model.Expense{
+				Id:         7,
+				Amount:     51000,
+				Created:    time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				CategoryId: 3,
+				Version:    1,
+			},
+			wantErr: false,
 			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
 				db, mock := NewMock()
 
-				mock.ExpectQuery(querySeq).
-					WillReturnError(errors.ErrUnsupported)
+				mock.ExpectQuery(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", 3, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
 
 				return db, mock
 			},
@@ -575,8 +719,7 @@ func Test_expensePostgresRepository_Save(t *testing.T) {
 			},
 			args: args{
 				e: &model.Expense{
-					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
 				},
 			},
@@ -584,25 +727,22 @@ func Test_expensePostgresRepository_Save(t *testing.T) {
 			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
 				db, mock := NewMock()
 
-				mock.ExpectQuery(querySeq).
-					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
-				mock.ExpectExec(query).
-					WithArgs(1, 510.0, "2023-04-12T08:22:15Z").
+				mock.ExpectQuery(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil).
 					WillReturnError(errors.ErrUnsupported)
 
 				return db, mock
 			},
 		},
 		{
-			name: "given an expense, when get error on reading operation result, then get error",
+			name: "given an expense, when the insert returns no id, then get error",
 			fields: fields{
 				schema: expensesSchema,
 				table:  expensesTable,
 			},
 			args: args{
 				e: &model.Expense{
-					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
 				},
 			},
@@ -610,37 +750,9 @@ func Test_expensePostgresRepository_Save(t *testing.T) {
 			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
 				db, mock := NewMock()
 
-				mock.ExpectQuery(querySeq).
-					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
-				mock.ExpectExec(query).
-					WithArgs(1, 510.0, "2023-04-12T08:22:15Z").
-					WillReturnResult(sqlmock.NewErrorResult(errors.ErrUnsupported))
-
-				return db, mock
-			},
-		},
-		{
-			name: "given an expense, when get 0 rows affected, then get error",
-			fields: fields{
-				schema: expensesSchema,
-				table:  expensesTable,
-			},
-			args: args{
-				e: &model.Expense{
-					Id:      1,
-					Amount:  510,
-					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
-				},
-			},
-			wantErr: true,
-			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
-				db, mock := NewMock()
-
-				mock.ExpectQuery(querySeq).
-					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
-				mock.ExpectExec(query).
-					WithArgs(1, 510.0, "2023-04-12T08:22:15Z").
-					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				return db, mock
 			},
@@ -657,11 +769,12 @@ func Test_expensePostgresRepository_Save(t *testing.T) {
 			defer db.Close()
 
 			r := &ExpensePostgresAdapter{
-				db:     db,
-				schema: tt.fields.schema,
-				table:  tt.fields.table,
+				db:          db,
+				schema:      tt.fields.schema,
+				table:       tt.fields.table,
+				splitsTable: expenseSplitsTable,
 			}
-			got, err := r.Save(tt.args.e)
+			got, err := r.Save(context.Background(), tt.args.e)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("expensePostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -678,7 +791,8 @@ func Test_expensePostgresRepository_Save(t *testing.T) {
 
 func Test_expensePostgresRepository_Update(t *testing.T) {
 	query := fmt.Sprintf("[UPDATE %s.%s SET amount=$1, "+
-		"created=TO_TIMESTAMP($2, 'YYYY\\-MM\\-DD\"T\"HH24:MI:SS') WHERE id=$3]",
+		"created=TO_TIMESTAMP($2, 'YYYY\\-MM\\-DD\"T\"HH24:MI:SS'), category_id=$3, account_id=$4, "+
+		"currency=$5, version=version\\+1 WHERE id=$6 AND version=$7]",
 		expensesSchema, expensesTable)
 	type fields struct {
 		schema string
@@ -688,12 +802,13 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 		e *model.Expense
 	}
 	tests := []struct {
-		name          string
-		fields        fields
-		args          args
-		want          *model.Expense
-		wantErr       bool
-		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+		name           string
+		fields         fields
+		args           args
+		want           *model.Expense
+		wantErr        bool
+		wantConcurrent bool
+		configSqlMock  func() (*sql.DB, sqlmock.Sqlmock)
 	}{
 		{
 			name: "given a expense to update, when update with success, then get an expense",
@@ -704,22 +819,27 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 			args: args{
 				e: &model.Expense{
 					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 1,
 				},
 			},
 			want: &model.Expense{
 				Id:      1,
-				Amount:  510,
+				Amount:  51000,
 				Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				Version: 2,
 			},
 			wantErr: false,
 			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(510.0, "2023-04-12T08:22:15Z", 1).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil, 1, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM test.expense_splits WHERE expense_id=$1")).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
 
 				return db, mock
 			},
@@ -733,8 +853,9 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 			args: args{
 				e: &model.Expense{
 					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 1,
 				},
 			},
 			wantErr: true,
@@ -742,7 +863,7 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(510.0, "2023-04-12T08:22:15Z", 1).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil, 1, 1).
 					WillReturnError(errors.ErrUnsupported)
 
 				return db, mock
@@ -757,8 +878,9 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 			args: args{
 				e: &model.Expense{
 					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 1,
 				},
 			},
 			wantErr: true,
@@ -766,7 +888,7 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(510.0, "2023-04-12T08:22:15Z", 1).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil, 1, 1).
 					WillReturnResult(sqlmock.NewErrorResult(errors.ErrUnsupported))
 
 				return db, mock
@@ -781,8 +903,9 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 			args: args{
 				e: &model.Expense{
 					Id:      1,
-					Amount:  510,
+					Amount:  51000,
 					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 1,
 				},
 			},
 			wantErr: true,
@@ -790,8 +913,40 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(510.0, "2023-04-12T08:22:15Z", 1).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil, 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("select count(t.id) from test.expenses t where t.id = $1")).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an expense to update, when its version is stale, then get a concurrent modification error",
+			fields: fields{
+				schema: expensesSchema,
+				table:  expensesTable,
+			},
+			args: args{
+				e: &model.Expense{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 1,
+				},
+			},
+			wantErr:        true,
+			wantConcurrent: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, nil, 1, 1).
 					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("select count(t.id) from test.expenses t where t.id = $1")).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 				return db, mock
 			},
@@ -808,15 +963,20 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 			defer db.Close()
 
 			r := &ExpensePostgresAdapter{
-				db:     db,
-				schema: tt.fields.schema,
-				table:  tt.fields.table,
+				db:          db,
+				schema:      tt.fields.schema,
+				table:       tt.fields.table,
+				splitsTable: expenseSplitsTable,
 			}
-			got, err := r.Update(tt.args.e)
+			got, err := r.Update(context.Background(), tt.args.e)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("expensePostgresRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			var concurrent *customErrors.ConcurrentModificationError
+			if errors.As(err, &concurrent) != tt.wantConcurrent {
+				t.Errorf("expensePostgresRepository.Update() error = %v, wantConcurrent %v", err, tt.wantConcurrent)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("expensePostgresRepository.Update() = %v, want %v", got, tt.want)
 			}
@@ -828,7 +988,8 @@ func Test_expensePostgresRepository_Update(t *testing.T) {
 }
 
 func Test_expensePostgresRepository_Delete(t *testing.T) {
-	query := fmt.Sprintf("[DELETE fROM %s.%s WHERE id=$1]", expensesSchema, expensesTable)
+	query := regexp.QuoteMeta("UPDATE test.expenses SET deleted=TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS') " +
+		"WHERE id=$2 AND deleted IS NULL")
 	type fields struct {
 		schema string
 		table  string
@@ -858,7 +1019,7 @@ func Test_expensePostgresRepository_Delete(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(1).
+					WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				return db, mock
@@ -878,7 +1039,7 @@ func Test_expensePostgresRepository_Delete(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(1).
+					WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnError(errors.ErrUnsupported)
 
 				return db, mock
@@ -899,7 +1060,7 @@ func Test_expensePostgresRepository_Delete(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(1).
+					WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnResult(sqlmock.NewErrorResult(errors.ErrUnsupported))
 
 				return db, mock
@@ -920,7 +1081,7 @@ func Test_expensePostgresRepository_Delete(t *testing.T) {
 				db, mock := NewMock()
 
 				mock.ExpectExec(query).
-					WithArgs(1).
+					WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 
 				return db, mock
@@ -938,11 +1099,12 @@ func Test_expensePostgresRepository_Delete(t *testing.T) {
 			defer db.Close()
 
 			r := &ExpensePostgresAdapter{
-				db:     db,
-				schema: tt.fields.schema,
-				table:  tt.fields.table,
+				db:          db,
+				schema:      tt.fields.schema,
+				table:       tt.fields.table,
+				splitsTable: expenseSplitsTable,
 			}
-			if err := r.Delete(tt.args.id); (err != nil) != tt.wantErr {
+			if err := r.Delete(context.Background(), tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("expensePostgresRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if err := mock.ExpectationsWereMet(); err != nil {
@@ -951,3 +1113,303 @@ func Test_expensePostgresRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_expensePostgresRepository_FindDeleted(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, amount, created, category_id, account_id, currency, version, deleted FROM test.expenses " +
+		"WHERE deleted IS NOT NULL ORDER BY deleted DESC, id DESC")
+	deleted := time.Date(2023, 4, 20, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "amount", "created", "category_id", "account_id", "currency", "version", "deleted"}
+	tests := []struct {
+		name          string
+		want          []model.Expense
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given deleted expenses, then get them with their deletion time",
+			want: []model.Expense{
+				{
+					Id:      3,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+					Version: 2,
+					Deleted: &deleted,
+				},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, 510, "2023-04-12T08:22:15Z", nil, nil, nil, 2, "2023-04-20T09:00:00Z"))
+				mock.ExpectQuery(expenseSplitsQuery).
+					WillReturnRows(sqlmock.NewRows(expenseSplitColumns))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an invalid deleted date, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, 510, "2023-04-12T08:22:15Z", nil, nil, nil, 2, "test"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &ExpensePostgresAdapter{db: db, schema: expensesSchema, table: expensesTable, splitsTable: expenseSplitsTable}
+			got, err := r.FindDeleted(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expensePostgresRepository.FindDeleted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expensePostgresRepository.FindDeleted() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_expensePostgresRepository_Restore(t *testing.T) {
+	query := regexp.QuoteMeta("UPDATE test.expenses SET deleted=NULL WHERE id=$1 AND deleted IS NOT NULL")
+	tests := []struct {
+		name         string
+		result       driver.Result
+		err          error
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:   "given a deleted expense, then restore it",
+			result: sqlmock.NewResult(0, 1),
+		},
+		{
+			name:         "given an expense out of the trash, then get item not found",
+			result:       sqlmock.NewResult(0, 0),
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:    "given a database error, then get error",
+			err:     errors.ErrUnsupported,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := NewMock()
+			defer db.Close()
+			expected := mock.ExpectExec(query).WithArgs(3)
+			if tt.err != nil {
+				expected.WillReturnError(tt.err)
+			} else {
+				expected.WillReturnResult(tt.result)
+			}
+
+			r := &ExpensePostgresAdapter{db: db, schema: expensesSchema, table: expensesTable, splitsTable: expenseSplitsTable}
+			err := r.Restore(context.Background(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expensePostgresRepository.Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var notFound *customErrors.ItemNotFound
+			if errors.As(err, &notFound) != tt.wantNotFound {
+				t.Errorf("expensePostgresRepository.Restore() error = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_expensePostgresRepository_Purge(t *testing.T) {
+	query := regexp.QuoteMeta("DELETE FROM test.expenses WHERE deleted IS NOT NULL " +
+		"AND deleted < TO_TIMESTAMP($1, 'YYYY-MM-DD\"T\"HH24:MI:SS')")
+	before := time.Date(2023, 4, 20, 4, 0, 0, 0, time.FixedZone("COT", -5*3600))
+	tests := []struct {
+		name    string
+		result  driver.Result
+		err     error
+		want    int
+		wantErr bool
+	}{
+		{
+			name:   "given a time, then purge the expenses deleted before it",
+			result: sqlmock.NewResult(0, 4),
+			want:   4,
+		},
+		{
+			name:    "given a database error, then get error",
+			err:     errors.ErrUnsupported,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := NewMock()
+			defer db.Close()
+			expected := mock.ExpectExec(query).WithArgs("2023-04-20T09:00:00Z")
+			if tt.err != nil {
+				expected.WillReturnError(tt.err)
+			} else {
+				expected.WillReturnResult(tt.result)
+			}
+
+			r := &ExpensePostgresAdapter{db: db, schema: expensesSchema, table: expensesTable, splitsTable: expenseSplitsTable}
+			got, err := r.Purge(context.Background(), before)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expensePostgresRepository.Purge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expensePostgresRepository.Purge() = %d, want %d", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_expensePostgresRepository_QueryTimeout(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+
+	mock.ExpectQuery("select count[(]t.id[)] from test.expenses t where t.id = [$]1").
+		WithArgs(1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	r := &ExpensePostgresAdapter{
+		db:          db,
+		schema:      expensesSchema,
+		table:       expensesTable,
+		splitsTable: expenseSplitsTable,
+		timeout:     10 * time.Millisecond,
+	}
+	if _, err := r.Exists(context.Background(), 1); err == nil {
+		t.Errorf("expensePostgresRepository.Exists() error = nil, want a timeout error")
+	}
+}
+
+func Test_expensePostgresRepository_CancelledContext(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}))
+
+	r := &ExpensePostgresAdapter{
+		db:          db,
+		schema:      expensesSchema,
+		table:       expensesTable,
+		splitsTable: expenseSplitsTable,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.FindAll(ctx, model.ExpenseQuery{}); err == nil {
+		t.Errorf("expensePostgresRepository.FindAll() error = nil, want a cancellation error")
+	}
+}
+
+func Test_expensePostgresRepository_Splits(t *testing.T) {
+	insert := regexp.QuoteMeta("INSERT INTO test.expense_splits (expense_id, position, category_id, amount, note) " +
+		"SELECT $1, s.position, NULLIF(s.category_id, 0), s.amount, s.note " +
+		"FROM UNNEST(CAST($2 AS INTEGER[]), CAST($3 AS NUMERIC[]), CAST($4 AS VARCHAR[])) " +
+		"WITH ORDINALITY AS s(category_id, amount, note, position)")
+	columns := []string{"id", "amount", "created", "category_id", "account_id", "currency", "version"}
+	splits := []model.ExpenseSplit{
+		{CategoryId: 2, Amount: 3000, Note: "groceries"},
+		{Amount: 1500},
+	}
+	newAdapter := func(db *sql.DB) *ExpensePostgresAdapter {
+		return &ExpensePostgresAdapter{db: db, schema: expensesSchema, table: expensesTable, splitsTable: expenseSplitsTable}
+	}
+
+	t.Run("given an expense with splits, then save them in order", func(t *testing.T) {
+		db, mock := NewMock()
+		defer db.Close()
+		mock.ExpectQuery("INSERT INTO test.expenses").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectExec(insert).
+			WithArgs(5, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		e := &model.Expense{Amount: 4500, Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC), Splits: splits}
+		if got, err := newAdapter(db).Save(context.Background(), e); err != nil || got.Id != 5 {
+			t.Errorf("expensePostgresRepository.Save() = %v, %v, want id 5", got, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expections: %s", err)
+		}
+	})
+
+	t.Run("given the splits fail to save, then get error", func(t *testing.T) {
+		db, mock := NewMock()
+		defer db.Close()
+		mock.ExpectQuery("INSERT INTO test.expenses").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectExec(insert).WillReturnError(errors.ErrUnsupported)
+
+		e := &model.Expense{Amount: 4500, Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC), Splits: splits}
+		if _, err := newAdapter(db).Save(context.Background(), e); err == nil {
+			t.Errorf("expensePostgresRepository.Save() error = nil, want an error")
+		}
+	})
+
+	t.Run("given expenses with splits, then find them along with their expense", func(t *testing.T) {
+		db, mock := NewMock()
+		defer db.Close()
+		mock.ExpectQuery("SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, 45, "2023-04-12T8:22:15Z", nil, nil, nil, 1).
+				AddRow(2, 10, "2023-04-12T8:26:43Z", 3, nil, nil, 1))
+		mock.ExpectQuery(expenseSplitsQuery).
+			WillReturnRows(sqlmock.NewRows(expenseSplitColumns).
+				AddRow(1, 2, "30.00", "groceries").
+				AddRow(1, nil, "15.00", ""))
+
+		want := []model.Expense{
+			{Id: 1, Amount: 4500, Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC), Version: 1, Splits: splits},
+			{Id: 2, Amount: 1000, Created: time.Date(2023, 4, 12, 8, 26, 43, 0, time.UTC), CategoryId: 3, Version: 1},
+		}
+		got, err := newAdapter(db).FindAll(context.Background(), model.ExpenseQuery{})
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("expensePostgresRepository.FindAll() = %v, %v, want %v", got, err, want)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expections: %s", err)
+		}
+	})
+
+	t.Run("given an invalid split amount, then get error", func(t *testing.T) {
+		db, mock := NewMock()
+		defer db.Close()
+		mock.ExpectQuery("SELECT id, amount, created, category_id, account_id, currency, version FROM test.expenses").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 45, "2023-04-12T8:22:15Z", nil, nil, nil, 1))
+		mock.ExpectQuery(expenseSplitsQuery).
+			WillReturnRows(sqlmock.NewRows(expenseSplitColumns).AddRow(1, 2, "test", ""))
+
+		if _, err := newAdapter(db).FindAll(context.Background(), model.ExpenseQuery{}); err == nil {
+			t.Errorf("expensePostgresRepository.FindAll() error = nil, want an error")
+		}
+	})
+}
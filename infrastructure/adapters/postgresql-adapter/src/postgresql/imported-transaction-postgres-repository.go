@@ -0,0 +1,65 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	importedTransactionsTable = "imported_transactions"
+)
+
+type ImportedTransactionPostgresAdapter struct {
+	db      executor
+	schema  string
+	table   string
+	timeout time.Duration
+}
+
+func NewImportedTransactionPostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.ImportedTransactionRepository {
+	return &ImportedTransactionPostgresAdapter{
+		db:      db,
+		schema:  prop.Schema,
+		table:   importedTransactionsTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *ImportedTransactionPostgresAdapter) Exists(ctx context.Context, account, externalId string) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s.%s t WHERE t.account = $1 AND t.external_id = $2",
+		r.schema, r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, account, externalId).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for imported transaction... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *ImportedTransactionPostgresAdapter) Save(ctx context.Context, t *model.ImportedTransaction) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (account, external_id, kind, record_id) VALUES($1, $2, $3, $4)",
+		r.schema, r.table)
+
+	if _, err := r.db.ExecContext(ctx, query, t.Account, t.ExternalId, string(t.Kind), t.Id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return errors.Join(fmt.Errorf("error: saving imported transaction... "), err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func Test_importedTransactionPostgresRepository_Exists(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT COUNT(t.id) FROM test.imported_transactions t " +
+		"WHERE t.account = $1 AND t.external_id = $2")
+	tests := []struct {
+		name          string
+		want          bool
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an imported transaction, then get true",
+			want: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs("1234", "20230401001").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &ImportedTransactionPostgresAdapter{db: db, schema: expensesSchema, table: importedTransactionsTable}
+			got, err := r.Exists(context.Background(), "1234", "20230401001")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("importedTransactionPostgresRepository.Exists() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("importedTransactionPostgresRepository.Exists() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_importedTransactionPostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.imported_transactions (account, external_id, kind, record_id) " +
+		"VALUES($1, $2, $3, $4)")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a transaction, then link it to its record",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs("1234", "20230401001", "expense", 7).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a transaction already imported, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &ImportedTransactionPostgresAdapter{db: db, schema: expensesSchema, table: importedTransactionsTable}
+			err := r.Save(context.Background(), &model.ImportedTransaction{
+				Kind: model.KindExpense, Account: "1234", ExternalId: "20230401001", Id: 7,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("importedTransactionPostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	incomesTable = "incomes"
+)
+
+type IncomePostgresAdapter struct {
+	db      executor
+	schema  string
+	table   string
+	timeout time.Duration
+}
+
+func NewIncomePostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.IncomeRepository {
+	return &IncomePostgresAdapter{
+		db:      db,
+		schema:  prop.Schema,
+		table:   incomesTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *IncomePostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("select count(t.id) from %s.%s t where t.id = $1", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for income... "), err)
+	}
+	var count int
+	if res.Next() {
+		if err = res.Scan(&count); err != nil {
+			log.Println("error: error reading result... ", err)
+			return false, errors.Join(fmt.Errorf("error: error reading exist result... "), err)
+		}
+	}
+
+	return count > 0, nil
+}
+
+func (r *IncomePostgresAdapter) FindByID(ctx context.Context, id int) (*model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, account_id, currency FROM %s.%s "+
+		"WHERE id = $1", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for income... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		var retId int
+		var rawAmount string
+		var createdDate string
+		var accountId sql.NullInt64
+		var currency sql.NullString
+		err = res.Scan(&retId, &rawAmount, &createdDate, &accountId, &currency)
+		if err != nil {
+			log.Println("error: error building income item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building income item... "), err)
+		}
+		amount, err := model.ParseMoney(rawAmount)
+		if err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
+		date, err := time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			log.Println("error: error parsing created date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+		}
+		return &model.Income{
+			Id:        retId,
+			Amount:    amount,
+			Created:   date,
+			AccountId: idFromNullable(accountId),
+			Currency:  currency.String,
+		}, nil
+	}
+
+	return nil, customErrors.NewItemNotFoundError("income")
+}
+
+func (r *IncomePostgresAdapter) FindAll(ctx context.Context) ([]model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, account_id, currency FROM %s.%s", r.schema, r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for incomes... "), err)
+	}
+
+	incomes := []model.Income{}
+
+	defer res.Close()
+	for res.Next() {
+		var retId int
+		var rawAmount string
+		var createdDate string
+		var accountId sql.NullInt64
+		var currency sql.NullString
+		err = res.Scan(&retId, &rawAmount, &createdDate, &accountId, &currency)
+		if err != nil {
+			log.Println("error: error building income item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building income item... "), err)
+		}
+		amount, err := model.ParseMoney(rawAmount)
+		if err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
+		date, err := time.Parse(time.RFC3339, createdDate)
+		if err != nil {
+			log.Println("error: error parsing created date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+		}
+		incomes = append(incomes, model.Income{
+			Id:        retId,
+			Amount:    amount,
+			Created:   date,
+			AccountId: idFromNullable(accountId),
+			Currency:  currency.String,
+		})
+	}
+
+	return incomes, nil
+}
+
+func (r *IncomePostgresAdapter) Save(ctx context.Context, e *model.Income) (*model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var nextVal int
+	err := r.db.
+		QueryRowContext(ctx, fmt.Sprintf("select nextval('%s.%s_id_seq'::regclass)", r.schema, r.table)).
+		Scan(&nextVal)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("INSERT "+
+		"INTO %s.%s (id, amount, created, account_id, currency) "+
+		"VALUES($1, $2, TO_TIMESTAMP($3, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $4, $5)",
+		r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, nextVal, e.Amount.String(), e.Created.Format(time.RFC3339),
+		nullableID(e.AccountId), nullableString(e.Currency))
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving income... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading save result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown save operation result... "), err)
+		}
+		log.Printf("error: error executing save query... %d items inserted\n", nr)
+		return nil, fmt.Errorf("error: 0 items inserted on operation... ")
+	}
+	e.Id = nextVal
+	return e, nil
+}
+
+func (r *IncomePostgresAdapter) Update(ctx context.Context, e *model.Income) (*model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET amount=$1, "+
+		"created=TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS'), account_id=$3, currency=$4 "+
+		"WHERE id=$5", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, e.Amount.String(), e.Created.Format(time.RFC3339),
+		nullableID(e.AccountId), nullableString(e.Currency), e.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating income... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return e, nil
+}
+
+func (r *IncomePostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id=$1", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting income... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
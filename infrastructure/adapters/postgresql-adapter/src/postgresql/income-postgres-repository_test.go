@@ -0,0 +1,945 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const (
+	incomesSchema = "test"
+)
+
+func TestNewIncomePostgresAdapter(t *testing.T) {
+	db, _ := NewMock()
+	defer db.Close()
+
+	type args struct {
+		prop postgresconfig.PostgreSqlConnectionProperties
+		db   *sql.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want port.IncomeRepository
+	}{
+		{
+			name: "given properties and database connection, then get a success repository instance",
+			args: args{
+				prop: postgresconfig.PostgreSqlConnectionProperties{Schema: "test"},
+				db:   db,
+			},
+			want: &IncomePostgresAdapter{
+				db:     db,
+				schema: "test",
+				table:  incomesTable,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIncomePostgresAdapter(tt.args.prop, tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewIncomePostgresAdapter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_incomePostgresRepository_Exists(t *testing.T) {
+	query := fmt.Sprintf("[select count(t.id) from %s.%s t where t.id = $1]",
+		incomesSchema, incomesTable)
+	type fields struct {
+		schema string
+		table  string
+	}
+	type args struct {
+		id int
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		want          bool
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, when the income exists in database, then return true",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			want: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when the income doesn't exists in database, then return false",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			want: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when check if the income exists in database, then return error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			want:    false,
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.ErrUnsupported)
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when check if the income exists in database " +
+				"and get an invalid result, then return error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			want:    false,
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow("test"))
+
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configSqlMock == nil {
+				t.Errorf("mock function is not configured")
+				return
+			}
+
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &IncomePostgresAdapter{
+				db:     db,
+				schema: tt.fields.schema,
+				table:  tt.fields.table,
+			}
+			got, err := r.Exists(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("incomePostgresRepository.Exists() error = %v, wantErr %v",
+					err, tt.wantErr)
+			}
+			if (got != tt.want) && !tt.wantErr {
+				t.Errorf("incomePostgresRepository.Exists() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_incomePostgresRepository_FindByID(t *testing.T) {
+	query := fmt.Sprintf("[SELECT id, amount, created, account_id, currency FROM %s.%s WHERE id = $1]",
+		incomesSchema, incomesTable)
+	type fields struct {
+		schema string
+		table  string
+	}
+	type args struct {
+		id int
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		want          *model.Income
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, then get a success income response",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			want: &model.Income{
+				Id:      1,
+				Amount:  15000,
+				Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+			},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}).
+						AddRow(1, 150, "2023-04-12T8:22:15Z", nil, nil))
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when an error occur in database, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when get invalid values, then get an error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}).
+						AddRow(1, "test", "2023-04-12T8:22:15Z", nil, nil))
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when get an invalid created field, then get an error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}).
+						AddRow(1, 150, "test", nil, nil))
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when income is not found, then get an error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configSqlMock == nil {
+				t.Errorf("mock function is not configured")
+				return
+			}
+
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &IncomePostgresAdapter{
+				db:     db,
+				schema: tt.fields.schema,
+				table:  tt.fields.table,
+			}
+			got, err := r.FindByID(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("incomePostgresRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("incomePostgresRepository.FindByID() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_incomePostgresRepository_FindAll(t *testing.T) {
+	query := fmt.Sprintf("SELECT id, amount, created, account_id, currency FROM %s.%s", incomesSchema, incomesTable)
+	type fields struct {
+		schema string
+		table  string
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		want          []model.Income
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an get all incomes request, then get all incomes",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			want: []model.Income{
+				{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+				{
+					Id:      2,
+					Amount:  23000,
+					Created: time.Date(2023, 4, 12, 8, 26, 43, 0, time.UTC),
+				},
+				{
+					Id:      3,
+					Amount:  48500,
+					Created: time.Date(2023, 4, 12, 8, 33, 12, 0, time.UTC),
+				},
+			},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}).
+						AddRow(1, 510, "2023-04-12T8:22:15Z", nil, nil).
+						AddRow(2, 230, "2023-04-12T8:26:43Z", nil, nil).
+						AddRow(3, 485, "2023-04-12T8:33:12Z", nil, nil))
+				return db, mock
+			},
+		},
+		{
+			name: "given an get all incomes request, when get a database error, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+		{
+			name: "given an get all incomes request, when incomes database is empty, " +
+				"get a empty response",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			want:    []model.Income{},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}))
+				return db, mock
+			},
+		},
+		{
+			name: "given an get all incomes request, when get an invalid value, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}).
+						AddRow(1, "test", "2023-04-12T8:22:15Z", nil, nil))
+				return db, mock
+			},
+		},
+		{
+			name: "given an get all incomes request, when get an invalid created date, " +
+				"then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "created", "account_id", "currency"}).
+						AddRow(1, 510, "test", nil, nil))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configSqlMock == nil {
+				t.Errorf("mock function is not configured")
+				return
+			}
+
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &IncomePostgresAdapter{
+				db:     db,
+				schema: tt.fields.schema,
+				table:  tt.fields.table,
+			}
+			got, err := r.FindAll(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("incomePostgresRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("incomePostgresRepository.FindAll() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_incomePostgresRepository_Save(t *testing.T) {
+	querySeq := fmt.
+		Sprintf("[select nextval('%s.%s_id_seq'::regclass)]", incomesSchema, incomesTable)
+	query := fmt.Sprintf("[INSERT "+
+		"INTO %s.%s (id, amount, created, account_id, currency) "+
+		"VALUES($1, $2, TO_TIMESTAMP($3, 'YYYY\\-MM\\-DD\"T\"HH24:MI:SS'), $4, $5)]",
+		incomesSchema, incomesTable)
+	type fields struct {
+		schema string
+		table  string
+	}
+	type args struct {
+		e *model.Income
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		want          *model.Income
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an income, when save with success in database, then get an income",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			want: &model.Income{
+				Id:      1,
+				Amount:  51000,
+				Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+			},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(querySeq).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+				mock.ExpectExec(query).
+					WithArgs(1, "510.00", "2023-04-12T08:22:15Z", nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income, when get an error checking next id, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(querySeq).
+					WillReturnError(errors.ErrUnsupported)
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income, when there is an error executing in database, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(querySeq).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+				mock.ExpectExec(query).
+					WithArgs(1, "510.00", "2023-04-12T08:22:15Z", nil, nil).
+					WillReturnError(errors.ErrUnsupported)
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income, when get error on reading operation result, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(querySeq).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+				mock.ExpectExec(query).
+					WithArgs(1, "510.00", "2023-04-12T08:22:15Z", nil, nil).
+					WillReturnResult(sqlmock.NewErrorResult(errors.ErrUnsupported))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income, when get 0 rows affected, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectQuery(querySeq).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+				mock.ExpectExec(query).
+					WithArgs(1, "510.00", "2023-04-12T08:22:15Z", nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configSqlMock == nil {
+				t.Errorf("mock function is not configured")
+				return
+			}
+
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &IncomePostgresAdapter{
+				db:     db,
+				schema: tt.fields.schema,
+				table:  tt.fields.table,
+			}
+			got, err := r.Save(context.Background(), tt.args.e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("incomePostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("incomePostgresRepository.Save() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_incomePostgresRepository_Update(t *testing.T) {
+	query := fmt.Sprintf("[UPDATE %s.%s SET amount=$1, "+
+		"created=TO_TIMESTAMP($2, 'YYYY\\-MM\\-DD\"T\"HH24:MI:SS'), account_id=$3, currency=$4 "+
+		"WHERE id=$5]",
+		incomesSchema, incomesTable)
+	type fields struct {
+		schema string
+		table  string
+	}
+	type args struct {
+		e *model.Income
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		want          *model.Income
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an income to update, when update with success, then get an income",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			want: &model.Income{
+				Id:      1,
+				Amount:  51000,
+				Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+			},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income to update, when there is an error in database, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, 1).
+					WillReturnError(errors.ErrUnsupported)
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income to update, when there is an error result, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, 1).
+					WillReturnResult(sqlmock.NewErrorResult(errors.ErrUnsupported))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an income to update, when get 0 rows affected, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				e: &model.Income{
+					Id:      1,
+					Amount:  51000,
+					Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC),
+				},
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs("510.00", "2023-04-12T08:22:15Z", nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configSqlMock == nil {
+				t.Errorf("mock function is not configured")
+				return
+			}
+
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &IncomePostgresAdapter{
+				db:     db,
+				schema: tt.fields.schema,
+				table:  tt.fields.table,
+			}
+			got, err := r.Update(context.Background(), tt.args.e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("incomePostgresRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("incomePostgresRepository.Update() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_incomePostgresRepository_Delete(t *testing.T) {
+	query := fmt.Sprintf("[DELETE FROM %s.%s WHERE id=$1]", incomesSchema, incomesTable)
+	type fields struct {
+		schema string
+		table  string
+	}
+	type args struct {
+		id int
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an id, when tries to delete income, " +
+				"then delete with success and get nil error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: false,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when tries to delete income an get database error, then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs(1).
+					WillReturnError(errors.ErrUnsupported)
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when tries to delete income and get error reading result," +
+				" then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewErrorResult(errors.ErrUnsupported))
+
+				return db, mock
+			},
+		},
+		{
+			name: "given an id, when tries to delete income and fail with 0 rows affected" +
+				" then get error",
+			fields: fields{
+				schema: incomesSchema,
+				table:  incomesTable,
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+
+				mock.ExpectExec(query).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.configSqlMock == nil {
+				t.Errorf("mock function is not configured")
+				return
+			}
+
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			r := &IncomePostgresAdapter{
+				db:     db,
+				schema: tt.fields.schema,
+				table:  tt.fields.table,
+			}
+			if err := r.Delete(context.Background(), tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("incomePostgresRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	migrationsTable = "schema_migrations"
+	// schemaPlaceholder is replaced by the configured schema in every script.
+	schemaPlaceholder = "${schema}"
+	// currencyPlaceholder is replaced by the reporting currency, which rows
+	// created before currencies existed are in.
+	currencyPlaceholder = "${currency}"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+// fileName matches scripts named <version>_<name>.<up|down>.sql.
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9-]+)\.(up|down)\.sql$`)
+
+// currencyCode matches the ISO 4217 codes a script can be given.
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Migration is one versioned schema change with the scripts that apply and
+// revert it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Migrator applies the embedded migrations in version order and records each
+// applied version in the schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	schema     string
+	currency   string
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator of the configured schema. currency is the
+// reporting currency, given to the scripts that fill in the currency of
+// older rows.
+func NewMigrator(prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB, currency string) (*Migrator, error) {
+	sqlFiles, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(db, prop.Schema, currency, sqlFiles)
+}
+
+func newMigrator(db *sql.DB, schema, currency string, files fs.FS) (*Migrator, error) {
+	if !currencyCode.MatchString(currency) {
+		return nil, fmt.Errorf("error: currency %q is not an ISO 4217 code... ", currency)
+	}
+	migrations, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, schema: schema, currency: currency, migrations: migrations}, nil
+}
+
+// Load reads the migration scripts found at the root of files. Every version
+// needs both an up and a down script, and versions can't be repeated.
+func Load(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		parts := fileName.FindStringSubmatch(path.Base(name))
+		if parts == nil {
+			return nil, fmt.Errorf("error: invalid migration file name %s... ", name)
+		}
+		version, _ := strconv.Atoi(parts[1])
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("error: reading migration %s... ", name), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != parts[2] {
+			return nil, fmt.Errorf("error: migration version %d is repeated... ", version)
+		}
+		if parts[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("error: migration %d needs both up and down scripts... ", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (m *Migrator) Up() (int, error) {
+	applied := 0
+	err := m.locked(func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if versions[migration.Version] {
+				continue
+			}
+			log.Printf("info: applying migration %04d_%s... \n", migration.Version, migration.Name)
+			insert := fmt.Sprintf("INSERT INTO %s.%s (version, name) VALUES($1, $2)",
+				m.schema, migrationsTable)
+			err := m.inTx(conn, migration.Up, insert, migration.Version, migration.Name)
+			if err != nil {
+				return errors.Join(fmt.Errorf("error: applying migration %d... ", migration.Version), err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations and returns how many were
+// reverted.
+func (m *Migrator) Down(steps int) (int, error) {
+	reverted := 0
+	err := m.locked(func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if !versions[migration.Version] {
+				continue
+			}
+			log.Printf("info: reverting migration %04d_%s... \n", migration.Version, migration.Name)
+			remove := fmt.Sprintf("DELETE FROM %s.%s WHERE version=$1", m.schema, migrationsTable)
+			if err := m.inTx(conn, migration.Down, remove, migration.Version); err != nil {
+				return errors.Join(fmt.Errorf("error: reverting migration %d... ", migration.Version), err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Version returns the highest applied migration version, zero if none. It
+// only reads: a schema without the schema_migrations table is at version 0.
+func (m *Migrator) Version() (int, error) {
+	ctx := context.Background()
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2)"
+	if err := m.db.QueryRowContext(ctx, query, m.schema, migrationsTable).Scan(&exists); err != nil {
+		log.Println("error: error executing select query... ", err)
+		return 0, errors.Join(fmt.Errorf("error: error searching for applied migrations... "), err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	query = fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s.%s", m.schema, migrationsTable)
+	if err := m.db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		log.Println("error: error executing select query... ", err)
+		return 0, errors.Join(fmt.Errorf("error: error searching for applied migrations... "), err)
+	}
+	return version, nil
+}
+
+// locked runs fn on a single connection holding a Postgres advisory lock, so
+// concurrent instances wait for each other instead of migrating twice.
+func (m *Migrator) locked(fn func(conn *sql.Conn) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		log.Println("error: error getting migrations connection... ", err)
+		return errors.Join(fmt.Errorf("error: error getting migrations connection... "), err)
+	}
+	defer conn.Close()
+
+	key := m.lockKey()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		log.Println("error: error acquiring migrations lock... ", err)
+		return errors.Join(fmt.Errorf("error: error acquiring migrations lock... "), err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Println("error: error releasing migrations lock... ", err)
+		}
+	}()
+
+	return fn(conn)
+}
+
+func (m *Migrator) appliedVersions(conn *sql.Conn) (map[int]bool, error) {
+	ctx := context.Background()
+	if err := m.checkOwnership(conn); err != nil {
+		return nil, err
+	}
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s ("+
+		"version INTEGER PRIMARY KEY NOT NULL, "+
+		"name VARCHAR(100) NOT NULL, "+
+		"applied TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)", m.schema, migrationsTable)
+	if _, err := conn.ExecContext(ctx, create); err != nil {
+		log.Println("error: error creating migrations table... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error creating migrations table... "), err)
+	}
+
+	query := fmt.Sprintf("SELECT version FROM %s.%s ORDER BY version", m.schema, migrationsTable)
+	res, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for applied migrations... "), err)
+	}
+
+	versions := map[int]bool{}
+
+	defer res.Close()
+	for res.Next() {
+		var version int
+		if err := res.Scan(&version); err != nil {
+			log.Println("error: error reading applied migration... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error reading applied migration... "), err)
+		}
+		versions[version] = true
+	}
+	return versions, res.Err()
+}
+
+// checkOwnership makes sure the connected role can change the schema: it
+// must own it or be allowed to create in it, and own every table and
+// sequence in it. Databases created by init.sh before the migrations left
+// them to the superuser; db/upgrade-ownership.sh hands them over.
+func (m *Migrator) checkOwnership(conn *sql.Conn) error {
+	ctx := context.Background()
+	query := "SELECT pg_has_role(n.nspowner, 'MEMBER') OR has_schema_privilege(n.oid, 'CREATE') " +
+		"FROM pg_catalog.pg_namespace n WHERE n.nspname = $1"
+	var canCreate bool
+	err := conn.QueryRowContext(ctx, query, m.schema).Scan(&canCreate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error: schema %s does not exist, create it with db/init.sh... ", m.schema)
+	}
+	if err != nil {
+		log.Println("error: error checking schema ownership... ", err)
+		return errors.Join(fmt.Errorf("error: error checking schema ownership... "), err)
+	}
+
+	query = "SELECT c.relname FROM pg_catalog.pg_class c " +
+		"JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace " +
+		"WHERE n.nspname = $1 AND c.relkind IN ('r', 'p', 'S') AND NOT pg_has_role(c.relowner, 'MEMBER') " +
+		"ORDER BY c.relname"
+	res, err := conn.QueryContext(ctx, query, m.schema)
+	if err != nil {
+		log.Println("error: error checking table ownership... ", err)
+		return errors.Join(fmt.Errorf("error: error checking table ownership... "), err)
+	}
+	defer res.Close()
+	foreign := []string{}
+	for res.Next() {
+		var name string
+		if err := res.Scan(&name); err != nil {
+			log.Println("error: error reading table ownership... ", err)
+			return errors.Join(fmt.Errorf("error: error reading table ownership... "), err)
+		}
+		foreign = append(foreign, name)
+	}
+	if err := res.Err(); err != nil {
+		return errors.Join(fmt.Errorf("error: error reading table ownership... "), err)
+	}
+
+	if !canCreate || len(foreign) > 0 {
+		owned := "the schema"
+		if len(foreign) > 0 {
+			owned = strings.Join(foreign, ", ")
+		}
+		log.Printf("error: the database role doesn't own %s in schema %s\n", owned, m.schema)
+		return fmt.Errorf("error: the database role can't migrate schema %s, it doesn't own %s; "+
+			"run db/upgrade-ownership.sh as the database owner first... ", m.schema, owned)
+	}
+	return nil
+}
+
+// inTx runs script and the bookkeeping statement in one transaction, so a
+// failing script leaves neither the schema nor schema_migrations changed.
+func (m *Migrator) inTx(conn *sql.Conn, script, bookkeeping string, args ...any) error {
+	ctx := context.Background()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	placeholders := strings.NewReplacer(schemaPlaceholder, m.schema, currencyPlaceholder, m.currency)
+	if _, err := tx.ExecContext(ctx, placeholders.Replace(script)); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// lockKey derives the advisory lock id from the schema, so services using
+// different schemas of the same database don't block each other.
+func (m *Migrator) lockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(migrationsTable + ":" + m.schema))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,322 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"reflect"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const (
+	migrationsSchema = "test"
+)
+
+var testFiles = fstest.MapFS{
+	"0001_create-expenses.up.sql":   {Data: []byte("CREATE TABLE ${schema}.expenses (id SERIAL)")},
+	"0001_create-expenses.down.sql": {Data: []byte("DROP TABLE ${schema}.expenses")},
+	"0002_create-incomes.up.sql":    {Data: []byte("CREATE TABLE ${schema}.incomes (currency CHAR(3) DEFAULT '${currency}')")},
+	"0002_create-incomes.down.sql":  {Data: []byte("DROP TABLE ${schema}.incomes")},
+}
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+// expectOwnership mocks the ownership checks, with the tables the role
+// doesn't own.
+func expectOwnership(mock sqlmock.Sqlmock, canCreate bool, foreign ...string) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pg_has_role(n.nspowner, 'MEMBER')")).
+		WithArgs(migrationsSchema).
+		WillReturnRows(sqlmock.NewRows([]string{"can_create"}).AddRow(canCreate))
+	rows := sqlmock.NewRows([]string{"relname"})
+	for _, name := range foreign {
+		rows.AddRow(name)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.relname FROM pg_catalog.pg_class c")).
+		WithArgs(migrationsSchema).
+		WillReturnRows(rows)
+}
+
+// expectApplied mocks the lock and the lookup of the applied versions.
+func expectApplied(mock sqlmock.Sqlmock, versions ...int) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectOwnership(mock, true)
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS test.schema_migrations (")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, v := range versions {
+		rows.AddRow(v)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM test.schema_migrations ORDER BY version")).
+		WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectMigrationsTable mocks the check for the schema_migrations table.
+func expectMigrationsTable(mock sqlmock.Sqlmock, exists bool) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM information_schema.tables")).
+		WithArgs(migrationsSchema, "schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:  "given migration scripts, then get them sorted by version",
+			files: testFiles,
+			want:  []int{1, 2},
+		},
+		{
+			name: "given a migration without down script, then get error",
+			files: fstest.MapFS{
+				"0001_create-expenses.up.sql": {Data: []byte("CREATE TABLE ${schema}.expenses (id SERIAL)")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given a repeated version, then get error",
+			files: fstest.MapFS{
+				"0001_create-expenses.up.sql": {Data: []byte("SELECT 1")},
+				"0001_create-incomes.up.sql":  {Data: []byte("SELECT 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "given a file with an invalid name, then get error",
+			files: fstest.MapFS{
+				"create-expenses.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			versions := []int{}
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("Load() versions = %v, want %v", versions, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	db, _ := NewMock()
+	defer db.Close()
+
+	if _, err := NewMigrator(postgresconfig.PostgreSqlConnectionProperties{Schema: migrationsSchema}, db, "USD"); err != nil {
+		t.Errorf("NewMigrator() error = %v", err)
+	}
+	if _, err := newMigrator(db, migrationsSchema, "usd", testFiles); err == nil {
+		t.Errorf("newMigrator() with currency %q error = nil, want error", "usd")
+	}
+}
+
+func TestMigratorUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		want          int
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an applied migration, then apply only the pending ones",
+			want: 1,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				expectApplied(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE test.incomes (currency CHAR(3) DEFAULT 'USD')")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO test.schema_migrations (version, name) VALUES($1, $2)")).
+					WithArgs(2, "create-incomes").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(mock)
+				return db, mock
+			},
+		},
+		{
+			name: "given every migration applied, then do nothing",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				expectApplied(mock, 1, 2)
+				expectUnlock(mock)
+				return db, mock
+			},
+		},
+		{
+			name:    "given a failing script, then rollback and get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				expectApplied(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE test.expenses (id SERIAL)")).
+					WillReturnError(errors.ErrUnsupported)
+				mock.ExpectRollback()
+				expectUnlock(mock)
+				return db, mock
+			},
+		},
+		{
+			name:    "given tables owned by another role, then get error without migrating",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectOwnership(mock, true, "expenses", "expenses_id_seq")
+				expectUnlock(mock)
+				return db, mock
+			},
+		},
+		{
+			name:    "given a schema the role can't create in, then get error without migrating",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectOwnership(mock, false)
+				expectUnlock(mock)
+				return db, mock
+			},
+		},
+		{
+			name:    "given the lock can't be acquired, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			migrator, err := newMigrator(db, migrationsSchema, "USD", testFiles)
+			if err != nil {
+				t.Fatalf("newMigrator() error = %v", err)
+			}
+			got, err := migrator.Up()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Migrator.Up() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Migrator.Up() = %d, want %d", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func TestMigratorDown(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+	expectApplied(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE test.incomes")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM test.schema_migrations WHERE version=$1")).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	migrator, err := newMigrator(db, migrationsSchema, "USD", testFiles)
+	if err != nil {
+		t.Fatalf("newMigrator() error = %v", err)
+	}
+	got, err := migrator.Down(1)
+	if err != nil {
+		t.Errorf("Migrator.Down() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("Migrator.Down() = %d, want %d", got, 1)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestMigratorVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		want          int
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given applied migrations, then get the highest version",
+			want: 2,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				expectMigrationsTable(mock, true)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM test.schema_migrations")).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+				return db, mock
+			},
+		},
+		{
+			name: "given no migrations table, then get version 0 without creating it",
+			want: 0,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				expectMigrationsTable(mock, false)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			migrator, err := newMigrator(db, migrationsSchema, "USD", testFiles)
+			if err != nil {
+				t.Fatalf("newMigrator() error = %v", err)
+			}
+			got, err := migrator.Version()
+			if err != nil || got != tt.want {
+				t.Errorf("Migrator.Version() = %d, %v, want %d", got, err, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ${schema}.incomes;
+DROP TABLE IF EXISTS ${schema}.expenses;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS ${schema}.expenses (
+    id SERIAL PRIMARY KEY NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL,
+    created TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ${schema}.incomes (
+    id SERIAL PRIMARY KEY NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL,
+    created TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS expenses_created_idx ON ${schema}.expenses (created);
+CREATE INDEX IF NOT EXISTS incomes_created_idx ON ${schema}.incomes (created);
@@ -0,0 +1,2 @@
+ALTER TABLE ${schema}.expenses ALTER COLUMN amount TYPE FLOAT;
+ALTER TABLE ${schema}.incomes ALTER COLUMN amount TYPE FLOAT;
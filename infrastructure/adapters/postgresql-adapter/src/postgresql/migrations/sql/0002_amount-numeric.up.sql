@@ -0,0 +1,6 @@
+-- Databases created before amounts were stored as exact values still have
+-- FLOAT columns; round every stored amount to cents.
+ALTER TABLE ${schema}.expenses
+    ALTER COLUMN amount TYPE NUMERIC(19, 2) USING ROUND(amount::NUMERIC, 2);
+ALTER TABLE ${schema}.incomes
+    ALTER COLUMN amount TYPE NUMERIC(19, 2) USING ROUND(amount::NUMERIC, 2);
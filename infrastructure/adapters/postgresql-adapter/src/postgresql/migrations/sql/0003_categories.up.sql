@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS ${schema}.categories (
+    id SERIAL PRIMARY KEY NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    parent_id INTEGER REFERENCES ${schema}.categories (id) ON DELETE SET NULL
+);
+
+ALTER TABLE ${schema}.expenses
+    ADD COLUMN IF NOT EXISTS category_id INTEGER
+        REFERENCES ${schema}.categories (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS expenses_category_id_idx ON ${schema}.expenses (category_id);
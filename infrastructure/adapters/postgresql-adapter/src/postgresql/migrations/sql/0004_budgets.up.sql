@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS ${schema}.budgets (
+    id SERIAL PRIMARY KEY NOT NULL,
+    category_id INTEGER NOT NULL REFERENCES ${schema}.categories (id) ON DELETE CASCADE,
+    period CHAR(7) NOT NULL,
+    limit_amount NUMERIC(19, 2) NOT NULL,
+    UNIQUE (category_id, period)
+);
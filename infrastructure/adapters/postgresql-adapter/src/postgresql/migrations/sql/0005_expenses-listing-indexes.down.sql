@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS expenses_created_idx ON ${schema}.expenses (created);
+DROP INDEX IF EXISTS ${schema}.expenses_amount_id_idx;
+DROP INDEX IF EXISTS ${schema}.expenses_created_id_idx;
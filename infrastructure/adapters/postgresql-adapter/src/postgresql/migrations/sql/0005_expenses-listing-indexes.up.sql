@@ -0,0 +1,4 @@
+-- Keyset pagination sorts by the listed column and then by id.
+CREATE INDEX IF NOT EXISTS expenses_created_id_idx ON ${schema}.expenses (created, id);
+CREATE INDEX IF NOT EXISTS expenses_amount_id_idx ON ${schema}.expenses (amount, id);
+DROP INDEX IF EXISTS ${schema}.expenses_created_idx;
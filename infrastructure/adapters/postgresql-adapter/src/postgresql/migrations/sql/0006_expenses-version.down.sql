@@ -0,0 +1 @@
+ALTER TABLE ${schema}.expenses DROP COLUMN IF EXISTS version;
@@ -0,0 +1,2 @@
+-- Optimistic locking: every update bumps the version it was based on.
+ALTER TABLE ${schema}.expenses ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+DELETE FROM ${schema}.expenses WHERE deleted IS NOT NULL;
+DROP INDEX IF EXISTS ${schema}.expenses_deleted_idx;
+ALTER TABLE ${schema}.expenses DROP COLUMN IF EXISTS deleted;
@@ -0,0 +1,3 @@
+-- Deleted expenses stay in the trash until they are purged.
+ALTER TABLE ${schema}.expenses ADD COLUMN IF NOT EXISTS deleted TIMESTAMP;
+CREATE INDEX IF NOT EXISTS expenses_deleted_idx ON ${schema}.expenses (deleted) WHERE deleted IS NOT NULL;
@@ -0,0 +1,13 @@
+-- Every change to a financial record, with who made it and JSON snapshots
+-- of the record before and after it.
+CREATE TABLE IF NOT EXISTS ${schema}.audit_log (
+    id SERIAL PRIMARY KEY NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor TEXT NOT NULL,
+    snapshot_before JSONB,
+    snapshot_after JSONB,
+    recorded TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON ${schema}.audit_log (entity, entity_id, id);
@@ -0,0 +1,10 @@
+-- Bank transactions already imported, by the account and id the bank gave
+-- them, with the expense or income created for each.
+CREATE TABLE IF NOT EXISTS ${schema}.imported_transactions (
+    id SERIAL PRIMARY KEY NOT NULL,
+    account VARCHAR(64) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    record_id INTEGER NOT NULL,
+    UNIQUE (account, external_id)
+);
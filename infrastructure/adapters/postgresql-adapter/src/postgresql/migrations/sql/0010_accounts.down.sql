@@ -0,0 +1,3 @@
+ALTER TABLE ${schema}.incomes DROP COLUMN IF EXISTS account_id;
+ALTER TABLE ${schema}.expenses DROP COLUMN IF EXISTS account_id;
+DROP TABLE IF EXISTS ${schema}.accounts;
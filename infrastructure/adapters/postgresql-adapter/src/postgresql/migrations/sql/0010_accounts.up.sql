@@ -0,0 +1,20 @@
+-- Wallets expenses and incomes belong to. Records without an account, like
+-- the ones created before accounts existed, keep a NULL account_id.
+CREATE TABLE IF NOT EXISTS ${schema}.accounts (
+    id SERIAL PRIMARY KEY NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    opening_balance NUMERIC(19, 2) NOT NULL DEFAULT 0,
+    closed BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+ALTER TABLE ${schema}.expenses
+    ADD COLUMN IF NOT EXISTS account_id INTEGER
+        REFERENCES ${schema}.accounts (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS expenses_account_id_idx ON ${schema}.expenses (account_id);
+
+ALTER TABLE ${schema}.incomes
+    ADD COLUMN IF NOT EXISTS account_id INTEGER
+        REFERENCES ${schema}.accounts (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS incomes_account_id_idx ON ${schema}.incomes (account_id);
@@ -0,0 +1,15 @@
+-- Money moved between two accounts. A transfer is a single row, so both of
+-- its sides are written or neither is; accounts with transfers can't be
+-- deleted.
+CREATE TABLE IF NOT EXISTS ${schema}.transfers (
+    id SERIAL PRIMARY KEY NOT NULL,
+    from_account_id INTEGER NOT NULL REFERENCES ${schema}.accounts (id),
+    to_account_id INTEGER NOT NULL REFERENCES ${schema}.accounts (id),
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    created TIMESTAMP NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX IF NOT EXISTS transfers_from_account_id_idx ON ${schema}.transfers (from_account_id);
+CREATE INDEX IF NOT EXISTS transfers_to_account_id_idx ON ${schema}.transfers (to_account_id);
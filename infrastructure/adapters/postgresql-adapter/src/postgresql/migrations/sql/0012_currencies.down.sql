@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ${schema}.exchange_rates;
+ALTER TABLE ${schema}.incomes DROP COLUMN IF EXISTS currency;
+ALTER TABLE ${schema}.expenses DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,14 @@
+-- Amounts keep the ISO 4217 code of their currency. NULL, like on the rows
+-- created before currencies existed, means the reporting currency.
+ALTER TABLE ${schema}.expenses ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE ${schema}.incomes ADD COLUMN IF NOT EXISTS currency CHAR(3);
+
+-- What one unit of base is worth in quote from date on, until a later rate
+-- of the same pair.
+CREATE TABLE IF NOT EXISTS ${schema}.exchange_rates (
+    date DATE NOT NULL,
+    base CHAR(3) NOT NULL,
+    quote CHAR(3) NOT NULL,
+    rate NUMERIC NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, date)
+);
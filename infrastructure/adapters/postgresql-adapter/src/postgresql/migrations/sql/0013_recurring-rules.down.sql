@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ${schema}.recurring_occurrences;
+DROP TABLE IF EXISTS ${schema}.recurring_rules;
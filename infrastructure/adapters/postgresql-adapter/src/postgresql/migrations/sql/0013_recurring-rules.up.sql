@@ -0,0 +1,28 @@
+-- Rules creating the same expense or income on a schedule. day_of_month is
+-- zero when occurrences fall on the day of start_date.
+CREATE TABLE IF NOT EXISTS ${schema}.recurring_rules (
+    id SERIAL PRIMARY KEY NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3),
+    category_id INTEGER REFERENCES ${schema}.categories (id) ON DELETE SET NULL,
+    account_id INTEGER REFERENCES ${schema}.accounts (id) ON DELETE SET NULL,
+    frequency VARCHAR(10) NOT NULL,
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    day_of_month INTEGER NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    start_date DATE NOT NULL,
+    end_date DATE
+);
+
+-- The occurrences of a rule that were skipped, overridden or created. The
+-- primary key keeps an occurrence from being created twice, even by two
+-- instances at once.
+CREATE TABLE IF NOT EXISTS ${schema}.recurring_occurrences (
+    rule_id INTEGER NOT NULL REFERENCES ${schema}.recurring_rules (id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL,
+    record_id INTEGER,
+    PRIMARY KEY (rule_id, date)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ${schema}.expense_splits;
@@ -0,0 +1,12 @@
+-- The lines a split expense is broken down into, in order. Their amounts
+-- add up to the amount of the expense, which has no category of its own.
+CREATE TABLE IF NOT EXISTS ${schema}.expense_splits (
+    expense_id INTEGER NOT NULL REFERENCES ${schema}.expenses (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    category_id INTEGER REFERENCES ${schema}.categories (id) ON DELETE SET NULL,
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (expense_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS expense_splits_category_id_idx ON ${schema}.expense_splits (category_id);
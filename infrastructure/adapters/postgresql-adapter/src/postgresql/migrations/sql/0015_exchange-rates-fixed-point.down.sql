@@ -0,0 +1 @@
+ALTER TABLE ${schema}.exchange_rates ALTER COLUMN rate TYPE NUMERIC;
@@ -0,0 +1,4 @@
+-- Rates are exact decimals with 8 places, so converting an amount gives the
+-- same cents on every database.
+ALTER TABLE ${schema}.exchange_rates
+    ALTER COLUMN rate TYPE NUMERIC(18, 8) USING ROUND(rate, 8);
@@ -0,0 +1,4 @@
+ALTER TABLE ${schema}.transfers DROP COLUMN IF EXISTS currency;
+ALTER TABLE ${schema}.recurring_rules ALTER COLUMN currency DROP NOT NULL;
+ALTER TABLE ${schema}.incomes ALTER COLUMN currency DROP NOT NULL;
+ALTER TABLE ${schema}.expenses ALTER COLUMN currency DROP NOT NULL;
@@ -0,0 +1,22 @@
+-- Every amount keeps its currency. Rows created before currencies existed
+-- take the one of their account, or the reporting currency without one.
+UPDATE ${schema}.expenses e SET currency = a.currency
+    FROM ${schema}.accounts a WHERE a.id = e.account_id AND e.currency IS NULL;
+UPDATE ${schema}.expenses SET currency = '${currency}' WHERE currency IS NULL;
+ALTER TABLE ${schema}.expenses ALTER COLUMN currency SET NOT NULL;
+
+UPDATE ${schema}.incomes i SET currency = a.currency
+    FROM ${schema}.accounts a WHERE a.id = i.account_id AND i.currency IS NULL;
+UPDATE ${schema}.incomes SET currency = '${currency}' WHERE currency IS NULL;
+ALTER TABLE ${schema}.incomes ALTER COLUMN currency SET NOT NULL;
+
+UPDATE ${schema}.recurring_rules r SET currency = a.currency
+    FROM ${schema}.accounts a WHERE a.id = r.account_id AND r.currency IS NULL;
+UPDATE ${schema}.recurring_rules SET currency = '${currency}' WHERE currency IS NULL;
+ALTER TABLE ${schema}.recurring_rules ALTER COLUMN currency SET NOT NULL;
+
+-- Transfers are in the currency both of their accounts share.
+ALTER TABLE ${schema}.transfers ADD COLUMN IF NOT EXISTS currency CHAR(3);
+UPDATE ${schema}.transfers t SET currency = a.currency
+    FROM ${schema}.accounts a WHERE a.id = t.from_account_id AND t.currency IS NULL;
+ALTER TABLE ${schema}.transfers ALTER COLUMN currency SET NOT NULL;
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	_ "github.com/lib/pq"
 )
@@ -16,6 +17,9 @@ type PostgreSqlConnectionProperties struct {
 	Password string `yaml:"password"`
 	DBname   string `yaml:"dbname"`
 	Schema   string `yaml:"schema"`
+	// QueryTimeout bounds every query run by the adapters that accept a
+	// context; zero means no timeout other than the caller's.
+	QueryTimeout time.Duration `yaml:"query-timeout"`
 }
 
 func CreateSqlConnection(properties PostgreSqlConnectionProperties) *sql.DB {
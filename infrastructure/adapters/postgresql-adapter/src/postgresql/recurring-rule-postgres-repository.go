@@ -0,0 +1,305 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	recurringRulesTable       = "recurring_rules"
+	recurringOccurrencesTable = "recurring_occurrences"
+
+	recurringRuleColumns = "id, kind, description, amount, currency, category_id, account_id, frequency, " +
+		"interval_count, day_of_month, start_date, end_date"
+)
+
+type RecurringRulePostgresAdapter struct {
+	db               executor
+	schema           string
+	table            string
+	occurrencesTable string
+	timeout          time.Duration
+}
+
+func NewRecurringRulePostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.RecurringRuleRepository {
+	return &RecurringRulePostgresAdapter{
+		db:               db,
+		schema:           prop.Schema,
+		table:            recurringRulesTable,
+		occurrencesTable: recurringOccurrencesTable,
+		timeout:          prop.QueryTimeout,
+	}
+}
+
+func (r *RecurringRulePostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s.%s t WHERE t.id = $1", r.schema, r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for recurring rule... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *RecurringRulePostgresAdapter) FindByID(ctx context.Context, id int) (*model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT %s FROM %s.%s WHERE id = $1", recurringRuleColumns, r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for recurring rule... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanRecurringRule(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("recurring rule")
+}
+
+func (r *RecurringRulePostgresAdapter) FindAll(ctx context.Context) ([]model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT %s FROM %s.%s ORDER BY id", recurringRuleColumns, r.schema, r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for recurring rules... "), err)
+	}
+
+	rules := []model.RecurringRule{}
+
+	defer res.Close()
+	for res.Next() {
+		rule, err := scanRecurringRule(res)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func (r *RecurringRulePostgresAdapter) Save(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (kind, description, amount, currency, category_id, account_id, "+
+		"frequency, interval_count, day_of_month, start_date, end_date) "+
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, TO_DATE($10, 'YYYY-MM-DD'), TO_DATE($11, 'YYYY-MM-DD')) "+
+		"RETURNING id", r.schema, r.table)
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, recurringRuleArgs(rule)...).Scan(&id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving recurring rule... "), err)
+	}
+	rule.Id = id
+	return rule, nil
+}
+
+func (r *RecurringRulePostgresAdapter) Update(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s.%s SET kind=$1, description=$2, amount=$3, currency=$4, category_id=$5, "+
+		"account_id=$6, frequency=$7, interval_count=$8, day_of_month=$9, "+
+		"start_date=TO_DATE($10, 'YYYY-MM-DD'), end_date=TO_DATE($11, 'YYYY-MM-DD') WHERE id=$12",
+		r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, append(recurringRuleArgs(rule), rule.Id)...)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating recurring rule... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return rule, nil
+}
+
+// Delete removes the occurrences of the rule along with it, through the ON
+// DELETE CASCADE of their foreign key.
+func (r *RecurringRulePostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id=$1", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting recurring rule... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *RecurringRulePostgresAdapter) FindOccurrences(ctx context.Context, ruleId int,
+	from, to time.Time) ([]model.RecurringOccurrence, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT rule_id, date, status, amount, record_id FROM %s.%s "+
+		"WHERE rule_id = $1 AND date BETWEEN TO_DATE($2, 'YYYY-MM-DD') AND TO_DATE($3, 'YYYY-MM-DD') "+
+		"ORDER BY date", r.schema, r.occurrencesTable)
+	res, err := r.db.QueryContext(ctx, query, ruleId, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for occurrences... "), err)
+	}
+
+	occurrences := []model.RecurringOccurrence{}
+
+	defer res.Close()
+	for res.Next() {
+		var o model.RecurringOccurrence
+		var rawDate, rawAmount string
+		var recordId sql.NullInt64
+		if err := res.Scan(&o.RuleId, &rawDate, &o.Status, &rawAmount, &recordId); err != nil {
+			log.Println("error: error building occurrence item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building occurrence item... "), err)
+		}
+		if o.Date, err = time.Parse(time.RFC3339, rawDate); err != nil {
+			log.Println("error: error parsing occurrence date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing occurrence date... "), err)
+		}
+		if o.Amount, err = model.ParseMoney(rawAmount); err != nil {
+			log.Println("error: error parsing amount... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+		}
+		o.RecordId = idFromNullable(recordId)
+		occurrences = append(occurrences, o)
+	}
+
+	return occurrences, nil
+}
+
+func (r *RecurringRulePostgresAdapter) LastCreated(ctx context.Context, ruleId int) (time.Time, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT MAX(o.date) FROM %[1]s.%[2]s o "+
+		"WHERE o.rule_id = $1 AND o.status = 'created' AND NOT EXISTS ("+
+		"SELECT 1 FROM %[1]s.%[2]s p WHERE p.rule_id = o.rule_id AND p.status = 'overridden' AND p.date < o.date)",
+		r.schema, r.occurrencesTable)
+	var rawDate sql.NullString
+	if err := r.db.QueryRowContext(ctx, query, ruleId).Scan(&rawDate); err != nil {
+		log.Println("error: error executing select query... ", err)
+		return time.Time{}, errors.Join(fmt.Errorf("error: error searching for the last created occurrence... "), err)
+	}
+	if !rawDate.Valid {
+		return time.Time{}, nil
+	}
+	last, err := time.Parse(time.RFC3339, rawDate.String)
+	if err != nil {
+		log.Println("error: error parsing occurrence date... ", err)
+		return time.Time{}, errors.Join(fmt.Errorf("error: error parsing occurrence date... "), err)
+	}
+	return last, nil
+}
+
+// SaveOccurrence upserts the occurrence, skipping the update when the stored
+// one was created. The primary key lock makes a concurrent call wait for
+// the first to commit and then see it created.
+func (r *RecurringRulePostgresAdapter) SaveOccurrence(ctx context.Context, o *model.RecurringOccurrence) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s.%s AS o (rule_id, date, status, amount, record_id) "+
+		"VALUES($1, TO_DATE($2, 'YYYY-MM-DD'), $3, $4, $5) "+
+		"ON CONFLICT (rule_id, date) DO UPDATE "+
+		"SET status = EXCLUDED.status, amount = EXCLUDED.amount, record_id = EXCLUDED.record_id "+
+		"WHERE o.status <> 'created'", r.schema, r.occurrencesTable)
+
+	res, err := r.db.ExecContext(ctx, query, o.RuleId, o.Date.Format(time.DateOnly), string(o.Status),
+		o.Amount.String(), nullableID(o.RecordId))
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return false, errors.Join(fmt.Errorf("error: saving occurrence... "), err)
+	}
+	nr, err := res.RowsAffected()
+	if err != nil {
+		log.Println("error: error reading insert result... ", err)
+		return false, errors.Join(fmt.Errorf("error: unknown insert operation result... "), err)
+	}
+	return nr > 0, nil
+}
+
+func recurringRuleArgs(rule *model.RecurringRule) []any {
+	var end sql.NullString
+	if rule.End != nil {
+		end = sql.NullString{String: rule.End.Format(time.DateOnly), Valid: true}
+	}
+	return []any{string(rule.Kind), rule.Description, rule.Amount.String(), nullableString(rule.Currency),
+		nullableID(rule.CategoryId), nullableID(rule.AccountId), string(rule.Frequency), rule.Interval,
+		rule.DayOfMonth, rule.Start.Format(time.DateOnly), end}
+}
+
+func scanRecurringRule(res *sql.Rows) (*model.RecurringRule, error) {
+	rule := &model.RecurringRule{}
+	var rawAmount, rawStart string
+	var currency, rawEnd sql.NullString
+	var categoryId, accountId sql.NullInt64
+	err := res.Scan(&rule.Id, &rule.Kind, &rule.Description, &rawAmount, &currency, &categoryId, &accountId,
+		&rule.Frequency, &rule.Interval, &rule.DayOfMonth, &rawStart, &rawEnd)
+	if err != nil {
+		log.Println("error: error building recurring rule item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building recurring rule item... "), err)
+	}
+	if rule.Amount, err = model.ParseMoney(rawAmount); err != nil {
+		log.Println("error: error parsing amount... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+	}
+	if rule.Start, err = time.Parse(time.RFC3339, rawStart); err != nil {
+		log.Println("error: error parsing start date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing start date... "), err)
+	}
+	if rawEnd.Valid {
+		end, err := time.Parse(time.RFC3339, rawEnd.String)
+		if err != nil {
+			log.Println("error: error parsing end date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing end date... "), err)
+		}
+		rule.End = &end
+	}
+	rule.Currency = currency.String
+	rule.CategoryId = idFromNullable(categoryId)
+	rule.AccountId = idFromNullable(accountId)
+	return rule, nil
+}
@@ -0,0 +1,272 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func newTestRecurringRuleAdapter(db *sql.DB) *RecurringRulePostgresAdapter {
+	return &RecurringRulePostgresAdapter{
+		db:               db,
+		schema:           expensesSchema,
+		table:            recurringRulesTable,
+		occurrencesTable: recurringOccurrencesTable,
+	}
+}
+
+func Test_recurringRulePostgresRepository_FindAll(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, kind, description, amount, currency, category_id, account_id, " +
+		"frequency, interval_count, day_of_month, start_date, end_date FROM test.recurring_rules ORDER BY id")
+	columns := []string{"id", "kind", "description", "amount", "currency", "category_id", "account_id",
+		"frequency", "interval_count", "day_of_month", "start_date", "end_date"}
+	end := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          []model.RecurringRule
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given rules, then get them all",
+			want: []model.RecurringRule{
+				{
+					Id: 1, Kind: model.KindExpense, Description: "rent", Amount: 120000, Currency: "USD",
+					CategoryId: 2, AccountId: 3, Frequency: model.FrequencyMonthly, Interval: 1, DayOfMonth: 31,
+					Start: time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC), End: &end,
+				},
+				{
+					Id: 2, Kind: model.KindIncome, Amount: 300000, Frequency: model.FrequencyWeekly, Interval: 2,
+					Start: time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "expense", "rent", "1200.00", "USD", 2, 3, "MONTHLY", 1, 31,
+							"2023-01-31T00:00:00Z", "2024-01-31T00:00:00Z").
+						AddRow(2, "income", "", "3000.00", nil, nil, nil, "WEEKLY", 2, 0,
+							"2023-01-06T00:00:00Z", nil))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an invalid start date, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "expense", "", "1200.00", nil, nil, nil, "MONTHLY", 1, 0, "test", nil))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestRecurringRuleAdapter(db).FindAll(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recurringRulePostgresRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recurringRulePostgresRepository.FindAll() = %+v, want %+v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_recurringRulePostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.recurring_rules (kind, description, amount, currency, " +
+		"category_id, account_id, frequency, interval_count, day_of_month, start_date, end_date) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, TO_DATE($10, 'YYYY-MM-DD'), TO_DATE($11, 'YYYY-MM-DD')) " +
+		"RETURNING id")
+	db, mock := NewMock()
+	defer db.Close()
+	mock.ExpectQuery(query).
+		WithArgs("expense", "rent", "1200.00", "USD", 2, nil, "MONTHLY", 1, 31, "2023-01-31", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+	rule := &model.RecurringRule{
+		Kind: model.KindExpense, Description: "rent", Amount: 120000, Currency: "USD", CategoryId: 2,
+		Frequency: model.FrequencyMonthly, Interval: 1, DayOfMonth: 31,
+		Start: time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+	}
+	got, err := newTestRecurringRuleAdapter(db).Save(context.Background(), rule)
+	if err != nil || got.Id != 4 {
+		t.Errorf("recurringRulePostgresRepository.Save() = %+v, %v, want id 4", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func Test_recurringRulePostgresRepository_FindOccurrences(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT rule_id, date, status, amount, record_id FROM test.recurring_occurrences " +
+		"WHERE rule_id = $1 AND date BETWEEN TO_DATE($2, 'YYYY-MM-DD') AND TO_DATE($3, 'YYYY-MM-DD') ORDER BY date")
+	db, mock := NewMock()
+	defer db.Close()
+	mock.ExpectQuery(query).WithArgs(1, "2023-01-01", "2023-03-31").
+		WillReturnRows(sqlmock.NewRows([]string{"rule_id", "date", "status", "amount", "record_id"}).
+			AddRow(1, "2023-01-31T00:00:00Z", "created", "1200.00", 7).
+			AddRow(1, "2023-02-28T00:00:00Z", "skipped", "1200.00", nil))
+
+	want := []model.RecurringOccurrence{
+		{
+			RuleId: 1, Date: time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			Status: model.OccurrenceCreated, Amount: 120000, RecordId: 7,
+		},
+		{
+			RuleId: 1, Date: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Status: model.OccurrenceSkipped, Amount: 120000,
+		},
+	}
+	got, err := newTestRecurringRuleAdapter(db).FindOccurrences(context.Background(), 1,
+		time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("recurringRulePostgresRepository.FindOccurrences() = %+v, %v, want %+v", got, err, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func Test_recurringRulePostgresRepository_LastCreated(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT MAX(o.date) FROM test.recurring_occurrences o " +
+		"WHERE o.rule_id = $1 AND o.status = 'created' AND NOT EXISTS (" +
+		"SELECT 1 FROM test.recurring_occurrences p WHERE p.rule_id = o.rule_id AND p.status = 'overridden' AND p.date < o.date)")
+	tests := []struct {
+		name          string
+		want          time.Time
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given created occurrences, then get the date of the last one",
+			want: time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow("2023-01-31T00:00:00Z"))
+				return db, mock
+			},
+		},
+		{
+			name: "given no created occurrence, then get the zero time",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+			got, err := newTestRecurringRuleAdapter(db).LastCreated(context.Background(), 1)
+			if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+				t.Errorf("recurringRulePostgresRepository.LastCreated() = %v, %v, want %v, wantErr %v",
+					got, err, tt.want, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_recurringRulePostgresRepository_SaveOccurrence(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.recurring_occurrences AS o " +
+		"(rule_id, date, status, amount, record_id) VALUES($1, TO_DATE($2, 'YYYY-MM-DD'), $3, $4, $5) " +
+		"ON CONFLICT (rule_id, date) DO UPDATE " +
+		"SET status = EXCLUDED.status, amount = EXCLUDED.amount, record_id = EXCLUDED.record_id " +
+		"WHERE o.status <> 'created'")
+	tests := []struct {
+		name          string
+		want          bool
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a new occurrence, then save it",
+			want: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(1, "2023-01-31", "created", "1200.00", 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db, mock
+			},
+		},
+		{
+			name: "given an occurrence created before, then get false",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(1, "2023-01-31", "created", "1200.00", 7).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectExec(query).WithArgs(1, "2023-01-31", "created", "1200.00", 7).
+					WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			occurrence := &model.RecurringOccurrence{
+				RuleId: 1, Date: time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+				Status: model.OccurrenceCreated, Amount: 120000, RecordId: 7,
+			}
+			got, err := newTestRecurringRuleAdapter(db).SaveOccurrence(context.Background(), occurrence)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("recurringRulePostgresRepository.SaveOccurrence() = %v, %v, want %v", got, err, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// executor runs the statements of an adapter, either on the connection pool
+// or inside a transaction; both *sql.DB and *sql.Tx satisfy it.
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// nullableID maps the zero id used by the domain for "no reference" to a
+// NULL foreign key.
+func nullableID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
+
+func idFromNullable(id sql.NullInt64) int {
+	if !id.Valid {
+		return 0
+	}
+	return int(id.Int64)
+}
+
+// nullableString maps an empty string to NULL, so a record saved without a
+// currency is rejected by the database instead of stored as an empty one.
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// withTimeout bounds ctx by the configured query timeout, if any.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,200 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+const (
+	transfersTable = "transfers"
+)
+
+type TransferPostgresAdapter struct {
+	db      executor
+	schema  string
+	table   string
+	timeout time.Duration
+}
+
+func NewTransferPostgresAdapter(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.TransferRepository {
+	return &TransferPostgresAdapter{
+		db:      db,
+		schema:  prop.Schema,
+		table:   transfersTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *TransferPostgresAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s.%s t WHERE t.id = $1", r.schema, r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for transfer... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *TransferPostgresAdapter) FindByID(ctx context.Context, id int) (*model.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, from_account_id, to_account_id, amount, currency, created, description "+
+		"FROM %s.%s WHERE id = $1", r.schema, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for transfer... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanTransfer(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("transfer")
+}
+
+func (r *TransferPostgresAdapter) FindAll(ctx context.Context, accountId int) ([]model.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, from_account_id, to_account_id, amount, currency, created, description "+
+		"FROM %s.%s WHERE $1 = 0 OR from_account_id = $1 OR to_account_id = $1 ORDER BY created, id",
+		r.schema, r.table)
+	res, err := r.db.QueryContext(ctx, query, accountId)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for transfers... "), err)
+	}
+
+	transfers := []model.Transfer{}
+
+	defer res.Close()
+	for res.Next() {
+		transfer, err := scanTransfer(res)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	return transfers, nil
+}
+
+func (r *TransferPostgresAdapter) Save(ctx context.Context, t *model.Transfer) (*model.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT "+
+		"INTO %s.%s (from_account_id, to_account_id, amount, currency, created, description) "+
+		"VALUES($1, $2, $3, $4, TO_TIMESTAMP($5, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $6) RETURNING id",
+		r.schema, r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, t.FromAccountId, t.ToAccountId, t.Amount.String(),
+		t.Currency, t.Created.Format(time.RFC3339), t.Description).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving transfer... "), err)
+	}
+	t.Id = id
+	return t, nil
+}
+
+func (r *TransferPostgresAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id=$1", r.schema, r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting transfer... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *TransferPostgresAdapter) Totals(ctx context.Context, accountId int) (*model.TransferTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT "+
+		"COALESCE(SUM(t.amount) FILTER (WHERE t.to_account_id = $1), 0), "+
+		"COALESCE(SUM(t.amount) FILTER (WHERE t.from_account_id = $1), 0) "+
+		"FROM %s.%s t WHERE t.from_account_id = $1 OR t.to_account_id = $1",
+		r.schema, r.table)
+
+	var rawIn, rawOut string
+	if err := r.db.QueryRowContext(ctx, query, accountId).Scan(&rawIn, &rawOut); err != nil {
+		log.Println("error: error executing totals query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating transfer totals... "), err)
+	}
+	in, err := model.ParseMoney(rawIn)
+	if err != nil {
+		log.Println("error: error parsing transfers in total... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing transfers in total... "), err)
+	}
+	out, err := model.ParseMoney(rawOut)
+	if err != nil {
+		log.Println("error: error parsing transfers out total... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing transfers out total... "), err)
+	}
+	return &model.TransferTotals{In: in, Out: out}, nil
+}
+
+func scanTransfer(res *sql.Rows) (*model.Transfer, error) {
+	var id, fromAccountId, toAccountId int
+	var rawAmount, currency, createdDate, description string
+	err := res.Scan(&id, &fromAccountId, &toAccountId, &rawAmount, &currency, &createdDate, &description)
+	if err != nil {
+		log.Println("error: error building transfer item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building transfer item... "), err)
+	}
+	amount, err := model.ParseMoney(rawAmount)
+	if err != nil {
+		log.Println("error: error parsing amount... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing amount... "), err)
+	}
+	date, err := time.Parse(time.RFC3339, createdDate)
+	if err != nil {
+		log.Println("error: error parsing created date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+	}
+	return &model.Transfer{
+		Id:            id,
+		FromAccountId: fromAccountId,
+		ToAccountId:   toAccountId,
+		Amount:        amount,
+		Currency:      currency,
+		Created:       date,
+		Description:   description,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func newTestTransferAdapter(db *sql.DB) *TransferPostgresAdapter {
+	return &TransferPostgresAdapter{db: db, schema: expensesSchema, table: transfersTable}
+}
+
+func Test_transferPostgresRepository_FindAll(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, from_account_id, to_account_id, amount, currency, created, description " +
+		"FROM test.transfers WHERE $1 = 0 OR from_account_id = $1 OR to_account_id = $1 ORDER BY created, id")
+	columns := []string{"id", "from_account_id", "to_account_id", "amount", "currency", "created", "description"}
+	created := time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		want          []model.Transfer
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an account, then get its transfers",
+			want: []model.Transfer{
+				{Id: 1, FromAccountId: 1, ToAccountId: 2, Amount: 10000, Currency: "USD", Created: created, Description: "savings"},
+				{Id: 2, FromAccountId: 2, ToAccountId: 1, Amount: 2530, Currency: "USD", Created: created},
+			},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, 1, 2, "100.00", "USD", "2023-04-15T00:00:00Z", "savings").
+						AddRow(2, 2, 1, "25.30", "USD", "2023-04-15T00:00:00Z", ""))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an invalid amount, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 1, 2, "test", "USD", "2023-04-15T00:00:00Z", ""))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestTransferAdapter(db).FindAll(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("transferPostgresRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("transferPostgresRepository.FindAll() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_transferPostgresRepository_Save(t *testing.T) {
+	query := regexp.QuoteMeta("INSERT INTO test.transfers (from_account_id, to_account_id, amount, currency, created, description) " +
+		"VALUES($1, $2, $3, $4, TO_TIMESTAMP($5, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $6) RETURNING id")
+	tests := []struct {
+		name          string
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given a transfer, then get it with its id",
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(1, 2, "100.00", "USD", "2023-04-15T00:00:00Z", "savings").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				return db, mock
+			},
+		},
+		{
+			name:    "given a database error, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			transfer := &model.Transfer{
+				FromAccountId: 1, ToAccountId: 2, Amount: 10000, Currency: "USD",
+				Created: time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC), Description: "savings",
+			}
+			got, err := newTestTransferAdapter(db).Save(context.Background(), transfer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("transferPostgresRepository.Save() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Id != 7 {
+				t.Errorf("transferPostgresRepository.Save() id = %d, want 7", got.Id)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func Test_transferPostgresRepository_Totals(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT " +
+		"COALESCE(SUM(t.amount) FILTER (WHERE t.to_account_id = $1), 0), " +
+		"COALESCE(SUM(t.amount) FILTER (WHERE t.from_account_id = $1), 0) " +
+		"FROM test.transfers t WHERE t.from_account_id = $1 OR t.to_account_id = $1")
+	tests := []struct {
+		name          string
+		want          *model.TransferTotals
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given an account, then get what went in and out of it",
+			want: &model.TransferTotals{In: 30000, Out: 12550},
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"in", "out"}).AddRow("300.00", "125.50"))
+				return db, mock
+			},
+		},
+		{
+			name:    "given an invalid total, then get error",
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectQuery(query).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"in", "out"}).AddRow("300.00", "test"))
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			got, err := newTestTransferAdapter(db).Totals(context.Background(), 7)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("transferPostgresRepository.Totals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("transferPostgresRepository.Totals() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
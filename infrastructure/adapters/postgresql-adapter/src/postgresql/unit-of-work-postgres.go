@@ -0,0 +1,119 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+)
+
+type PostgresUnitOfWork struct {
+	db   *sql.DB
+	prop postgresconfig.PostgreSqlConnectionProperties
+}
+
+func NewPostgresUnitOfWork(
+	prop postgresconfig.PostgreSqlConnectionProperties, db *sql.DB) port.UnitOfWork {
+	return &PostgresUnitOfWork{db: db, prop: prop}
+}
+
+// Run begins a transaction and hands fn repositories bound to it. The
+// transaction is committed when fn succeeds and rolled back when it fails
+// or panics.
+func (u *PostgresUnitOfWork) Run(ctx context.Context, fn func(repos port.Repositories) error) error {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("error: error beginning transaction... ", err)
+		return errors.Join(fmt.Errorf("error: error beginning transaction... "), err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(u.repositories(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Println("error: error rolling back transaction... ", rbErr)
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("error: error committing transaction... ", err)
+		return errors.Join(fmt.Errorf("error: error committing transaction... "), err)
+	}
+	return nil
+}
+
+func (u *PostgresUnitOfWork) repositories(tx *sql.Tx) port.Repositories {
+	return port.Repositories{
+		Expenses: &ExpensePostgresAdapter{
+			db:          tx,
+			schema:      u.prop.Schema,
+			table:       expensesTable,
+			splitsTable: expenseSplitsTable,
+			timeout:     u.prop.QueryTimeout,
+		},
+		Incomes: &IncomePostgresAdapter{
+			db:      tx,
+			schema:  u.prop.Schema,
+			table:   incomesTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Categories: &CategoryPostgresAdapter{
+			db:      tx,
+			schema:  u.prop.Schema,
+			table:   categoriesTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Budgets: &BudgetPostgresAdapter{
+			db:              tx,
+			schema:          u.prop.Schema,
+			table:           budgetsTable,
+			categoriesTable: categoriesTable,
+			expensesTable:   expensesTable,
+			splitsTable:     expenseSplitsTable,
+			timeout:         u.prop.QueryTimeout,
+		},
+		Audit: &AuditPostgresAdapter{
+			db:      tx,
+			schema:  u.prop.Schema,
+			table:   auditTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Imports: &ImportedTransactionPostgresAdapter{
+			db:      tx,
+			schema:  u.prop.Schema,
+			table:   importedTransactionsTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Accounts: &AccountPostgresAdapter{
+			db:            tx,
+			schema:        u.prop.Schema,
+			table:         accountsTable,
+			incomesTable:  incomesTable,
+			expensesTable: expensesTable,
+			timeout:       u.prop.QueryTimeout,
+		},
+		Transfers: &TransferPostgresAdapter{
+			db:      tx,
+			schema:  u.prop.Schema,
+			table:   transfersTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Recurring: &RecurringRulePostgresAdapter{
+			db:               tx,
+			schema:           u.prop.Schema,
+			table:            recurringRulesTable,
+			occurrencesTable: recurringOccurrencesTable,
+			timeout:          u.prop.QueryTimeout,
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/postgresql-adapter/src/postgresql/postgresconfig"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPostgresUnitOfWork_Run(t *testing.T) {
+	exists := regexp.QuoteMeta("select count(t.id) from test.categories t where t.id = $1")
+	insert := regexp.QuoteMeta("INSERT INTO test.expenses (amount, created, category_id, account_id, currency) " +
+		"VALUES($1, TO_TIMESTAMP($2, 'YYYY-MM-DD\"T\"HH24:MI:SS'), $3, $4, $5) RETURNING id")
+	expense := func() *model.Expense {
+		return &model.Expense{Amount: 1000, Created: time.Date(2023, 4, 12, 8, 22, 15, 0, time.UTC), CategoryId: 3}
+	}
+	save := func(repos port.Repositories) error {
+		if _, err := repos.Categories.Exists(context.Background(), 3); err != nil {
+			return err
+		}
+		_, err := repos.Expenses.Save(context.Background(), expense())
+		return err
+	}
+	tests := []struct {
+		name          string
+		fn            func(repos port.Repositories) error
+		wantErr       bool
+		configSqlMock func() (*sql.DB, sqlmock.Sqlmock)
+	}{
+		{
+			name: "given repository calls that succeed, then run them in one transaction and commit",
+			fn:   save,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectBegin()
+				mock.ExpectQuery(exists).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(insert).WithArgs("10.00", "2023-04-12T08:22:15Z", 3, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+				return db, mock
+			},
+		},
+		{
+			name:    "given a failing repository call, then roll back and get its error",
+			fn:      save,
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectBegin()
+				mock.ExpectQuery(exists).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(insert).WithArgs("10.00", "2023-04-12T08:22:15Z", 3, nil, nil).
+					WillReturnError(errors.ErrUnsupported)
+				mock.ExpectRollback()
+				return db, mock
+			},
+		},
+		{
+			name: "given a function that fails after writing, then roll back the writes",
+			fn: func(repos port.Repositories) error {
+				if _, err := repos.Expenses.Save(context.Background(), expense()); err != nil {
+					return err
+				}
+				return errors.ErrUnsupported
+			},
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectBegin()
+				mock.ExpectQuery(insert).WithArgs("10.00", "2023-04-12T08:22:15Z", 3, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectRollback()
+				return db, mock
+			},
+		},
+		{
+			name:    "given a failing commit, then get error",
+			fn:      func(repos port.Repositories) error { return nil },
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+		{
+			name:    "given the transaction can't begin, then get error without running the function",
+			fn:      func(repos port.Repositories) error { panic("must not run") },
+			wantErr: true,
+			configSqlMock: func() (*sql.DB, sqlmock.Sqlmock) {
+				db, mock := NewMock()
+				mock.ExpectBegin().WillReturnError(errors.ErrUnsupported)
+				return db, mock
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.configSqlMock()
+			defer db.Close()
+
+			uow := NewPostgresUnitOfWork(postgresconfig.PostgreSqlConnectionProperties{Schema: "test"}, db)
+			if err := uow.Run(context.Background(), tt.fn); (err != nil) != tt.wantErr {
+				t.Errorf("PostgresUnitOfWork.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expections: %s", err)
+			}
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_RunPanic(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("PostgresUnitOfWork.Run() didn't propagate the panic")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expections: %s", err)
+		}
+	}()
+	uow := NewPostgresUnitOfWork(postgresconfig.PostgreSqlConnectionProperties{Schema: "test"}, db)
+	uow.Run(context.Background(), func(repos port.Repositories) error { panic("boom") })
+}
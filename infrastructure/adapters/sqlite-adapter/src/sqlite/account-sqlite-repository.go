@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	accountsTable = "accounts"
+)
+
+type AccountSqliteAdapter struct {
+	db             executor
+	table          string
+	incomesTable   string
+	expensesTable  string
+	transfersTable string
+	rulesTable     string
+	timeout        time.Duration
+}
+
+func NewAccountSqliteAdapter(prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.AccountRepository {
+	return &AccountSqliteAdapter{
+		db:             db,
+		table:          accountsTable,
+		incomesTable:   incomesTable,
+		expensesTable:  expensesTable,
+		transfersTable: transfersTable,
+		rulesTable:     recurringRulesTable,
+		timeout:        prop.QueryTimeout,
+	}
+}
+
+func (r *AccountSqliteAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.id = ?", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for account... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *AccountSqliteAdapter) FindByID(ctx context.Context, id int) (*model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, name, type, currency, opening_balance, closed FROM %s WHERE id = ?",
+		r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for account... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanAccount(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("account")
+}
+
+func (r *AccountSqliteAdapter) FindAll(ctx context.Context) ([]model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, name, type, currency, opening_balance, closed FROM %s ORDER BY id",
+		r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for accounts... "), err)
+	}
+
+	accounts := []model.Account{}
+
+	defer res.Close()
+	for res.Next() {
+		account, err := scanAccount(res)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+
+	return accounts, nil
+}
+
+func (r *AccountSqliteAdapter) Save(ctx context.Context, a *model.Account) (*model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (name, type, currency, opening_balance, closed) "+
+		"VALUES(?, ?, ?, ?, ?) RETURNING id", r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, a.Name, string(a.Type), a.Currency,
+		int64(a.OpeningBalance), a.Closed).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving account... "), err)
+	}
+	a.Id = id
+	return a, nil
+}
+
+func (r *AccountSqliteAdapter) Update(ctx context.Context, a *model.Account) (*model.Account, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET name=?, type=?, currency=?, opening_balance=?, closed=? WHERE id=?",
+		r.table)
+
+	res, err := r.db.ExecContext(ctx, query, a.Name, string(a.Type), a.Currency,
+		int64(a.OpeningBalance), a.Closed, a.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating account... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return a, nil
+}
+
+func (r *AccountSqliteAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting account... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *AccountSqliteAdapter) Totals(ctx context.Context, id int) (*model.BalanceTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT "+
+		"(SELECT COALESCE(SUM(i.amount), 0) FROM %s i WHERE i.account_id = ?1), "+
+		"(SELECT COALESCE(SUM(e.amount), 0) FROM %s e WHERE e.deleted IS NULL AND e.account_id = ?1)",
+		r.incomesTable, r.expensesTable)
+
+	var income, expenses int64
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&income, &expenses); err != nil {
+		log.Println("error: error executing totals query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating account totals... "), err)
+	}
+	return &model.BalanceTotals{Income: model.Money(income), Expenses: model.Money(expenses)}, nil
+}
+
+func (r *AccountSqliteAdapter) HasRecords(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT "+
+		"EXISTS (SELECT 1 FROM %s e WHERE e.account_id = ?1) OR "+
+		"EXISTS (SELECT 1 FROM %s i WHERE i.account_id = ?1) OR "+
+		"EXISTS (SELECT 1 FROM %s t WHERE t.from_account_id = ?1 OR t.to_account_id = ?1) OR "+
+		"EXISTS (SELECT 1 FROM %s r WHERE r.account_id = ?1)",
+		r.expensesTable, r.incomesTable, r.transfersTable, r.rulesTable)
+
+	var hasRecords bool
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&hasRecords); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for account records... "), err)
+	}
+	return hasRecords, nil
+}
+
+func scanAccount(res *sql.Rows) (*model.Account, error) {
+	var id int
+	var name, kind, currency string
+	var openingBalance int64
+	var closed bool
+	if err := res.Scan(&id, &name, &kind, &currency, &openingBalance, &closed); err != nil {
+		log.Println("error: error building account item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building account item... "), err)
+	}
+	return &model.Account{
+		Id:             id,
+		Name:           name,
+		Type:           model.AccountType(kind),
+		Currency:       currency,
+		OpeningBalance: model.Money(openingBalance),
+		Closed:         closed,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	auditTable = "audit_log"
+)
+
+type AuditSqliteAdapter struct {
+	db      executor
+	table   string
+	timeout time.Duration
+}
+
+func NewAuditSqliteAdapter(
+	prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.AuditRepository {
+	return &AuditSqliteAdapter{
+		db:      db,
+		table:   auditTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *AuditSqliteAdapter) Record(ctx context.Context, entry *model.AuditEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (entity, entity_id, action, actor, snapshot_before, snapshot_after, recorded) "+
+		"VALUES(?, ?, ?, ?, ?, ?, ?) RETURNING id", r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, entry.Entity, entry.EntityId, string(entry.Action), entry.Actor,
+		nullableJSON(entry.Before), nullableJSON(entry.After), formatTimestamp(entry.Recorded.UTC())).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return errors.Join(fmt.Errorf("error: saving audit entry... "), err)
+	}
+	entry.Id = id
+	return nil
+}
+
+func (r *AuditSqliteAdapter) FindByEntity(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, entity, entity_id, action, actor, snapshot_before, snapshot_after, recorded "+
+		"FROM %s WHERE entity = ? AND entity_id = ? ORDER BY id", r.table)
+
+	res, err := r.db.QueryContext(ctx, query, entity, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for audit entries... "), err)
+	}
+
+	entries := []model.AuditEntry{}
+
+	defer res.Close()
+	for res.Next() {
+		var e model.AuditEntry
+		var action, recordedDate string
+		var before, after sql.NullString
+		err = res.Scan(&e.Id, &e.Entity, &e.EntityId, &action, &e.Actor, &before, &after, &recordedDate)
+		if err != nil {
+			log.Println("error: error building audit entry... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building audit entry... "), err)
+		}
+		recorded, err := parseTimestamp(recordedDate)
+		if err != nil {
+			log.Println("error: error parsing recorded date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing recorded date... "), err)
+		}
+		e.Action = model.AuditAction(action)
+		e.Before = jsonFromNullable(before)
+		e.After = jsonFromNullable(after)
+		e.Recorded = recorded
+		entries = append(entries, e)
+	}
+	if err := res.Err(); err != nil {
+		log.Println("error: error reading select result... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for audit entries... "), err)
+	}
+
+	return entries, nil
+}
+
+// nullableJSON maps an empty snapshot to a NULL column.
+func nullableJSON(raw json.RawMessage) sql.NullString {
+	return sql.NullString{String: string(raw), Valid: len(raw) > 0}
+}
+
+func jsonFromNullable(raw sql.NullString) json.RawMessage {
+	if !raw.Valid {
+		return nil
+	}
+	return json.RawMessage(raw.String)
+}
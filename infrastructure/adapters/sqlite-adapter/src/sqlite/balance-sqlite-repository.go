@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	// dayLayout is the form of the day a stored timestamp starts with.
+	dayLayout = "2006-01-02"
+)
+
+type BalanceSqliteAdapter struct {
+	db            executor
+	incomesTable  string
+	expensesTable string
+	timeout       time.Duration
+}
+
+func NewBalanceSqliteAdapter(
+	prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.BalanceRepository {
+	return &BalanceSqliteAdapter{
+		db:            db,
+		incomesTable:  incomesTable,
+		expensesTable: expensesTable,
+		timeout:       prop.QueryTimeout,
+	}
+}
+
+func (r *BalanceSqliteAdapter) TotalsBefore(ctx context.Context, date time.Time,
+	currency string) ([]model.CurrencyTotals, error) {
+	return r.totals(ctx, "CASE WHEN m.currency = ?2 THEN NULL ELSE SUBSTR(m.created, 1, 10) END",
+		"m.created < ?1", formatTimestamp(date), currency)
+}
+
+func (r *BalanceSqliteAdapter) DailyTotals(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error) {
+	return r.totals(ctx, "SUBSTR(m.created, 1, 10)",
+		"m.created >= ?1 AND m.created < ?2", formatTimestamp(from), formatTimestamp(to))
+}
+
+// totals sums the incomes and expenses matching condition per currency and
+// group, each row dated on the latest day of its group. condition and group
+// refer to either table as m.
+func (r *BalanceSqliteAdapter) totals(ctx context.Context, group, condition string,
+	args ...any) ([]model.CurrencyTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT MAX(t.day), t.currency, SUM(t.income), SUM(t.expenses) FROM ("+
+		"SELECT SUBSTR(m.created, 1, 10) AS day, %s AS grp, m.currency, m.amount AS income, 0 AS expenses "+
+		"FROM %s m WHERE %s "+
+		"UNION ALL "+
+		"SELECT SUBSTR(m.created, 1, 10) AS day, %s AS grp, m.currency, 0 AS income, m.amount AS expenses "+
+		"FROM %s m WHERE m.deleted IS NULL AND %s"+
+		") t GROUP BY t.grp, t.currency ORDER BY MAX(t.day), t.currency",
+		group, r.incomesTable, condition, group, r.expensesTable, condition)
+
+	res, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("error: error executing daily totals query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating daily totals... "), err)
+	}
+
+	daily := []model.CurrencyTotals{}
+
+	defer res.Close()
+	for res.Next() {
+		totals, err := scanCurrencyTotals(res)
+		if err != nil {
+			return nil, err
+		}
+		daily = append(daily, *totals)
+	}
+
+	return daily, nil
+}
+
+// scanCurrencyTotals reads a day, a currency and its income and expenses.
+func scanCurrencyTotals(res *sql.Rows) (*model.CurrencyTotals, error) {
+	var day string
+	var currency sql.NullString
+	var income, expenses int64
+	if err := res.Scan(&day, &currency, &income, &expenses); err != nil {
+		log.Println("error: error reading daily totals... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error reading daily totals... "), err)
+	}
+	date, err := time.Parse(dayLayout, day)
+	if err != nil {
+		log.Println("error: error parsing daily totals date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing daily totals date... "), err)
+	}
+	return &model.CurrencyTotals{
+		Date:     date,
+		Currency: currency.String,
+		Income:   model.Money(income),
+		Expenses: model.Money(expenses),
+	}, nil
+}
@@ -0,0 +1,216 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	budgetsTable = "budgets"
+)
+
+type BudgetSqliteAdapter struct {
+	db              executor
+	table           string
+	categoriesTable string
+	expensesTable   string
+	splitsTable     string
+	timeout         time.Duration
+}
+
+func NewBudgetSqliteAdapter(
+	prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.BudgetRepository {
+	return &BudgetSqliteAdapter{
+		db:              db,
+		table:           budgetsTable,
+		categoriesTable: categoriesTable,
+		expensesTable:   expensesTable,
+		splitsTable:     expenseSplitsTable,
+		timeout:         prop.QueryTimeout,
+	}
+}
+
+func (r *BudgetSqliteAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.id = ?", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for budget... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *BudgetSqliteAdapter) FindByID(ctx context.Context, id int) (*model.Budget, error) {
+	query := fmt.Sprintf("SELECT id, category_id, period, limit_amount FROM %s WHERE id = ?", r.table)
+
+	budgets, err := r.find(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return nil, customErrors.NewItemNotFoundError("budget")
+	}
+	return &budgets[0], nil
+}
+
+func (r *BudgetSqliteAdapter) FindAll(ctx context.Context) ([]model.Budget, error) {
+	query := fmt.Sprintf("SELECT id, category_id, period, limit_amount FROM %s "+
+		"ORDER BY period, category_id", r.table)
+	return r.find(ctx, query)
+}
+
+func (r *BudgetSqliteAdapter) FindByPeriod(ctx context.Context, period string) ([]model.Budget, error) {
+	query := fmt.Sprintf("SELECT id, category_id, period, limit_amount FROM %s "+
+		"WHERE period = ? ORDER BY category_id", r.table)
+	return r.find(ctx, query, period)
+}
+
+func (r *BudgetSqliteAdapter) Save(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (category_id, period, limit_amount) "+
+		"VALUES(?, ?, ?) RETURNING id", r.table)
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, b.CategoryId, b.Period, int64(b.Limit)).Scan(&id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving budget... "), err)
+	}
+	b.Id = id
+	return b, nil
+}
+
+func (r *BudgetSqliteAdapter) Update(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET category_id=?, period=?, limit_amount=? WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, b.CategoryId, b.Period, int64(b.Limit), b.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating budget... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return b, nil
+}
+
+func (r *BudgetSqliteAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting budget... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+// Spent walks the category tree below categoryId with a recursive query so
+// expenses filed under subcategories count towards the budget. The walk uses
+// UNION so a category already in the tree isn't visited again, ending it on
+// a cycle in the hierarchy. The split lines in the tree count with the date
+// and currency of their expense.
+func (r *BudgetSqliteAdapter) Spent(ctx context.Context, categoryId int, from, to time.Time) ([]model.CurrencyTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("WITH RECURSIVE tree AS ("+
+		"SELECT c.id FROM %s c WHERE c.id = ? "+
+		"UNION "+
+		"SELECT c.id FROM %s c JOIN tree t ON c.parent_id = t.id"+
+		"), spent AS ("+
+		"SELECT e.created, e.currency, e.amount FROM %s e "+
+		"WHERE e.category_id IN (SELECT id FROM tree) AND e.deleted IS NULL "+
+		"UNION ALL "+
+		"SELECT e.created, e.currency, s.amount FROM %s s JOIN %s e ON e.id = s.expense_id "+
+		"WHERE s.category_id IN (SELECT id FROM tree) AND e.deleted IS NULL"+
+		") SELECT SUBSTR(created, 1, 10) AS day, currency, 0, SUM(amount) FROM spent "+
+		"WHERE created >= ? AND created < ? "+
+		"GROUP BY day, currency ORDER BY day, currency",
+		r.categoriesTable, r.categoriesTable, r.expensesTable, r.splitsTable, r.expensesTable)
+
+	res, err := r.db.QueryContext(ctx, query, categoryId, formatTimestamp(from), formatTimestamp(to))
+	if err != nil {
+		log.Println("error: error executing spent query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating budget spent... "), err)
+	}
+
+	spent := []model.CurrencyTotals{}
+
+	defer res.Close()
+	for res.Next() {
+		totals, err := scanCurrencyTotals(res)
+		if err != nil {
+			return nil, err
+		}
+		spent = append(spent, *totals)
+	}
+	return spent, nil
+}
+
+func (r *BudgetSqliteAdapter) find(ctx context.Context, query string, args ...any) ([]model.Budget, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for budgets... "), err)
+	}
+
+	budgets := []model.Budget{}
+
+	defer res.Close()
+	for res.Next() {
+		var id, categoryId int
+		var period string
+		var limit int64
+		if err := res.Scan(&id, &categoryId, &period, &limit); err != nil {
+			log.Println("error: error building budget item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building budget item... "), err)
+		}
+		budgets = append(budgets, model.Budget{
+			Id:         id,
+			CategoryId: categoryId,
+			Period:     period,
+			Limit:      model.Money(limit),
+		})
+	}
+
+	return budgets, nil
+}
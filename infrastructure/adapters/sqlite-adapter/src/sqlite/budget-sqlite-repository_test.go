@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+)
+
+func Test_budgetSqliteRepository(t *testing.T) {
+	db, props := newTestDB(t)
+	categories := NewCategorySqliteAdapter(props, db)
+	expenses := NewExpenseSqliteAdapter(props, db)
+	r := NewBudgetSqliteAdapter(props, db)
+	ctx := context.Background()
+
+	food, _ := categories.Save(ctx, &model.Category{Name: "food"})
+	groceries, _ := categories.Save(ctx, &model.Category{Name: "groceries", ParentId: food.Id})
+	rent, _ := categories.Save(ctx, &model.Category{Name: "rent"})
+	expenses.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: testDate, CategoryId: food.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 2500, Currency: "USD", Created: testDate, CategoryId: groceries.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 9000, Currency: "USD", Created: testDate, CategoryId: rent.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 700, Currency: "USD", Created: testDate.AddDate(0, 1, 0), CategoryId: food.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 400, Currency: "EUR", Created: testDate, CategoryId: groceries.Id})
+	expenses.Save(ctx, &model.Expense{Amount: 2000, Currency: "USD", Created: testDate, Splits: []model.ExpenseSplit{
+		{CategoryId: groceries.Id, Amount: 1500}, {CategoryId: rent.Id, Amount: 500},
+	}})
+	deleted, _ := expenses.Save(ctx, &model.Expense{Amount: 4000, Currency: "USD", Created: testDate, CategoryId: food.Id})
+	expenses.Delete(ctx, deleted.Id)
+	deletedSplit, _ := expenses.Save(ctx, &model.Expense{Amount: 800, Currency: "USD", Created: testDate, Splits: []model.ExpenseSplit{
+		{CategoryId: food.Id, Amount: 800},
+	}})
+	expenses.Delete(ctx, deletedSplit.Id)
+
+	budget, err := r.Save(ctx, &model.Budget{CategoryId: food.Id, Period: "2023-10", Limit: 50000})
+	if err != nil {
+		t.Fatalf("budgetSqliteRepository.Save() error = %v", err)
+	}
+	if _, err := r.Save(ctx, &model.Budget{CategoryId: food.Id, Period: "2023-10", Limit: 100}); err == nil {
+		t.Errorf("budgetSqliteRepository.Save() of a repeated period error = nil, want error")
+	}
+	got, _ := r.FindByPeriod(ctx, "2023-10")
+	if want := []model.Budget{*budget}; !reflect.DeepEqual(got, want) {
+		t.Errorf("budgetSqliteRepository.FindByPeriod() = %v, want %v", got, want)
+	}
+
+	spent, err := r.Spent(ctx, food.Id, testDate.AddDate(0, 0, -1), testDate.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("budgetSqliteRepository.Spent() error = %v", err)
+	}
+	day := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	want := []model.CurrencyTotals{{Date: day, Currency: "EUR", Expenses: 400}, {Date: day, Currency: "USD", Expenses: 5000}}
+	if !reflect.DeepEqual(spent, want) {
+		t.Errorf("budgetSqliteRepository.Spent() = %v, want %v", spent, want)
+	}
+
+	if err := categories.Delete(ctx, food.Id); err != nil {
+		t.Fatalf("categorySqliteRepository.Delete() error = %v", err)
+	}
+	if exists, _ := r.Exists(ctx, budget.Id); exists {
+		t.Errorf("budgetSqliteRepository.Exists() after deleting its category = %v, want %v", exists, false)
+	}
+}
+
+func Test_budgetSqliteRepository_Spent_cycle(t *testing.T) {
+	db, props := newTestDB(t)
+	categories := NewCategorySqliteAdapter(props, db)
+	expenses := NewExpenseSqliteAdapter(props, db)
+	ctx := context.Background()
+
+	food, _ := categories.Save(ctx, &model.Category{Name: "food"})
+	groceries, _ := categories.Save(ctx, &model.Category{Name: "groceries", ParentId: food.Id})
+	if _, err := db.Exec("UPDATE categories SET parent_id = ? WHERE id = ?", groceries.Id, food.Id); err != nil {
+		t.Fatalf("error making the hierarchy a cycle: %v", err)
+	}
+	expenses.Save(ctx, &model.Expense{Amount: 2500, Currency: "USD", Created: testDate, CategoryId: groceries.Id})
+
+	spent, err := NewBudgetSqliteAdapter(props, db).Spent(ctx, food.Id, testDate, testDate.AddDate(0, 0, 1))
+	day := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	want := []model.CurrencyTotals{{Date: day, Currency: "USD", Expenses: 2500}}
+	if err != nil || !reflect.DeepEqual(spent, want) {
+		t.Errorf("budgetSqliteRepository.Spent() on a cycle = %v, %v, want %v", spent, err, want)
+	}
+}
+
+func Test_balanceSqliteRepository(t *testing.T) {
+	db, props := newTestDB(t)
+	incomes := NewIncomeSqliteAdapter(props, db)
+	expenses := NewExpenseSqliteAdapter(props, db)
+	ctx := context.Background()
+
+	incomes.Save(ctx, &model.Income{Amount: 10000, Currency: "USD", Created: testDate.AddDate(0, 0, -1)})
+	incomes.Save(ctx, &model.Income{Amount: 4000, Currency: "EUR", Created: testDate.AddDate(0, 0, -1)})
+	incomes.Save(ctx, &model.Income{Amount: 5000, Currency: "USD", Created: testDate})
+	expenses.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: testDate})
+	expenses.Save(ctx, &model.Expense{Amount: 2000, Currency: "USD", Created: testDate.Add(time.Hour)})
+	expenses.Save(ctx, &model.Expense{Amount: 3000, Currency: "USD", Created: testDate.AddDate(0, 0, 2)})
+	expenses.Save(ctx, &model.Expense{Amount: 700, Currency: "EUR", Created: testDate.AddDate(0, 0, 2)})
+	deleted, _ := expenses.Save(ctx, &model.Expense{Amount: 9000, Currency: "USD", Created: testDate})
+	expenses.Delete(ctx, deleted.Id)
+
+	r := NewBalanceSqliteAdapter(props, db)
+	day := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	before, err := r.TotalsBefore(ctx, day, "USD")
+	wantBefore := []model.CurrencyTotals{
+		{Date: day.AddDate(0, 0, -1), Currency: "EUR", Income: 4000},
+		{Date: day.AddDate(0, 0, -1), Currency: "USD", Income: 10000},
+	}
+	if err != nil || !reflect.DeepEqual(before, wantBefore) {
+		t.Errorf("balanceSqliteRepository.TotalsBefore() = %v, %v, want %v", before, err, wantBefore)
+	}
+	before, err = r.TotalsBefore(ctx, day.AddDate(0, 0, 3), "USD")
+	wantBefore = []model.CurrencyTotals{
+		{Date: day.AddDate(0, 0, -1), Currency: "EUR", Income: 4000},
+		{Date: day.AddDate(0, 0, 2), Currency: "EUR", Expenses: 700},
+		{Date: day.AddDate(0, 0, 2), Currency: "USD", Income: 15000, Expenses: 6000},
+	}
+	if err != nil || !reflect.DeepEqual(before, wantBefore) {
+		t.Errorf("balanceSqliteRepository.TotalsBefore() of the reporting currency = %v, %v, want %v",
+			before, err, wantBefore)
+	}
+	daily, err := r.DailyTotals(ctx, day, day.AddDate(0, 0, 3))
+	want := []model.CurrencyTotals{
+		{Date: day, Currency: "USD", Income: 5000, Expenses: 3000},
+		{Date: day.AddDate(0, 0, 2), Currency: "EUR", Expenses: 700},
+		{Date: day.AddDate(0, 0, 2), Currency: "USD", Expenses: 3000},
+	}
+	if err != nil || !reflect.DeepEqual(daily, want) {
+		t.Errorf("balanceSqliteRepository.DailyTotals() = %v, %v, want %v", daily, err, want)
+	}
+
+	all, _ := incomes.FindAll(ctx)
+	if len(all) != 3 || !all[2].Created.Equal(testDate) || all[1].Currency != "EUR" {
+		t.Errorf("incomeSqliteRepository.FindAll() = %v, want 3 incomes", all)
+	}
+}
@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	exchangeRatesTable = "exchange_rates"
+)
+
+type ExchangeRateSqliteAdapter struct {
+	db      executor
+	table   string
+	timeout time.Duration
+}
+
+func NewExchangeRateSqliteAdapter(prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.ExchangeRateRepository {
+	return &ExchangeRateSqliteAdapter{
+		db:      db,
+		table:   exchangeRatesTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+// Save writes the rates in a transaction of their own, unless the adapter
+// already runs in one, so a file is stored whole or not at all.
+func (r *ExchangeRateSqliteAdapter) Save(ctx context.Context, rates []model.ExchangeRate) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	exec := r.db
+	var tx *sql.Tx
+	if db, ok := r.db.(*sql.DB); ok {
+		var err error
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			log.Println("error: error beginning transaction... ", err)
+			return errors.Join(fmt.Errorf("error: saving exchange rates... "), err)
+		}
+		defer tx.Rollback()
+		exec = tx
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (date, base, quote, rate) VALUES(?, ?, ?, ?) "+
+		"ON CONFLICT (base, quote, date) DO UPDATE SET rate = excluded.rate", r.table)
+	for _, rate := range rates {
+		_, err := exec.ExecContext(ctx, query, rate.Date.Format(dayLayout), rate.Base, rate.Quote, rate.Rate)
+		if err != nil {
+			log.Println("error: error executing insert query... ", err)
+			return errors.Join(fmt.Errorf("error: saving exchange rates... "), err)
+		}
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			log.Println("error: error committing transaction... ", err)
+			return errors.Join(fmt.Errorf("error: saving exchange rates... "), err)
+		}
+	}
+	return nil
+}
+
+func (r *ExchangeRateSqliteAdapter) FindEffective(ctx context.Context, base, quote string,
+	date time.Time) (*model.ExchangeRate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT date, base, quote, rate FROM %s "+
+		"WHERE base = ? AND quote = ? AND date <= ? ORDER BY date DESC LIMIT 1", r.table)
+
+	var day string
+	rate := &model.ExchangeRate{}
+	err := r.db.QueryRowContext(ctx, query, base, quote, date.Format(dayLayout)).
+		Scan(&day, &rate.Base, &rate.Quote, &rate.Rate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, customErrors.NewItemNotFoundError("exchange rate")
+	}
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for exchange rate... "), err)
+	}
+	if rate.Date, err = time.Parse(dayLayout, day); err != nil {
+		log.Println("error: error parsing exchange rate date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing exchange rate date... "), err)
+	}
+	return rate, nil
+}
+
+// FindInEffect reads the rates of the period with one query; the first is
+// the latest one on or before from.
+func (r *ExchangeRateSqliteAdapter) FindInEffect(ctx context.Context, base, quote string,
+	from, to time.Time) ([]model.ExchangeRate, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT date, base, quote, rate FROM %s "+
+		"WHERE base = ?1 AND quote = ?2 AND date <= ?4 AND date >= COALESCE("+
+		"(SELECT MAX(date) FROM %s WHERE base = ?1 AND quote = ?2 AND date <= ?3), ?3) ORDER BY date",
+		r.table, r.table)
+
+	rows, err := r.db.QueryContext(ctx, query, base, quote, from.Format(dayLayout), to.Format(dayLayout))
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for exchange rates... "), err)
+	}
+	defer rows.Close()
+
+	rates := []model.ExchangeRate{}
+	for rows.Next() {
+		var day string
+		rate := model.ExchangeRate{}
+		if err := rows.Scan(&day, &rate.Base, &rate.Quote, &rate.Rate); err != nil {
+			log.Println("error: error scanning exchange rate... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error searching for exchange rates... "), err)
+		}
+		if rate.Date, err = time.Parse(dayLayout, day); err != nil {
+			log.Println("error: error parsing exchange rate date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing exchange rate date... "), err)
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println("error: error reading exchange rates... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for exchange rates... "), err)
+	}
+	return rates, nil
+}
@@ -0,0 +1,487 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	expensesTable      = "expenses"
+	expenseSplitsTable = "expense_splits"
+)
+
+// expenseSortColumns whitelists the columns a listing can be sorted by.
+var expenseSortColumns = map[model.ExpenseSortField]string{
+	model.SortByCreated: "created",
+	model.SortByAmount:  "amount",
+	model.SortById:      "id",
+}
+
+type ExpenseSqliteAdapter struct {
+	db          executor
+	table       string
+	splitsTable string
+	timeout     time.Duration
+}
+
+func NewExpenseSqliteAdapter(
+	prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.ExpenseRepository {
+	return &ExpenseSqliteAdapter{
+		db:          db,
+		table:       expensesTable,
+		splitsTable: expenseSplitsTable,
+		timeout:     prop.QueryTimeout,
+	}
+}
+
+func (r *ExpenseSqliteAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.id = ? AND t.deleted IS NULL", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for expense... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *ExpenseSqliteAdapter) FindByID(ctx context.Context, id int) (*model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version FROM %s "+
+		"WHERE id = ? AND deleted IS NULL", r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for expense... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		expense, err := scanExpense(res)
+		if err != nil {
+			return nil, err
+		}
+		res.Close()
+		expenses := []model.Expense{*expense}
+		if err := r.findSplits(ctx, expenses); err != nil {
+			return nil, err
+		}
+		return &expenses[0], nil
+	}
+	if err := res.Err(); err != nil {
+		log.Println("error: error reading select result... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for expense... "), err)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("expense")
+}
+
+func (r *ExpenseSqliteAdapter) FindAll(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query, args, err := r.findAllQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for expenses... "), err)
+	}
+
+	expenses := []model.Expense{}
+
+	defer res.Close()
+	for res.Next() {
+		expense, err := scanExpense(res)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+	if err := res.Err(); err != nil {
+		log.Println("error: error reading select result... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for expenses... "), err)
+	}
+	res.Close()
+
+	if err := r.findSplits(ctx, expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *ExpenseSqliteAdapter) Save(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (amount, created, category_id, account_id, currency) "+
+		"VALUES(?, ?, ?, ?, ?) RETURNING id", r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, int64(e.Amount), formatTimestamp(e.Created),
+		nullableID(e.CategoryId), nullableID(e.AccountId), nullableString(e.Currency)).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving expense... "), err)
+	}
+	e.Id = id
+	if err := r.saveSplits(ctx, e); err != nil {
+		return nil, err
+	}
+	e.Version = 1
+	return e, nil
+}
+
+func (r *ExpenseSqliteAdapter) Update(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET amount=?, created=?, category_id=?, account_id=?, currency=?, "+
+		"version=version+1 WHERE id=? AND version=? AND deleted IS NULL", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, int64(e.Amount), formatTimestamp(e.Created),
+		nullableID(e.CategoryId), nullableID(e.AccountId), nullableString(e.Currency), e.Id, e.Version)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating expense... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		if exists, err := r.Exists(ctx, e.Id); err == nil && exists {
+			log.Printf("error: expense %d changed since version %d\n", e.Id, e.Version)
+			return nil, customErrors.NewConcurrentModificationError("expense")
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE expense_id=?", r.splitsTable)
+	if _, err := r.db.ExecContext(ctx, query, e.Id); err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating expense splits... "), err)
+	}
+	if err := r.saveSplits(ctx, e); err != nil {
+		return nil, err
+	}
+	e.Version++
+	return e, nil
+}
+
+func (r *ExpenseSqliteAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET deleted=? WHERE id=? AND deleted IS NULL", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, formatTimestamp(time.Now().UTC()), id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting expense... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *ExpenseSqliteAdapter) FindDeleted(ctx context.Context) ([]model.Expense, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version, deleted FROM %s "+
+		"WHERE deleted IS NOT NULL ORDER BY deleted DESC, id DESC", r.table)
+
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for deleted expenses... "), err)
+	}
+
+	expenses := []model.Expense{}
+
+	defer res.Close()
+	for res.Next() {
+		expense, err := scanExpense(res)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+	if err := res.Err(); err != nil {
+		log.Println("error: error reading select result... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for deleted expenses... "), err)
+	}
+	res.Close()
+
+	if err := r.findSplits(ctx, expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *ExpenseSqliteAdapter) Restore(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET deleted=NULL WHERE id=? AND deleted IS NOT NULL", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing restore query... ", err)
+		return errors.Join(fmt.Errorf("error: restoring expense... "), err)
+	}
+	nr, err := res.RowsAffected()
+	if err != nil {
+		log.Println("error: error reading restore result... ", err)
+		return errors.Join(fmt.Errorf("error: unknown restore operation result... "), err)
+	}
+	if nr == 0 {
+		return customErrors.NewItemNotFoundError("deleted expense")
+	}
+
+	return nil
+}
+
+func (r *ExpenseSqliteAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE deleted IS NOT NULL AND deleted < ?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, formatTimestamp(before.UTC()))
+	if err != nil {
+		log.Println("error: error executing purge query... ", err)
+		return 0, errors.Join(fmt.Errorf("error: purging deleted expenses... "), err)
+	}
+	nr, err := res.RowsAffected()
+	if err != nil {
+		log.Println("error: error reading purge result... ", err)
+		return 0, errors.Join(fmt.Errorf("error: unknown purge operation result... "), err)
+	}
+
+	return int(nr), nil
+}
+
+// findSplits fills in the splits of expenses with a single query, passing the
+// ids as a JSON array. The caller must have closed its own rows, since a
+// transaction holds a single connection.
+func (r *ExpenseSqliteAdapter) findSplits(ctx context.Context, expenses []model.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+	index := make(map[int]int, len(expenses))
+	ids := make([]int, 0, len(expenses))
+	for i, e := range expenses {
+		index[e.Id] = i
+		ids = append(ids, e.Id)
+	}
+	rawIds, err := json.Marshal(ids)
+	if err != nil {
+		return errors.Join(fmt.Errorf("error: error encoding expense ids... "), err)
+	}
+
+	query := fmt.Sprintf("SELECT expense_id, category_id, amount, note FROM %s "+
+		"WHERE expense_id IN (SELECT value FROM json_each(?)) ORDER BY expense_id, position", r.splitsTable)
+	res, err := r.db.QueryContext(ctx, query, string(rawIds))
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return errors.Join(fmt.Errorf("error: error searching for expense splits... "), err)
+	}
+
+	defer res.Close()
+	for res.Next() {
+		var expenseId int
+		var categoryId sql.NullInt64
+		var amount int64
+		var note string
+		if err := res.Scan(&expenseId, &categoryId, &amount, &note); err != nil {
+			log.Println("error: error building expense split item... ", err)
+			return errors.Join(fmt.Errorf("error: error building expense split item... "), err)
+		}
+		e := &expenses[index[expenseId]]
+		e.Splits = append(e.Splits, model.ExpenseSplit{
+			CategoryId: idFromNullable(categoryId),
+			Amount:     model.Money(amount),
+			Note:       note,
+		})
+	}
+	if err := res.Err(); err != nil {
+		log.Println("error: error reading select result... ", err)
+		return errors.Join(fmt.Errorf("error: error searching for expense splits... "), err)
+	}
+	return nil
+}
+
+// saveSplits stores the splits of e, numbered in their order.
+func (r *ExpenseSqliteAdapter) saveSplits(ctx context.Context, e *model.Expense) error {
+	query := fmt.Sprintf("INSERT INTO %s (expense_id, position, category_id, amount, note) "+
+		"VALUES(?, ?, ?, ?, ?)", r.splitsTable)
+	for i, split := range e.Splits {
+		_, err := r.db.ExecContext(ctx, query, e.Id, i+1, nullableID(split.CategoryId),
+			int64(split.Amount), split.Note)
+		if err != nil {
+			log.Println("error: error executing insert query... ", err)
+			return errors.Join(fmt.Errorf("error: saving expense splits... "), err)
+		}
+	}
+	return nil
+}
+
+// findAllQuery builds the parameterized select for q. Every value travels as
+// a query argument; only whitelisted column names are written into the SQL.
+func (r *ExpenseSqliteAdapter) findAllQuery(q model.ExpenseQuery) (string, []any, error) {
+	conditions := []string{"deleted IS NULL"}
+	args := []any{}
+
+	if !q.From.IsZero() {
+		conditions = append(conditions, "created >= ?")
+		args = append(args, formatTimestamp(q.From))
+	}
+	if !q.To.IsZero() {
+		conditions = append(conditions, "created < ?")
+		args = append(args, formatTimestamp(q.To))
+	}
+	if q.MinAmount != nil {
+		conditions = append(conditions, "amount >= ?")
+		args = append(args, int64(*q.MinAmount))
+	}
+	if q.MaxAmount != nil {
+		conditions = append(conditions, "amount <= ?")
+		args = append(args, int64(*q.MaxAmount))
+	}
+	if q.CategoryId != 0 {
+		conditions = append(conditions, fmt.Sprintf(
+			"(category_id = ? OR id IN (SELECT expense_id FROM %s WHERE category_id = ?))", r.splitsTable))
+		args = append(args, q.CategoryId, q.CategoryId)
+	}
+	if q.AccountId != 0 {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, q.AccountId)
+	}
+
+	column, ok := expenseSortColumns[q.SortBy]
+	if !ok {
+		column = expenseSortColumns[model.SortByCreated]
+	}
+	direction, comparison := "DESC", "<"
+	if q.Direction == model.SortAscending {
+		direction, comparison = "ASC", ">"
+	}
+
+	if q.After != nil {
+		if column == "id" {
+			conditions = append(conditions, fmt.Sprintf("id %s ?", comparison))
+			args = append(args, q.After.Id)
+		} else {
+			value, err := cursorValue(q.SortBy, q.After.Value)
+			if err != nil {
+				return "", nil, err
+			}
+			conditions = append(conditions, fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison))
+			args = append(args, value, q.After.Id)
+		}
+	}
+
+	query := fmt.Sprintf("SELECT id, amount, created, category_id, account_id, currency, version FROM %s WHERE %s",
+		r.table, strings.Join(conditions, " AND "))
+	if column == "id" {
+		query += fmt.Sprintf(" ORDER BY id %s", direction)
+	} else {
+		query += fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+	}
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	return query, args, nil
+}
+
+// cursorValue converts a cursor value to the stored form of the column it
+// is compared against.
+func cursorValue(field model.ExpenseSortField, value string) (any, error) {
+	if field == model.SortByAmount {
+		amount, err := model.ParseMoney(value)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("error: invalid cursor value %s... ", value), err)
+		}
+		return int64(amount), nil
+	}
+	created, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error: invalid cursor value %s... ", value), err)
+	}
+	return formatTimestamp(created), nil
+}
+
+// scanExpense reads a row of the expense columns, followed by the deleted
+// column when the query selects it.
+func scanExpense(res *sql.Rows) (*model.Expense, error) {
+	var id int
+	var amount int64
+	var createdDate string
+	var categoryId, accountId sql.NullInt64
+	var currency sql.NullString
+	var version int
+	var deletedDate sql.NullString
+	dest := []any{&id, &amount, &createdDate, &categoryId, &accountId, &currency, &version}
+	if columns, _ := res.Columns(); len(columns) > len(dest) {
+		dest = append(dest, &deletedDate)
+	}
+	if err := res.Scan(dest...); err != nil {
+		log.Println("error: error building expense item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building expense item... "), err)
+	}
+	date, err := parseTimestamp(createdDate)
+	if err != nil {
+		log.Println("error: error parsing created date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+	}
+	expense := &model.Expense{
+		Id:         id,
+		Amount:     model.Money(amount),
+		Created:    date,
+		CategoryId: idFromNullable(categoryId),
+		AccountId:  idFromNullable(accountId),
+		Currency:   currency.String,
+		Version:    version,
+	}
+	if deletedDate.Valid {
+		deleted, err := parseTimestamp(deletedDate.String)
+		if err != nil {
+			log.Println("error: error parsing deleted date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing deleted date... "), err)
+		}
+		expense.Deleted = &deleted
+	}
+	return expense, nil
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/porttest"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/migrations"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+var testDate = time.Date(2023, time.October, 1, 10, 30, 0, 0, time.UTC)
+
+// newTestDB opens a migrated database in a file removed when the test ends.
+func newTestDB(t *testing.T) (*sql.DB, sqliteconfig.SqliteConnectionProperties) {
+	t.Helper()
+	props := sqliteconfig.SqliteConnectionProperties{
+		Path:         filepath.Join(t.TempDir(), "budget.db"),
+		QueryTimeout: 5 * time.Second,
+	}
+	db := sqliteconfig.CreateSqlConnection(props)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.NewMigrator(db, "USD")
+	if err != nil {
+		t.Fatalf("error loading migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("error migrating database: %v", err)
+	}
+	return db, props
+}
+
+func Test_expenseSqliteRepository_Contract(t *testing.T) {
+	porttest.TestExpenseRepository(t, func(t *testing.T) port.ExpenseRepository {
+		db, props := newTestDB(t)
+		return NewExpenseSqliteAdapter(props, db)
+	})
+}
+
+func Test_auditSqliteRepository_Contract(t *testing.T) {
+	porttest.TestAuditRepository(t, func(t *testing.T) port.AuditRepository {
+		db, props := newTestDB(t)
+		return NewAuditSqliteAdapter(props, db)
+	})
+}
+
+func Test_importedTransactionSqliteRepository_Contract(t *testing.T) {
+	porttest.TestImportedTransactionRepository(t, func(t *testing.T) port.ImportedTransactionRepository {
+		db, props := newTestDB(t)
+		return NewImportedTransactionSqliteAdapter(props, db)
+	})
+}
+
+func Test_accountSqliteRepository_Contract(t *testing.T) {
+	porttest.TestAccountRepository(t, func(t *testing.T) port.AccountRepository {
+		db, props := newTestDB(t)
+		return NewAccountSqliteAdapter(props, db)
+	})
+}
+
+func Test_transferSqliteRepository_Contract(t *testing.T) {
+	porttest.TestTransferRepository(t, func(t *testing.T) (port.TransferRepository, port.AccountRepository) {
+		db, props := newTestDB(t)
+		return NewTransferSqliteAdapter(props, db), NewAccountSqliteAdapter(props, db)
+	})
+}
+
+func Test_exchangeRateSqliteRepository_Contract(t *testing.T) {
+	porttest.TestExchangeRateRepository(t, func(t *testing.T) port.ExchangeRateRepository {
+		db, props := newTestDB(t)
+		return NewExchangeRateSqliteAdapter(props, db)
+	})
+}
+
+func Test_recurringRuleSqliteRepository_Contract(t *testing.T) {
+	porttest.TestRecurringRuleRepository(t, func(t *testing.T) port.RecurringRuleRepository {
+		db, props := newTestDB(t)
+		return NewRecurringRuleSqliteAdapter(props, db)
+	})
+}
+
+func Test_expenseSqliteRepository_Category(t *testing.T) {
+	db, props := newTestDB(t)
+	ctx := context.Background()
+	categories := NewCategorySqliteAdapter(props, db)
+	r := NewExpenseSqliteAdapter(props, db)
+
+	food, _ := categories.Save(context.Background(), &model.Category{Name: "food"})
+	saved, err := r.Save(ctx, &model.Expense{Amount: 2530, Currency: "USD", Created: testDate, CategoryId: food.Id})
+	if err != nil {
+		t.Fatalf("expenseSqliteRepository.Save() error = %v", err)
+	}
+	if _, err := r.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: testDate, CategoryId: 99}); err == nil {
+		t.Errorf("expenseSqliteRepository.Save() with an unknown category error = nil, want error")
+	}
+
+	split, err := r.Save(ctx, &model.Expense{Amount: 3000, Currency: "USD", Created: testDate, Splits: []model.ExpenseSplit{
+		{CategoryId: food.Id, Amount: 2000, Note: "groceries"}, {Amount: 1000},
+	}})
+	if err != nil {
+		t.Fatalf("expenseSqliteRepository.Save() of a split expense error = %v", err)
+	}
+	if _, err := r.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: testDate, Splits: []model.ExpenseSplit{
+		{CategoryId: 99, Amount: 1000},
+	}}); err == nil {
+		t.Errorf("expenseSqliteRepository.Save() with a split in an unknown category error = nil, want error")
+	}
+
+	got, _ := r.FindAll(ctx, model.ExpenseQuery{CategoryId: food.Id})
+	if want := []model.Expense{*split, *saved}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expenseSqliteRepository.FindAll() = %v, want %v", got, want)
+	}
+
+	if err := categories.Delete(context.Background(), food.Id); err != nil {
+		t.Fatalf("categorySqliteRepository.Delete() error = %v", err)
+	}
+	if got, _ := r.FindByID(ctx, saved.Id); got.CategoryId != 0 {
+		t.Errorf("expenseSqliteRepository.FindByID() categoryId = %d, want %d", got.CategoryId, 0)
+	}
+	if got, _ := r.FindByID(ctx, split.Id); got.Splits[0].CategoryId != 0 {
+		t.Errorf("expenseSqliteRepository.FindByID() split categoryId = %d, want %d", got.Splits[0].CategoryId, 0)
+	}
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	importedTransactionsTable = "imported_transactions"
+)
+
+type ImportedTransactionSqliteAdapter struct {
+	db      executor
+	table   string
+	timeout time.Duration
+}
+
+func NewImportedTransactionSqliteAdapter(
+	prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.ImportedTransactionRepository {
+	return &ImportedTransactionSqliteAdapter{
+		db:      db,
+		table:   importedTransactionsTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *ImportedTransactionSqliteAdapter) Exists(ctx context.Context, account, externalId string) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.account = ? AND t.external_id = ?", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, account, externalId).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for imported transaction... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *ImportedTransactionSqliteAdapter) Save(ctx context.Context, t *model.ImportedTransaction) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (account, external_id, kind, record_id) VALUES(?, ?, ?, ?)", r.table)
+
+	if _, err := r.db.ExecContext(ctx, query, t.Account, t.ExternalId, string(t.Kind), t.Id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return errors.Join(fmt.Errorf("error: saving imported transaction... "), err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	incomesTable = "incomes"
+)
+
+type IncomeSqliteAdapter struct {
+	db      executor
+	table   string
+	timeout time.Duration
+}
+
+func NewIncomeSqliteAdapter(
+	prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.IncomeRepository {
+	return &IncomeSqliteAdapter{
+		db:      db,
+		table:   incomesTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *IncomeSqliteAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.id = ?", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for income... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *IncomeSqliteAdapter) FindByID(ctx context.Context, id int) (*model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, account_id, currency FROM %s WHERE id = ?", r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for income... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanIncome(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("income")
+}
+
+func (r *IncomeSqliteAdapter) FindAll(ctx context.Context) ([]model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, amount, created, account_id, currency FROM %s ORDER BY id", r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for incomes... "), err)
+	}
+
+	incomes := []model.Income{}
+
+	defer res.Close()
+	for res.Next() {
+		income, err := scanIncome(res)
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, *income)
+	}
+
+	return incomes, nil
+}
+
+func (r *IncomeSqliteAdapter) Save(ctx context.Context, i *model.Income) (*model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (amount, created, account_id, currency) VALUES(?, ?, ?, ?) RETURNING id",
+		r.table)
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, int64(i.Amount), formatTimestamp(i.Created),
+		nullableID(i.AccountId), nullableString(i.Currency)).Scan(&id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving income... "), err)
+	}
+	i.Id = id
+	return i, nil
+}
+
+func (r *IncomeSqliteAdapter) Update(ctx context.Context, i *model.Income) (*model.Income, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET amount=?, created=?, account_id=?, currency=? WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, int64(i.Amount), formatTimestamp(i.Created), nullableID(i.AccountId),
+		nullableString(i.Currency), i.Id)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating income... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return i, nil
+}
+
+func (r *IncomeSqliteAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting income... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func scanIncome(res *sql.Rows) (*model.Income, error) {
+	var id int
+	var amount int64
+	var createdDate string
+	var accountId sql.NullInt64
+	var currency sql.NullString
+	if err := res.Scan(&id, &amount, &createdDate, &accountId, &currency); err != nil {
+		log.Println("error: error building income item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building income item... "), err)
+	}
+	date, err := parseTimestamp(createdDate)
+	if err != nil {
+		log.Println("error: error parsing created date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+	}
+	return &model.Income{
+		Id:        id,
+		Amount:    model.Money(amount),
+		Created:   date,
+		AccountId: idFromNullable(accountId),
+		Currency:  currency.String,
+	}, nil
+}
@@ -0,0 +1,226 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	migrationsTable = "schema_migrations"
+	// currencyPlaceholder is replaced by the reporting currency, which rows
+	// created before currencies existed are in.
+	currencyPlaceholder = "${currency}"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+// fileName matches scripts named <version>_<name>.<up|down>.sql.
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9-]+)\.(up|down)\.sql$`)
+
+// currencyCode matches the ISO 4217 codes a script can be given.
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Migration is one versioned schema change with the scripts that apply and
+// revert it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Migrator applies the embedded migrations in version order and records each
+// applied version in the schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	currency   string
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator of the database. currency is the reporting
+// currency, given to the scripts that fill in the currency of older rows.
+func NewMigrator(db *sql.DB, currency string) (*Migrator, error) {
+	sqlFiles, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(db, currency, sqlFiles)
+}
+
+func newMigrator(db *sql.DB, currency string, files fs.FS) (*Migrator, error) {
+	if !currencyCode.MatchString(currency) {
+		return nil, fmt.Errorf("error: currency %q is not an ISO 4217 code... ", currency)
+	}
+	migrations, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, currency: currency, migrations: migrations}, nil
+}
+
+// Load reads the migration scripts found at the root of files. Every version
+// needs both an up and a down script, and versions can't be repeated.
+func Load(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		parts := fileName.FindStringSubmatch(path.Base(name))
+		if parts == nil {
+			return nil, fmt.Errorf("error: invalid migration file name %s... ", name)
+		}
+		version, _ := strconv.Atoi(parts[1])
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("error: reading migration %s... ", name), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != parts[2] {
+			return nil, fmt.Errorf("error: migration version %d is repeated... ", version)
+		}
+		if parts[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("error: migration %d needs both up and down scripts... ", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (m *Migrator) Up() (int, error) {
+	if err := m.createTable(); err != nil {
+		return 0, err
+	}
+	applied := 0
+	for _, migration := range m.migrations {
+		insert := fmt.Sprintf("INSERT INTO %s (version, name) VALUES(?, ?)", migrationsTable)
+		done, err := m.inTx(migration, true, migration.Up, insert, migration.Version, migration.Name)
+		if err != nil {
+			return applied, errors.Join(fmt.Errorf("error: applying migration %d... ", migration.Version), err)
+		}
+		if done {
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations and returns how many were
+// reverted.
+func (m *Migrator) Down(steps int) (int, error) {
+	if err := m.createTable(); err != nil {
+		return 0, err
+	}
+	reverted := 0
+	for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+		migration := m.migrations[i]
+		remove := fmt.Sprintf("DELETE FROM %s WHERE version=?", migrationsTable)
+		done, err := m.inTx(migration, false, migration.Down, remove, migration.Version)
+		if err != nil {
+			return reverted, errors.Join(fmt.Errorf("error: reverting migration %d... ", migration.Version), err)
+		}
+		if done {
+			reverted++
+		}
+	}
+	return reverted, nil
+}
+
+// Version returns the highest applied migration version, zero if none. It
+// only reads: a database without the schema_migrations table is at version 0.
+func (m *Migrator) Version() (int, error) {
+	var exists bool
+	query := "SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?"
+	if err := m.db.QueryRow(query, migrationsTable).Scan(&exists); err != nil {
+		log.Println("error: error executing select query... ", err)
+		return 0, errors.Join(fmt.Errorf("error: error searching for applied migrations... "), err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	query = fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s", migrationsTable)
+	if err := m.db.QueryRow(query).Scan(&version); err != nil {
+		log.Println("error: error executing select query... ", err)
+		return 0, errors.Join(fmt.Errorf("error: error searching for applied migrations... "), err)
+	}
+	return version, nil
+}
+
+func (m *Migrator) createTable() error {
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ("+
+		"version INTEGER PRIMARY KEY NOT NULL, "+
+		"name TEXT NOT NULL, "+
+		"applied TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP)", migrationsTable)
+	if _, err := m.db.Exec(create); err != nil {
+		log.Println("error: error creating migrations table... ", err)
+		return errors.Join(fmt.Errorf("error: error creating migrations table... "), err)
+	}
+	return nil
+}
+
+// inTx runs script and the bookkeeping statement in one transaction when
+// the migration is pending (up) or applied (!up), and reports whether it
+// ran. Transactions take the database write lock when they begin, so the
+// check can't race with another process migrating the same file.
+func (m *Migrator) inTx(migration Migration, up bool, script, bookkeeping string, args ...any) (bool, error) {
+	ctx := context.Background()
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(version) FROM %s WHERE version=?", migrationsTable)
+	if err := tx.QueryRowContext(ctx, query, migration.Version).Scan(&count); err != nil {
+		return false, errors.Join(err, tx.Rollback())
+	}
+	if (count > 0) == up {
+		return false, tx.Rollback()
+	}
+
+	action := "applying"
+	if !up {
+		action = "reverting"
+	}
+	log.Printf("info: %s migration %04d_%s... \n", action, migration.Version, migration.Name)
+	if _, err := tx.ExecContext(ctx, strings.ReplaceAll(script, currencyPlaceholder, m.currency)); err != nil {
+		return false, errors.Join(err, tx.Rollback())
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return false, errors.Join(err, tx.Rollback())
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,187 @@
+package migrations
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+var testFiles = fstest.MapFS{
+	"0001_create-expenses.up.sql":   {Data: []byte("CREATE TABLE expenses (id INTEGER PRIMARY KEY)")},
+	"0001_create-expenses.down.sql": {Data: []byte("DROP TABLE expenses")},
+	"0002_create-incomes.up.sql":    {Data: []byte("CREATE TABLE incomes (id INTEGER PRIMARY KEY)")},
+	"0002_create-incomes.down.sql":  {Data: []byte("DROP TABLE incomes")},
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := sqliteconfig.CreateSqlConnection(sqliteconfig.SqliteConnectionProperties{
+		Path: filepath.Join(t.TempDir(), "budget.db"),
+	})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, table string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).
+		Scan(&count)
+	if err != nil {
+		t.Fatalf("error reading tables: %v", err)
+	}
+	return count > 0
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	db := newTestDB(t)
+	migrator, err := NewMigrator(db, "USD")
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+	for _, table := range []string{"expenses", "incomes", "categories", "budgets"} {
+		if !tableExists(t, db, table) {
+			t.Errorf("Migrator.Up() didn't create table %s", table)
+		}
+	}
+	reverted, err := migrator.Down(len(migrator.migrations))
+	if err != nil || reverted != len(migrator.migrations) {
+		t.Errorf("Migrator.Down() = %d, %v, want %d", reverted, err, len(migrator.migrations))
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	db := newTestDB(t)
+	migrator, err := newMigrator(db, "USD", testFiles)
+	if err != nil {
+		t.Fatalf("newMigrator() error = %v", err)
+	}
+	if version, err := migrator.Version(); err != nil || version != 0 || tableExists(t, db, "schema_migrations") {
+		t.Errorf("Migrator.Version() of an empty database = %d, %v, want %d without creating tables", version, err, 0)
+	}
+
+	steps := []struct {
+		name   string
+		run    func() (int, error)
+		want   int
+		tables map[string]bool
+	}{
+		{
+			name:   "given an empty database, then apply every migration",
+			run:    migrator.Up,
+			want:   2,
+			tables: map[string]bool{"expenses": true, "incomes": true},
+		},
+		{
+			name:   "given every migration applied, then do nothing",
+			run:    migrator.Up,
+			want:   0,
+			tables: map[string]bool{"expenses": true, "incomes": true},
+		},
+		{
+			name:   "given a step back, then revert the last migration",
+			run:    func() (int, error) { return migrator.Down(1) },
+			want:   1,
+			tables: map[string]bool{"expenses": true, "incomes": false},
+		},
+	}
+	for _, step := range steps {
+		got, err := step.run()
+		if err != nil || got != step.want {
+			t.Errorf("%s: got %d, %v, want %d", step.name, got, err, step.want)
+		}
+		for table, want := range step.tables {
+			if tableExists(t, db, table) != want {
+				t.Errorf("%s: table %s exists = %v, want %v", step.name, table, !want, want)
+			}
+		}
+	}
+
+	if version, err := migrator.Version(); err != nil || version != 1 {
+		t.Errorf("Migrator.Version() = %d, %v, want %d", version, err, 1)
+	}
+}
+
+func TestMigratorFailingScript(t *testing.T) {
+	db := newTestDB(t)
+	files := fstest.MapFS{
+		"0001_broken.up.sql":   {Data: []byte("CREATE TABLE broken (id INTEGER PRIMARY KEY); INSERT INTO missing VALUES (1)")},
+		"0001_broken.down.sql": {Data: []byte("DROP TABLE broken")},
+	}
+	migrator, err := newMigrator(db, "USD", files)
+	if err != nil {
+		t.Fatalf("newMigrator() error = %v", err)
+	}
+	if _, err := migrator.Up(); err == nil {
+		t.Fatalf("Migrator.Up() error = nil, want error")
+	}
+	if tableExists(t, db, "broken") {
+		t.Errorf("Migrator.Up() left the table of a failed migration")
+	}
+	if version, _ := migrator.Version(); version != 0 {
+		t.Errorf("Migrator.Version() = %d, want %d", version, 0)
+	}
+}
+
+func TestCurrencyBackfill(t *testing.T) {
+	db := newTestDB(t)
+	migrator, err := NewMigrator(db, "COP")
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("Migrator.Down() error = %v", err)
+	}
+	seed := []string{
+		"INSERT INTO accounts (name, type, currency) VALUES ('Checking', 'bank', 'EUR')",
+		"INSERT INTO expenses (amount, created) VALUES (1000, '2023-04-15T00:00:00Z')",
+		"INSERT INTO expenses (amount, created, account_id) VALUES (2000, '2023-04-15T00:00:00Z', 1)",
+		"INSERT INTO incomes (amount, created, currency) VALUES (3000, '2023-04-15T00:00:00Z', 'USD')",
+	}
+	for _, query := range seed {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("error seeding %q: %v", query, err)
+		}
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+
+	rows, err := db.Query("SELECT currency FROM expenses ORDER BY id")
+	if err != nil {
+		t.Fatalf("error reading expenses: %v", err)
+	}
+	defer rows.Close()
+	got := []string{}
+	for rows.Next() {
+		var currency string
+		rows.Scan(&currency)
+		got = append(got, currency)
+	}
+	if want := []string{"COP", "EUR"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expenses currency = %v, want %v", got, want)
+	}
+	var income string
+	if err := db.QueryRow("SELECT currency FROM incomes").Scan(&income); err != nil || income != "USD" {
+		t.Errorf("incomes currency = %q, %v, want %q", income, err, "USD")
+	}
+	if _, err := db.Exec("INSERT INTO expenses (amount, created) VALUES (1000, '2023-04-15T00:00:00Z')"); err == nil {
+		t.Errorf("insert of an expense without a currency error = nil, want error")
+	}
+}
+
+func TestNewMigratorInvalidCurrency(t *testing.T) {
+	if _, err := newMigrator(newTestDB(t), "usd", testFiles); err == nil {
+		t.Errorf("newMigrator() with currency %q error = nil, want error", "usd")
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS budgets;
+DROP TABLE IF EXISTS incomes;
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,35 @@
+-- AUTOINCREMENT keeps ids from being reused after a delete, like the
+-- sequences of the Postgres schema. Dates are stored as
+-- YYYY-MM-DDTHH:MM:SSZ text, which sorts chronologically, and amounts as
+-- integer cents.
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    parent_id INTEGER REFERENCES categories (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    amount INTEGER NOT NULL,
+    created TEXT NOT NULL,
+    category_id INTEGER REFERENCES categories (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS incomes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    amount INTEGER NOT NULL,
+    created TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    category_id INTEGER NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    period TEXT NOT NULL,
+    limit_amount INTEGER NOT NULL,
+    UNIQUE (category_id, period)
+);
+
+CREATE INDEX IF NOT EXISTS expenses_created_id_idx ON expenses (created, id);
+CREATE INDEX IF NOT EXISTS expenses_amount_id_idx ON expenses (amount, id);
+CREATE INDEX IF NOT EXISTS expenses_category_id_idx ON expenses (category_id);
+CREATE INDEX IF NOT EXISTS incomes_created_idx ON incomes (created);
@@ -0,0 +1,2 @@
+-- Optimistic locking: every update bumps the version it was based on.
+ALTER TABLE expenses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
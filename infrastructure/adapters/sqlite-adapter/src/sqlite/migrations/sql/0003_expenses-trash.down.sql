@@ -0,0 +1,3 @@
+DELETE FROM expenses WHERE deleted IS NOT NULL;
+DROP INDEX IF EXISTS expenses_deleted_idx;
+ALTER TABLE expenses DROP COLUMN deleted;
@@ -0,0 +1,3 @@
+-- Deleted expenses stay in the trash until they are purged.
+ALTER TABLE expenses ADD COLUMN deleted TEXT;
+CREATE INDEX IF NOT EXISTS expenses_deleted_idx ON expenses (deleted);
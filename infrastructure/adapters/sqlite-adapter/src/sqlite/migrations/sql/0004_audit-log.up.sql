@@ -0,0 +1,13 @@
+-- Every change to a financial record, with who made it and JSON snapshots
+-- of the record before and after it.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    snapshot_before TEXT,
+    snapshot_after TEXT,
+    recorded TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity, entity_id, id);
@@ -0,0 +1,10 @@
+-- Bank transactions already imported, by the account and id the bank gave
+-- them, with the expense or income created for each.
+CREATE TABLE IF NOT EXISTS imported_transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    record_id INTEGER NOT NULL,
+    UNIQUE (account, external_id)
+);
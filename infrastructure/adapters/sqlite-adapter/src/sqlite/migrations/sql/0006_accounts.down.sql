@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS incomes_account_id_idx;
+ALTER TABLE incomes DROP COLUMN account_id;
+
+DROP INDEX IF EXISTS expenses_account_id_idx;
+ALTER TABLE expenses DROP COLUMN account_id;
+
+DROP TABLE IF EXISTS accounts;
@@ -0,0 +1,16 @@
+-- Wallets expenses and incomes belong to. Opening balances are integer
+-- cents like every other amount.
+CREATE TABLE IF NOT EXISTS accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    opening_balance INTEGER NOT NULL DEFAULT 0,
+    closed INTEGER NOT NULL DEFAULT 0
+);
+
+ALTER TABLE expenses ADD COLUMN account_id INTEGER REFERENCES accounts (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS expenses_account_id_idx ON expenses (account_id);
+
+ALTER TABLE incomes ADD COLUMN account_id INTEGER REFERENCES accounts (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS incomes_account_id_idx ON incomes (account_id);
@@ -0,0 +1,15 @@
+-- Money moved between two accounts. A transfer is a single row, so both of
+-- its sides are written or neither is; accounts with transfers can't be
+-- deleted.
+CREATE TABLE IF NOT EXISTS transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_account_id INTEGER NOT NULL REFERENCES accounts (id),
+    to_account_id INTEGER NOT NULL REFERENCES accounts (id),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    created TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX IF NOT EXISTS transfers_from_account_id_idx ON transfers (from_account_id);
+CREATE INDEX IF NOT EXISTS transfers_to_account_id_idx ON transfers (to_account_id);
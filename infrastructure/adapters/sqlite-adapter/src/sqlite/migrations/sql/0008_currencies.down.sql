@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE incomes DROP COLUMN currency;
+ALTER TABLE expenses DROP COLUMN currency;
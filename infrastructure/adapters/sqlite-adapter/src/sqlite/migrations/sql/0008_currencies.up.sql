@@ -0,0 +1,14 @@
+-- Amounts keep the ISO 4217 code of their currency. NULL, like on the rows
+-- created before currencies existed, means the reporting currency.
+ALTER TABLE expenses ADD COLUMN currency TEXT;
+ALTER TABLE incomes ADD COLUMN currency TEXT;
+
+-- What one unit of base is worth in quote from date on, until a later rate
+-- of the same pair. Dates are stored as YYYY-MM-DD text.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    date TEXT NOT NULL,
+    base TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    rate REAL NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, date)
+);
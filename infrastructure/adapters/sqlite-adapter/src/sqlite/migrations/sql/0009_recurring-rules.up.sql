@@ -0,0 +1,28 @@
+-- Rules creating the same expense or income on a schedule. day_of_month is
+-- zero when occurrences fall on the day of start_date. Dates are stored as
+-- YYYY-MM-DD text.
+CREATE TABLE IF NOT EXISTS recurring_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    currency TEXT,
+    category_id INTEGER REFERENCES categories (id) ON DELETE SET NULL,
+    account_id INTEGER REFERENCES accounts (id) ON DELETE SET NULL,
+    frequency TEXT NOT NULL,
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    day_of_month INTEGER NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    start_date TEXT NOT NULL,
+    end_date TEXT
+);
+
+-- The occurrences of a rule that were skipped, overridden or created. The
+-- primary key keeps an occurrence from being created twice.
+CREATE TABLE IF NOT EXISTS recurring_occurrences (
+    rule_id INTEGER NOT NULL REFERENCES recurring_rules (id) ON DELETE CASCADE,
+    date TEXT NOT NULL,
+    status TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    record_id INTEGER,
+    PRIMARY KEY (rule_id, date)
+);
@@ -0,0 +1,12 @@
+-- The lines a split expense is broken down into, in order. Their amounts
+-- add up to the amount of the expense, which has no category of its own.
+CREATE TABLE IF NOT EXISTS expense_splits (
+    expense_id INTEGER NOT NULL REFERENCES expenses (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    category_id INTEGER REFERENCES categories (id) ON DELETE SET NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    note TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (expense_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS expense_splits_category_id_idx ON expense_splits (category_id);
@@ -0,0 +1,11 @@
+CREATE TABLE exchange_rates_real (
+    date TEXT NOT NULL,
+    base TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    rate REAL NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, date)
+);
+INSERT INTO exchange_rates_real (date, base, quote, rate)
+    SELECT date, base, quote, rate / 100000000.0 FROM exchange_rates;
+DROP TABLE exchange_rates;
+ALTER TABLE exchange_rates_real RENAME TO exchange_rates;
@@ -0,0 +1,14 @@
+-- Rates are stored as integers in units of 10^-8, like amounts are in
+-- cents, so converting an amount gives the same cents on every database.
+CREATE TABLE exchange_rates_fixed_point (
+    date TEXT NOT NULL,
+    base TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    rate INTEGER NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, date)
+);
+INSERT INTO exchange_rates_fixed_point (date, base, quote, rate)
+    SELECT date, base, quote, CAST(ROUND(rate * 100000000) AS INTEGER) FROM exchange_rates
+    WHERE ROUND(rate * 100000000) > 0;
+DROP TABLE exchange_rates;
+ALTER TABLE exchange_rates_fixed_point RENAME TO exchange_rates;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS expenses_currency_insert;
+DROP TRIGGER IF EXISTS expenses_currency_update;
+DROP TRIGGER IF EXISTS incomes_currency_insert;
+DROP TRIGGER IF EXISTS incomes_currency_update;
+DROP TRIGGER IF EXISTS recurring_rules_currency_insert;
+DROP TRIGGER IF EXISTS recurring_rules_currency_update;
+DROP TRIGGER IF EXISTS transfers_currency_insert;
+DROP TRIGGER IF EXISTS transfers_currency_update;
+ALTER TABLE transfers DROP COLUMN currency;
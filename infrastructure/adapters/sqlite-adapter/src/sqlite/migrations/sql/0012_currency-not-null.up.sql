@@ -0,0 +1,66 @@
+-- Every amount keeps its currency. Rows created before currencies existed
+-- take the one of their account, or the reporting currency without one.
+-- SQLite can't make a column NOT NULL without rebuilding its table, which
+-- would cascade to the rows referencing it, so triggers reject NULL instead.
+ALTER TABLE transfers ADD COLUMN currency TEXT;
+UPDATE transfers SET currency = (SELECT a.currency FROM accounts a WHERE a.id = transfers.from_account_id);
+
+UPDATE expenses SET currency = COALESCE(
+    (SELECT a.currency FROM accounts a WHERE a.id = expenses.account_id), '${currency}')
+    WHERE currency IS NULL;
+
+UPDATE incomes SET currency = COALESCE(
+    (SELECT a.currency FROM accounts a WHERE a.id = incomes.account_id), '${currency}')
+    WHERE currency IS NULL;
+
+UPDATE recurring_rules SET currency = COALESCE(
+    (SELECT a.currency FROM accounts a WHERE a.id = recurring_rules.account_id), '${currency}')
+    WHERE currency IS NULL;
+
+CREATE TRIGGER IF NOT EXISTS expenses_currency_insert BEFORE INSERT ON expenses
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: expenses.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS expenses_currency_update BEFORE UPDATE ON expenses
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: expenses.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS incomes_currency_insert BEFORE INSERT ON incomes
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: incomes.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS incomes_currency_update BEFORE UPDATE ON incomes
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: incomes.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS recurring_rules_currency_insert BEFORE INSERT ON recurring_rules
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: recurring_rules.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS recurring_rules_currency_update BEFORE UPDATE ON recurring_rules
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: recurring_rules.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS transfers_currency_insert BEFORE INSERT ON transfers
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: transfers.currency');
+END;
+
+CREATE TRIGGER IF NOT EXISTS transfers_currency_update BEFORE UPDATE ON transfers
+    WHEN NEW.currency IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: transfers.currency');
+END;
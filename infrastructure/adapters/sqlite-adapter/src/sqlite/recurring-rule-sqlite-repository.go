@@ -0,0 +1,293 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	recurringRulesTable       = "recurring_rules"
+	recurringOccurrencesTable = "recurring_occurrences"
+
+	recurringRuleColumns = "id, kind, description, amount, currency, category_id, account_id, frequency, " +
+		"interval_count, day_of_month, start_date, end_date"
+)
+
+type RecurringRuleSqliteAdapter struct {
+	db               executor
+	table            string
+	occurrencesTable string
+	timeout          time.Duration
+}
+
+func NewRecurringRuleSqliteAdapter(prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.RecurringRuleRepository {
+	return &RecurringRuleSqliteAdapter{
+		db:               db,
+		table:            recurringRulesTable,
+		occurrencesTable: recurringOccurrencesTable,
+		timeout:          prop.QueryTimeout,
+	}
+}
+
+func (r *RecurringRuleSqliteAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.id = ?", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for recurring rule... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *RecurringRuleSqliteAdapter) FindByID(ctx context.Context, id int) (*model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = ?", recurringRuleColumns, r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for recurring rule... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanRecurringRule(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("recurring rule")
+}
+
+func (r *RecurringRuleSqliteAdapter) FindAll(ctx context.Context) ([]model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY id", recurringRuleColumns, r.table)
+	res, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for recurring rules... "), err)
+	}
+
+	rules := []model.RecurringRule{}
+
+	defer res.Close()
+	for res.Next() {
+		rule, err := scanRecurringRule(res)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func (r *RecurringRuleSqliteAdapter) Save(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (kind, description, amount, currency, category_id, account_id, "+
+		"frequency, interval_count, day_of_month, start_date, end_date) "+
+		"VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id", r.table)
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, recurringRuleArgs(rule)...).Scan(&id); err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving recurring rule... "), err)
+	}
+	rule.Id = id
+	return rule, nil
+}
+
+func (r *RecurringRuleSqliteAdapter) Update(ctx context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("UPDATE %s SET kind=?, description=?, amount=?, currency=?, category_id=?, "+
+		"account_id=?, frequency=?, interval_count=?, day_of_month=?, start_date=?, end_date=? WHERE id=?",
+		r.table)
+
+	res, err := r.db.ExecContext(ctx, query, append(recurringRuleArgs(rule), rule.Id)...)
+	if err != nil {
+		log.Println("error: error executing update query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: updating recurring rule... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading update result... ", err)
+			return nil, errors.Join(fmt.Errorf("error: unknown update operation result... "), err)
+		}
+		log.Printf("error: error executing update query... %d items updated\n", nr)
+		return nil, fmt.Errorf("error: 0 items updated on operation... ")
+	}
+	return rule, nil
+}
+
+// Delete removes the occurrences of the rule along with it, through the ON
+// DELETE CASCADE of their foreign key.
+func (r *RecurringRuleSqliteAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting recurring rule... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleSqliteAdapter) FindOccurrences(ctx context.Context, ruleId int,
+	from, to time.Time) ([]model.RecurringOccurrence, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT rule_id, date, status, amount, record_id FROM %s "+
+		"WHERE rule_id = ? AND date BETWEEN ? AND ? ORDER BY date", r.occurrencesTable)
+	res, err := r.db.QueryContext(ctx, query, ruleId, from.Format(dayLayout), to.Format(dayLayout))
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for occurrences... "), err)
+	}
+
+	occurrences := []model.RecurringOccurrence{}
+
+	defer res.Close()
+	for res.Next() {
+		var o model.RecurringOccurrence
+		var day string
+		var amount int64
+		var recordId sql.NullInt64
+		if err := res.Scan(&o.RuleId, &day, &o.Status, &amount, &recordId); err != nil {
+			log.Println("error: error building occurrence item... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error building occurrence item... "), err)
+		}
+		if o.Date, err = time.Parse(dayLayout, day); err != nil {
+			log.Println("error: error parsing occurrence date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing occurrence date... "), err)
+		}
+		o.Amount = model.Money(amount)
+		o.RecordId = idFromNullable(recordId)
+		occurrences = append(occurrences, o)
+	}
+
+	return occurrences, nil
+}
+
+func (r *RecurringRuleSqliteAdapter) LastCreated(ctx context.Context, ruleId int) (time.Time, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT MAX(o.date) FROM %[1]s o "+
+		"WHERE o.rule_id = ? AND o.status = 'created' AND NOT EXISTS ("+
+		"SELECT 1 FROM %[1]s p WHERE p.rule_id = o.rule_id AND p.status = 'overridden' AND p.date < o.date)",
+		r.occurrencesTable)
+	var day sql.NullString
+	if err := r.db.QueryRowContext(ctx, query, ruleId).Scan(&day); err != nil {
+		log.Println("error: error executing select query... ", err)
+		return time.Time{}, errors.Join(fmt.Errorf("error: error searching for the last created occurrence... "), err)
+	}
+	if !day.Valid {
+		return time.Time{}, nil
+	}
+	last, err := time.Parse(dayLayout, day.String)
+	if err != nil {
+		log.Println("error: error parsing occurrence date... ", err)
+		return time.Time{}, errors.Join(fmt.Errorf("error: error parsing occurrence date... "), err)
+	}
+	return last, nil
+}
+
+// SaveOccurrence upserts the occurrence, skipping the update when the stored
+// one was created.
+func (r *RecurringRuleSqliteAdapter) SaveOccurrence(ctx context.Context, o *model.RecurringOccurrence) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (rule_id, date, status, amount, record_id) VALUES(?, ?, ?, ?, ?) "+
+		"ON CONFLICT (rule_id, date) DO UPDATE "+
+		"SET status = excluded.status, amount = excluded.amount, record_id = excluded.record_id "+
+		"WHERE status <> 'created'", r.occurrencesTable)
+
+	res, err := r.db.ExecContext(ctx, query, o.RuleId, o.Date.Format(dayLayout), string(o.Status),
+		int64(o.Amount), nullableID(o.RecordId))
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return false, errors.Join(fmt.Errorf("error: saving occurrence... "), err)
+	}
+	nr, err := res.RowsAffected()
+	if err != nil {
+		log.Println("error: error reading insert result... ", err)
+		return false, errors.Join(fmt.Errorf("error: unknown insert operation result... "), err)
+	}
+	return nr > 0, nil
+}
+
+func recurringRuleArgs(rule *model.RecurringRule) []any {
+	var end sql.NullString
+	if rule.End != nil {
+		end = sql.NullString{String: rule.End.Format(dayLayout), Valid: true}
+	}
+	return []any{string(rule.Kind), rule.Description, int64(rule.Amount), nullableString(rule.Currency),
+		nullableID(rule.CategoryId), nullableID(rule.AccountId), string(rule.Frequency), rule.Interval,
+		rule.DayOfMonth, rule.Start.Format(dayLayout), end}
+}
+
+func scanRecurringRule(res *sql.Rows) (*model.RecurringRule, error) {
+	rule := &model.RecurringRule{}
+	var amount int64
+	var start string
+	var currency, end sql.NullString
+	var categoryId, accountId sql.NullInt64
+	err := res.Scan(&rule.Id, &rule.Kind, &rule.Description, &amount, &currency, &categoryId, &accountId,
+		&rule.Frequency, &rule.Interval, &rule.DayOfMonth, &start, &end)
+	if err != nil {
+		log.Println("error: error building recurring rule item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building recurring rule item... "), err)
+	}
+	if rule.Start, err = time.Parse(dayLayout, start); err != nil {
+		log.Println("error: error parsing start date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing start date... "), err)
+	}
+	if end.Valid {
+		date, err := time.Parse(dayLayout, end.String)
+		if err != nil {
+			log.Println("error: error parsing end date... ", err)
+			return nil, errors.Join(fmt.Errorf("error: error parsing end date... "), err)
+		}
+		rule.End = &date
+	}
+	rule.Amount = model.Money(amount)
+	rule.Currency = currency.String
+	rule.CategoryId = idFromNullable(categoryId)
+	rule.AccountId = idFromNullable(accountId)
+	return rule, nil
+}
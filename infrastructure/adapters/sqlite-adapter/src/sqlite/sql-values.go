@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// timestampLayout is how dates are stored: the wall clock to the second,
+// like the TIMESTAMP columns of the Postgres schema. The text sorts
+// chronologically, so dates are compared as strings.
+const timestampLayout = "2006-01-02T15:04:05Z"
+
+func formatTimestamp(t time.Time) string {
+	return t.Format(timestampLayout)
+}
+
+func parseTimestamp(raw string) (time.Time, error) {
+	return time.Parse(timestampLayout, raw)
+}
+
+// executor runs the statements of an adapter, either on the connection pool
+// or inside a transaction; both *sql.DB and *sql.Tx satisfy it.
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// nullableID maps the zero id used by the domain for "no reference" to a
+// NULL foreign key.
+func nullableID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
+
+func idFromNullable(id sql.NullInt64) int {
+	if !id.Valid {
+		return 0
+	}
+	return int(id.Int64)
+}
+
+// nullableString maps an empty string to NULL, so a record saved without a
+// currency is rejected by the database instead of stored as an empty one.
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// withTimeout bounds ctx by the configured query timeout, if any.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
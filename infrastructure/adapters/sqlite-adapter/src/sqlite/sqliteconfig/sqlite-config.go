@@ -0,0 +1,38 @@
+package sqliteconfig
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+type SqliteConnectionProperties struct {
+	// Path is the database file, created on first use.
+	Path string `yaml:"path"`
+	// QueryTimeout bounds every query run by the adapters that accept a
+	// context; zero means no timeout other than the caller's.
+	QueryTimeout time.Duration `yaml:"query-timeout"`
+}
+
+// CreateSqlConnection opens the database file with foreign keys enforced.
+// Writers wait for each other instead of failing with SQLITE_BUSY, and
+// transactions take the write lock when they begin.
+func CreateSqlConnection(properties SqliteConnectionProperties) *sql.DB {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"+
+		"&_pragma=journal_mode(WAL)&_txlock=immediate", properties.Path)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		log.Panic(errors.Join(fmt.Errorf("error creating database connection -> %v", err), err))
+	}
+
+	if err = db.Ping(); err != nil {
+		log.Fatal("cannot open database file... ", err)
+	}
+
+	return db
+}
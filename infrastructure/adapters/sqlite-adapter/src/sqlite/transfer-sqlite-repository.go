@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+const (
+	transfersTable = "transfers"
+)
+
+type TransferSqliteAdapter struct {
+	db      executor
+	table   string
+	timeout time.Duration
+}
+
+func NewTransferSqliteAdapter(prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.TransferRepository {
+	return &TransferSqliteAdapter{
+		db:      db,
+		table:   transfersTable,
+		timeout: prop.QueryTimeout,
+	}
+}
+
+func (r *TransferSqliteAdapter) Exists(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT COUNT(t.id) FROM %s t WHERE t.id = ?", r.table)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Println("error: error executing query... ", err)
+		return false, errors.Join(fmt.Errorf("error: error searching for transfer... "), err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *TransferSqliteAdapter) FindByID(ctx context.Context, id int) (*model.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, from_account_id, to_account_id, amount, currency, created, description "+
+		"FROM %s WHERE id = ?", r.table)
+
+	res, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for transfer... "), err)
+	}
+
+	defer res.Close()
+
+	if res.Next() {
+		return scanTransfer(res)
+	}
+
+	return nil, customErrors.NewItemNotFoundError("transfer")
+}
+
+func (r *TransferSqliteAdapter) FindAll(ctx context.Context, accountId int) ([]model.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT id, from_account_id, to_account_id, amount, currency, created, description "+
+		"FROM %s WHERE ?1 = 0 OR from_account_id = ?1 OR to_account_id = ?1 ORDER BY created, id", r.table)
+	res, err := r.db.QueryContext(ctx, query, accountId)
+	if err != nil {
+		log.Println("error: error executing select query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error searching for transfers... "), err)
+	}
+
+	transfers := []model.Transfer{}
+
+	defer res.Close()
+	for res.Next() {
+		transfer, err := scanTransfer(res)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	return transfers, nil
+}
+
+func (r *TransferSqliteAdapter) Save(ctx context.Context, t *model.Transfer) (*model.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("INSERT INTO %s (from_account_id, to_account_id, amount, currency, created, description) "+
+		"VALUES(?, ?, ?, ?, ?, ?) RETURNING id", r.table)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, query, t.FromAccountId, t.ToAccountId, int64(t.Amount),
+		t.Currency, formatTimestamp(t.Created), t.Description).Scan(&id)
+	if err != nil {
+		log.Println("error: error executing insert query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: saving transfer... "), err)
+	}
+	t.Id = id
+	return t, nil
+}
+
+func (r *TransferSqliteAdapter) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=?", r.table)
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println("error: error executing delete query... ", err)
+		return errors.Join(fmt.Errorf("error: deleting transfer... "), err)
+	}
+	if nr, err := res.RowsAffected(); err != nil || nr == 0 {
+		if err != nil {
+			log.Println("error: error reading delete result... ", err)
+			return errors.Join(fmt.Errorf("error: unknown delete operation result... "), err)
+		}
+		log.Printf("error: error executing delete query... %d items deleted\n", nr)
+		return fmt.Errorf("error: 0 items deleted on operation... ")
+	}
+
+	return nil
+}
+
+func (r *TransferSqliteAdapter) Totals(ctx context.Context, accountId int) (*model.TransferTotals, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := fmt.Sprintf("SELECT "+
+		"COALESCE(SUM(CASE WHEN t.to_account_id = ?1 THEN t.amount END), 0), "+
+		"COALESCE(SUM(CASE WHEN t.from_account_id = ?1 THEN t.amount END), 0) "+
+		"FROM %s t WHERE t.from_account_id = ?1 OR t.to_account_id = ?1", r.table)
+
+	var in, out int64
+	if err := r.db.QueryRowContext(ctx, query, accountId).Scan(&in, &out); err != nil {
+		log.Println("error: error executing totals query... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error calculating transfer totals... "), err)
+	}
+	return &model.TransferTotals{In: model.Money(in), Out: model.Money(out)}, nil
+}
+
+func scanTransfer(res *sql.Rows) (*model.Transfer, error) {
+	var id, fromAccountId, toAccountId int
+	var amount int64
+	var currency, createdDate, description string
+	err := res.Scan(&id, &fromAccountId, &toAccountId, &amount, &currency, &createdDate, &description)
+	if err != nil {
+		log.Println("error: error building transfer item... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error building transfer item... "), err)
+	}
+	date, err := parseTimestamp(createdDate)
+	if err != nil {
+		log.Println("error: error parsing created date... ", err)
+		return nil, errors.Join(fmt.Errorf("error: error parsing created date... "), err)
+	}
+	return &model.Transfer{
+		Id:            id,
+		FromAccountId: fromAccountId,
+		ToAccountId:   toAccountId,
+		Amount:        model.Money(amount),
+		Currency:      currency,
+		Created:       date,
+		Description:   description,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/infrastructure/adapters/sqlite-adapter/src/sqlite/sqliteconfig"
+)
+
+type SqliteUnitOfWork struct {
+	db   *sql.DB
+	prop sqliteconfig.SqliteConnectionProperties
+}
+
+func NewSqliteUnitOfWork(prop sqliteconfig.SqliteConnectionProperties, db *sql.DB) port.UnitOfWork {
+	return &SqliteUnitOfWork{db: db, prop: prop}
+}
+
+// Run begins a transaction, which takes the database write lock, and hands
+// fn repositories bound to it. The transaction is committed when fn
+// succeeds and rolled back when it fails or panics.
+func (u *SqliteUnitOfWork) Run(ctx context.Context, fn func(repos port.Repositories) error) error {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("error: error beginning transaction... ", err)
+		return errors.Join(fmt.Errorf("error: error beginning transaction... "), err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(u.repositories(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Println("error: error rolling back transaction... ", rbErr)
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("error: error committing transaction... ", err)
+		return errors.Join(fmt.Errorf("error: error committing transaction... "), err)
+	}
+	return nil
+}
+
+func (u *SqliteUnitOfWork) repositories(tx *sql.Tx) port.Repositories {
+	return port.Repositories{
+		Expenses: &ExpenseSqliteAdapter{
+			db:          tx,
+			table:       expensesTable,
+			splitsTable: expenseSplitsTable,
+			timeout:     u.prop.QueryTimeout,
+		},
+		Incomes: &IncomeSqliteAdapter{
+			db:      tx,
+			table:   incomesTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Categories: &CategorySqliteAdapter{
+			db:      tx,
+			table:   categoriesTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Budgets: &BudgetSqliteAdapter{
+			db:              tx,
+			table:           budgetsTable,
+			categoriesTable: categoriesTable,
+			expensesTable:   expensesTable,
+			splitsTable:     expenseSplitsTable,
+			timeout:         u.prop.QueryTimeout,
+		},
+		Audit: &AuditSqliteAdapter{
+			db:      tx,
+			table:   auditTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Imports: &ImportedTransactionSqliteAdapter{
+			db:      tx,
+			table:   importedTransactionsTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Accounts: &AccountSqliteAdapter{
+			db:            tx,
+			table:         accountsTable,
+			incomesTable:  incomesTable,
+			expensesTable: expensesTable,
+			timeout:       u.prop.QueryTimeout,
+		},
+		Transfers: &TransferSqliteAdapter{
+			db:      tx,
+			table:   transfersTable,
+			timeout: u.prop.QueryTimeout,
+		},
+		Recurring: &RecurringRuleSqliteAdapter{
+			db:               tx,
+			table:            recurringRulesTable,
+			occurrencesTable: recurringOccurrencesTable,
+			timeout:          u.prop.QueryTimeout,
+		},
+	}
+}
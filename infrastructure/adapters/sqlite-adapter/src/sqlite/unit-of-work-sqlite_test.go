@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+)
+
+func TestSqliteUnitOfWork_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func(ctx context.Context, repos port.Repositories) error
+		wantErr   bool
+		wantSaved int
+	}{
+		{
+			name: "given writes that succeed, then commit all of them",
+			fn: func(ctx context.Context, repos port.Repositories) error {
+				category, err := repos.Categories.Save(context.Background(), &model.Category{Name: "food"})
+				if err != nil {
+					return err
+				}
+				_, err = repos.Expenses.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: testDate, CategoryId: category.Id})
+				return err
+			},
+			wantSaved: 1,
+		},
+		{
+			name: "given a function that fails after writing, then roll back every write",
+			fn: func(ctx context.Context, repos port.Repositories) error {
+				if _, err := repos.Categories.Save(context.Background(), &model.Category{Name: "food"}); err != nil {
+					return err
+				}
+				if _, err := repos.Expenses.Save(ctx, &model.Expense{Amount: 1000, Currency: "USD", Created: testDate}); err != nil {
+					return err
+				}
+				return errors.ErrUnsupported
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, props := newTestDB(t)
+			ctx := context.Background()
+
+			err := NewSqliteUnitOfWork(props, db).Run(ctx, func(repos port.Repositories) error {
+				return tt.fn(ctx, repos)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SqliteUnitOfWork.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			expenses, _ := NewExpenseSqliteAdapter(props, db).FindAll(ctx, model.ExpenseQuery{})
+			categories, _ := NewCategorySqliteAdapter(props, db).FindAll(context.Background())
+			if len(expenses) != tt.wantSaved || len(categories) != tt.wantSaved {
+				t.Errorf("SqliteUnitOfWork.Run() stored %d expenses and %d categories, want %d",
+					len(expenses), len(categories), tt.wantSaved)
+			}
+		})
+	}
+}
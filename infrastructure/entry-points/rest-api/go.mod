@@ -0,0 +1,42 @@
+module github.com/enaldo1709/budget-manager/infrastructure/entry-points/rest-api
+
+go 1.21.1
+
+replace github.com/enaldo1709/budget-manager/domain/model => ../../../domain/model
+
+replace github.com/enaldo1709/budget-manager/domain/usecase => ../../../domain/usecase
+
+replace github.com/enaldo1709/budget-manager/helpers/errorutil => ../../../helpers/errorutil
+
+require (
+	github.com/enaldo1709/budget-manager/domain/model v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/domain/usecase v0.0.0-00010101000000-000000000000
+	github.com/enaldo1709/budget-manager/helpers/errorutil v0.0.0-00010101000000-000000000000
+	github.com/gin-gonic/gin v1.9.0
+	github.com/go-playground/validator/v10 v10.11.2
+)
+
+require (
+	github.com/bytedance/sonic v1.8.0 // indirect
+	github.com/chenzhuoyu/base64x v0.0.0-20221115062448-fe3a3abad311 // indirect
+	github.com/gin-contrib/sse v0.1.0 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/goccy/go-json v0.10.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
+	github.com/leodido/go-urn v1.2.1 // indirect
+	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/pelletier/go-toml/v2 v2.0.6 // indirect
+	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
+	github.com/ugorji/go/codec v1.2.9 // indirect
+	golang.org/x/arch v0.0.0-20210923205945-b76863e36670 // indirect
+	golang.org/x/crypto v0.5.0 // indirect
+	golang.org/x/net v0.7.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+	golang.org/x/text v0.7.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,118 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	accountsPath       = "/accounts"
+	accountBalancePath = "/balance"
+)
+
+type AccountHandler struct {
+	useCase usecase.AccountUseCase
+}
+
+func NewAccountHandler(uc usecase.AccountUseCase) *AccountHandler {
+	return &AccountHandler{useCase: uc}
+}
+
+func (h *AccountHandler) Register(router gin.IRouter) {
+	group := router.Group(accountsPath)
+	group.GET("", h.FindAll)
+	group.GET("/:"+idParam, h.FindByID)
+	group.GET("/:"+idParam+accountBalancePath, h.Balance)
+	group.POST("", h.Save)
+	group.PUT("/:"+idParam, h.Update)
+	group.DELETE("/:"+idParam, h.Delete)
+}
+
+func (h *AccountHandler) FindAll(ctx *gin.Context) {
+	accounts, err := h.useCase.FindAll(ctx.Request.Context())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, accounts)
+}
+
+func (h *AccountHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	account, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, account)
+}
+
+// Balance returns the opening balance of the account plus its incomes minus
+// its expenses.
+func (h *AccountHandler) Balance(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	balance, err := h.useCase.Balance(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, balance)
+}
+
+func (h *AccountHandler) Save(ctx *gin.Context) {
+	account := &model.Account{}
+	if err := ctx.ShouldBindJSON(account); err != nil {
+		abortWithError(ctx, bindingError(usecase.AccountName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), account)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (h *AccountHandler) Update(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	account := &model.Account{}
+	if err := ctx.ShouldBindJSON(account); err != nil {
+		abortWithError(ctx, bindingError(usecase.AccountName, err))
+		return
+	}
+	account.Id = id
+	updated, err := h.useCase.Update(ctx.Request.Context(), account)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *AccountHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,145 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestAccountHandler(t *testing.T) {
+	checking := model.Account{Id: 1, Name: "checking", Type: model.AccountBank, Currency: "USD", OpeningBalance: 10000}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repository port.AccountRepository
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "given a GET request, then get all accounts",
+			method: http.MethodGet,
+			path:   "/accounts",
+			repository: &mocks.AccountRepositoryMock{
+				FindAllFn: func(context.Context) ([]model.Account, error) {
+					return []model.Account{checking}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":1,"name":"checking","type":"bank","currency":"USD",` +
+				`"openingBalance":100.00,"closed":false}]`,
+		},
+		{
+			name:   "given a POST request, then save the account",
+			method: http.MethodPost,
+			path:   "/accounts",
+			body:   `{"name":" wallet ","type":"cash","currency":"eur","openingBalance":20}`,
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn: func(context.Context, int) (bool, error) { return false, nil },
+				SaveFn: func(_ context.Context, a *model.Account) (*model.Account, error) {
+					a.Id = 2
+					return a, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody: `{"id":2,"name":"wallet","type":"cash","currency":"EUR",` +
+				`"openingBalance":20.00,"closed":false}`,
+		},
+		{
+			name:       "given a POST request with an unknown type, then get bad request",
+			method:     http.MethodPost,
+			path:       "/accounts",
+			body:       `{"name":"wallet","type":"crypto","currency":"EUR"}`,
+			repository: &mocks.AccountRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a PUT request, then close the account",
+			method: http.MethodPut,
+			path:   "/accounts/1",
+			body:   `{"name":"checking","type":"bank","currency":"USD","openingBalance":100,"closed":true}`,
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn:   func(context.Context, int) (bool, error) { return true, nil },
+				FindByIDFn: func(context.Context, int) (*model.Account, error) { return &checking, nil },
+				UpdateFn:   func(_ context.Context, a *model.Account) (*model.Account, error) { return a, nil },
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"id":1,"name":"checking","type":"bank","currency":"USD",` +
+				`"openingBalance":100.00,"closed":true}`,
+		},
+		{
+			name:   "given a PUT request changing the currency of an account with records, then get bad request",
+			method: http.MethodPut,
+			path:   "/accounts/1",
+			body:   `{"name":"checking","type":"bank","currency":"EUR","openingBalance":100}`,
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn:     func(context.Context, int) (bool, error) { return true, nil },
+				FindByIDFn:   func(context.Context, int) (*model.Account, error) { return &checking, nil },
+				HasRecordsFn: func(context.Context, int) (bool, error) { return true, nil },
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ITEM","message":"account is invalid,account 1 has records in USD, ` +
+				`its currency can't change","details":["account 1 has records in USD, its currency can't change"]}`,
+		},
+		{
+			name:   "given a GET balance request, then get the account balance",
+			method: http.MethodGet,
+			path:   "/accounts/1/balance",
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn:   func(context.Context, int) (bool, error) { return true, nil },
+				FindByIDFn: func(context.Context, int) (*model.Account, error) { return &checking, nil },
+				TotalsFn: func(context.Context, int) (*model.BalanceTotals, error) {
+					return &model.BalanceTotals{Income: 5000, Expenses: 2550}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"accountId":1,"currency":"USD","openingBalance":100.00,` +
+				`"totalIncome":50.00,"totalExpenses":25.50,"transfersIn":0.00,"transfersOut":0.00,"balance":124.50}`,
+		},
+		{
+			name:   "given a GET balance request for a missing account, then get not found",
+			method: http.MethodGet,
+			path:   "/accounts/9/balance",
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn: func(context.Context, int) (bool, error) { return false, nil },
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "given a DELETE request, then delete the account",
+			method: http.MethodDelete,
+			path:   "/accounts/1",
+			repository: &mocks.AccountRepositoryMock{
+				ExistsFn: func(context.Context, int) (bool, error) { return true, nil },
+				DeleteFn: func(context.Context, int) error { return nil },
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(
+				NewAccountHandler(usecase.AccountUseCase{Repository: tt.repository}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("AccountHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("AccountHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
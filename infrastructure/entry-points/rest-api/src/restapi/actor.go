@@ -0,0 +1,47 @@
+package restapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	actorHeader = "X-Actor"
+	// unverifiedActorPrefix marks the actors taken from the X-Actor header,
+	// which any client can set, apart from authenticated identities.
+	unverifiedActorPrefix = "unverified:"
+	maxActorLength        = 64
+)
+
+// actorName limits the actor to letters, digits, spaces and the punctuation
+// found in user names and emails.
+var actorName = regexp.MustCompile(`^[\p{L}\p{N} ._@+-]+$`)
+
+// ActorHandler puts the actor named by the X-Actor header in the request
+// context, so the changes made by the request are audited under that name.
+// The header is advisory: it is recorded with the unverified: prefix, and
+// requests with a name that is too long or has other characters are
+// rejected.
+func ActorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		actor := strings.TrimSpace(ctx.GetHeader(actorHeader))
+		if actor == "" {
+			ctx.Next()
+			return
+		}
+		if len([]rune(actor)) > maxActorLength || !actorName.MatchString(actor) {
+			abortWithError(ctx, customErrors.NewInvalidItemError(actorHeader, fmt.Sprintf(
+				"header %s must have up to %d letters, digits, spaces or . _ @ + - characters",
+				actorHeader, maxActorLength)))
+			return
+		}
+		actor = unverifiedActorPrefix + actor
+		ctx.Request = ctx.Request.WithContext(model.WithActor(ctx.Request.Context(), actor))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	balancePath = "/balance"
+	fromParam   = "from"
+	toParam     = "to"
+	dateLayout  = "2006-01-02"
+)
+
+type BalanceHandler struct {
+	useCase usecase.BalanceUseCase
+}
+
+func NewBalanceHandler(uc usecase.BalanceUseCase) *BalanceHandler {
+	return &BalanceHandler{useCase: uc}
+}
+
+func (h *BalanceHandler) Register(router gin.IRouter) {
+	router.GET(balancePath, h.Calculate)
+}
+
+func (h *BalanceHandler) Calculate(ctx *gin.Context) {
+	from, err := queryDate(ctx, fromParam)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	to, err := queryDate(ctx, toParam)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	balance, err := h.useCase.Calculate(ctx.Request.Context(), from, to)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, balance)
+}
+
+func queryDate(ctx *gin.Context, param string) (time.Time, error) {
+	date, err := time.Parse(dateLayout, ctx.Query(param))
+	if err != nil {
+		return time.Time{}, customErrors.NewInvalidItemError(param,
+			fmt.Sprintf("query param %s must be a date in YYYY-MM-DD format", param))
+	}
+	return date, nil
+}
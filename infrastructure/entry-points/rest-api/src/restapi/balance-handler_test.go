@@ -0,0 +1,74 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestBalanceHandler(t *testing.T) {
+	repository := &mocks.BalanceRepositoryMock{
+		TotalsBeforeFn: func(ctx context.Context, date time.Time, currency string) ([]model.CurrencyTotals, error) {
+			return []model.CurrencyTotals{{Date: date.AddDate(0, 0, -1), Income: 10000}}, nil
+		},
+		DailyTotalsFn: func(ctx context.Context, from, to time.Time) ([]model.CurrencyTotals, error) {
+			return []model.CurrencyTotals{{Date: from, Income: 5000, Expenses: 3000}}, nil
+		},
+	}
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a date range, then get the balance",
+			path:       "/balance?from=2023-04-01&to=2023-04-01",
+			wantStatus: http.StatusOK,
+			wantBody: `{"from":"2023-04-01T00:00:00Z","to":"2023-04-01T00:00:00Z","currency":"USD",` +
+				`"openingBalance":100.00,"totalIncome":50.00,"totalExpenses":30.00,"closingBalance":120.00,` +
+				`"daily":[{"date":"2023-04-01T00:00:00Z","income":50.00,"expenses":30.00,"balance":120.00}]}`,
+		},
+		{
+			name:       "given a request without from, then get bad request",
+			path:       "/balance?to=2023-04-01",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given an invalid to date, then get bad request",
+			path:       "/balance?from=2023-04-01&to=04/30/2023",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given a from date after to, then get bad request",
+			path:       "/balance?from=2023-04-30&to=2023-04-01",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(
+				NewBalanceHandler(usecase.BalanceUseCase{
+					Repository: repository,
+					Converter:  usecase.CurrencyConverter{Currency: "USD"},
+				}).Register)
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("BalanceHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("BalanceHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
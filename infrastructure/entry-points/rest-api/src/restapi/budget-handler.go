@@ -0,0 +1,130 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	budgetsPath = "/budgets"
+	statusPath  = "/status"
+	periodParam = "period"
+)
+
+type BudgetHandler struct {
+	useCase usecase.BudgetUseCase
+}
+
+func NewBudgetHandler(uc usecase.BudgetUseCase) *BudgetHandler {
+	return &BudgetHandler{useCase: uc}
+}
+
+func (h *BudgetHandler) Register(router gin.IRouter) {
+	group := router.Group(budgetsPath)
+	group.GET("", h.FindAll)
+	group.GET("/:"+idParam, h.FindByID)
+	group.POST("", h.Save)
+	group.PUT("/:"+idParam, h.Update)
+	group.DELETE("/:"+idParam, h.Delete)
+	group.GET(statusPath, h.StatusByPeriod)
+	group.GET("/:"+idParam+statusPath, h.Status)
+}
+
+func (h *BudgetHandler) FindAll(ctx *gin.Context) {
+	budgets, err := h.useCase.FindAll(ctx.Request.Context())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, budgets)
+}
+
+func (h *BudgetHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	budget, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) Save(ctx *gin.Context) {
+	budget := &model.Budget{}
+	if err := ctx.ShouldBindJSON(budget); err != nil {
+		abortWithError(ctx, bindingError(usecase.BudgetName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), budget)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (h *BudgetHandler) Update(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	budget := &model.Budget{}
+	if err := ctx.ShouldBindJSON(budget); err != nil {
+		abortWithError(ctx, bindingError(usecase.BudgetName, err))
+		return
+	}
+	budget.Id = id
+	updated, err := h.useCase.Update(ctx.Request.Context(), budget)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *BudgetHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Status reports how much of the budget has been spent in its period.
+func (h *BudgetHandler) Status(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	status, err := h.useCase.Status(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, status)
+}
+
+// StatusByPeriod reports the status of every budget of the period query
+// param, or of the current period when it is missing.
+func (h *BudgetHandler) StatusByPeriod(ctx *gin.Context) {
+	statuses, err := h.useCase.StatusByPeriod(ctx.Request.Context(), ctx.Query(periodParam))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, statuses)
+}
@@ -0,0 +1,114 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestBudgetHandler(t *testing.T) {
+	budget := model.Budget{Id: 2, CategoryId: 1, Period: "2023-04", Limit: 40000}
+	categories := &mocks.CategoryRepositoryMock{
+		ExistsFn: func(ctx context.Context, i int) (bool, error) { return i == 1, nil },
+	}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repository port.BudgetRepository
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "given a POST request, then save the budget",
+			method: http.MethodPost,
+			path:   "/budgets",
+			body:   `{"categoryId":1,"period":"2023-04","limit":400}`,
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:       func(ctx context.Context, i int) (bool, error) { return false, nil },
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) { return []model.Budget{}, nil },
+				SaveFn: func(ctx context.Context, b *model.Budget) (*model.Budget, error) {
+					b.Id = 2
+					return b, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":2,"categoryId":1,"period":"2023-04","limit":400.00}`,
+		},
+		{
+			name:       "given a POST request without limit, then get bad request",
+			method:     http.MethodPost,
+			path:       "/budgets",
+			body:       `{"categoryId":1,"period":"2023-04"}`,
+			repository: &mocks.BudgetRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a GET status request, then get the budget status",
+			method: http.MethodGet,
+			path:   "/budgets/2/status",
+			repository: &mocks.BudgetRepositoryMock{
+				ExistsFn:   func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Budget, error) { return &budget, nil },
+				SpentFn: func(ctx context.Context, _ int, from, _ time.Time) ([]model.CurrencyTotals, error) {
+					return []model.CurrencyTotals{{Date: from, Expenses: 10000}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"id":2,"categoryId":1,"period":"2023-04","limit":400.00,` +
+				`"from":"2023-04-01T00:00:00Z","to":"2023-04-30T00:00:00Z",` +
+				`"spent":100.00,"remaining":300.00,"percentage":25}`,
+		},
+		{
+			name:   "given a GET status request for a period, then get the status of its budgets",
+			method: http.MethodGet,
+			path:   "/budgets/status?period=2023-04",
+			repository: &mocks.BudgetRepositoryMock{
+				FindByPeriodFn: func(context.Context, string) ([]model.Budget, error) { return []model.Budget{budget}, nil },
+				SpentFn: func(ctx context.Context, _ int, from, _ time.Time) ([]model.CurrencyTotals, error) {
+					return []model.CurrencyTotals{{Date: from, Expenses: 40000}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":2,"categoryId":1,"period":"2023-04","limit":400.00,` +
+				`"from":"2023-04-01T00:00:00Z","to":"2023-04-30T00:00:00Z",` +
+				`"spent":400.00,"remaining":0.00,"percentage":100}]`,
+		},
+		{
+			name:       "given a GET status request with an invalid period, then get bad request",
+			method:     http.MethodGet,
+			path:       "/budgets/status?period=april",
+			repository: &mocks.BudgetRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(NewBudgetHandler(usecase.BudgetUseCase{
+				Repository: tt.repository,
+				Categories: categories,
+			}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("BudgetHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("BudgetHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const categoriesPath = "/categories"
+
+type CategoryHandler struct {
+	useCase usecase.CategoryUseCase
+}
+
+func NewCategoryHandler(uc usecase.CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{useCase: uc}
+}
+
+func (h *CategoryHandler) Register(router gin.IRouter) {
+	group := router.Group(categoriesPath)
+	group.GET("", h.FindAll)
+	group.GET("/:"+idParam, h.FindByID)
+	group.POST("", h.Save)
+	group.PUT("/:"+idParam, h.Update)
+	group.DELETE("/:"+idParam, h.Delete)
+}
+
+func (h *CategoryHandler) FindAll(ctx *gin.Context) {
+	categories, err := h.useCase.FindAll(ctx.Request.Context())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, categories)
+}
+
+func (h *CategoryHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	category, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
+
+func (h *CategoryHandler) Save(ctx *gin.Context) {
+	category := &model.Category{}
+	if err := ctx.ShouldBindJSON(category); err != nil {
+		abortWithError(ctx, bindingError(usecase.CategoryName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), category)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (h *CategoryHandler) Update(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	category := &model.Category{}
+	if err := ctx.ShouldBindJSON(category); err != nil {
+		abortWithError(ctx, bindingError(usecase.CategoryName, err))
+		return
+	}
+	category.Id = id
+	updated, err := h.useCase.Update(ctx.Request.Context(), category)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *CategoryHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
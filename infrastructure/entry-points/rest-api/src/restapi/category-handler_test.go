@@ -0,0 +1,103 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestCategoryHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repository port.CategoryRepository
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "given a GET request, then get all categories",
+			method: http.MethodGet,
+			path:   "/categories",
+			repository: &mocks.CategoryRepositoryMock{
+				FindAllFn: func(ctx context.Context) ([]model.Category, error) {
+					return []model.Category{{Id: 1, Name: "food"}, {Id: 2, Name: "groceries", ParentId: 1}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"id":1,"name":"food"},{"id":2,"name":"groceries","parentId":1}]`,
+		},
+		{
+			name:   "given a POST request, then save the category",
+			method: http.MethodPost,
+			path:   "/categories",
+			body:   `{"name":"groceries","parentId":1}`,
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return i == 1, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Category, error) {
+					return &model.Category{Id: 1, Name: "food"}, nil
+				},
+				SaveFn: func(ctx context.Context, c *model.Category) (*model.Category, error) {
+					c.Id = 2
+					return c, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":2,"name":"groceries","parentId":1}`,
+		},
+		{
+			name:       "given a POST request without name, then get bad request",
+			method:     http.MethodPost,
+			path:       "/categories",
+			body:       `{"parentId":1}`,
+			repository: &mocks.CategoryRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a PUT request, when the parent is the category itself, then get bad request",
+			method: http.MethodPut,
+			path:   "/categories/2",
+			body:   `{"name":"groceries","parentId":2}`,
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a DELETE request, then delete the category",
+			method: http.MethodDelete,
+			path:   "/categories/2",
+			repository: &mocks.CategoryRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				DeleteFn: func(ctx context.Context, i int) error { return nil },
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(
+				NewCategoryHandler(usecase.CategoryUseCase{Repository: tt.repository}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("CategoryHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("CategoryHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
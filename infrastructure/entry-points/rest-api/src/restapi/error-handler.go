@@ -0,0 +1,105 @@
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	errorutil "github.com/enaldo1709/budget-manager/helpers/errorutil/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	ItemNotFoundCode           = "ITEM_NOT_FOUND"
+	InvalidItemCode            = "INVALID_ITEM"
+	ItemAlreadyExistsCode      = "ITEM_ALREADY_EXISTS"
+	ConcurrentModificationCode = "CONCURRENT_MODIFICATION"
+	FindItemErrorCode          = "FIND_ITEM_ERROR"
+	SaveItemErrorCode          = "SAVE_ITEM_ERROR"
+	UpdateItemErrorCode        = "UPDATE_ITEM_ERROR"
+	DeleteItemErrorCode        = "DELETE_ITEM_ERROR"
+	InternalErrorCode          = "INTERNAL_ERROR"
+
+	internalErrorMessage = "unexpected error processing the request"
+)
+
+// ErrorHandler writes the last error attached to the gin context as a
+// WebError JSON body once the handler chain has finished.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		webErr := ToWebError(ctx.Errors.Last().Err)
+		ctx.JSON(webErr.Code(), webErr.Body())
+	}
+}
+
+// ToWebError translates a domain error into the WebError sent to clients.
+// Unknown errors are logged and hidden behind a generic internal error.
+func ToWebError(err error) *errorutil.WebError {
+	var (
+		webErr   *errorutil.WebError
+		notFound *customErrors.ItemNotFound
+		invalid  *customErrors.InvalidItemError
+		exists   *customErrors.ItemAlreadyExistsError
+		modified *customErrors.ConcurrentModificationError
+		find     *customErrors.FindItemError
+		save     *customErrors.SaveItemError
+		update   *customErrors.UpdateItemError
+		deleted  *customErrors.DeleteItemError
+	)
+	switch {
+	case errors.As(err, &webErr):
+		return webErr
+	case errors.As(err, &notFound):
+		return newWebError(http.StatusNotFound, ItemNotFoundCode, err.Error())
+	case errors.As(err, &invalid):
+		return newWebError(http.StatusBadRequest, InvalidItemCode,
+			err.Error(), invalid.Details()...)
+	case errors.As(err, &exists):
+		return newWebError(http.StatusConflict, ItemAlreadyExistsCode, err.Error())
+	case errors.As(err, &modified):
+		return newWebError(http.StatusPreconditionFailed, ConcurrentModificationCode, err.Error())
+	case errors.As(err, &find):
+		return newWebError(http.StatusInternalServerError, FindItemErrorCode, err.Error())
+	case errors.As(err, &save):
+		return newWebError(http.StatusInternalServerError, SaveItemErrorCode, err.Error())
+	case errors.As(err, &update):
+		return newWebError(http.StatusInternalServerError, UpdateItemErrorCode, err.Error())
+	case errors.As(err, &deleted):
+		return newWebError(http.StatusInternalServerError, DeleteItemErrorCode, err.Error())
+	default:
+		log.Println("error: unexpected error handling request... ", err)
+		return newWebError(http.StatusInternalServerError, InternalErrorCode,
+			internalErrorMessage)
+	}
+}
+
+func abortWithError(ctx *gin.Context, err error) {
+	ctx.Error(err)
+	ctx.Abort()
+}
+
+func newWebError(code int, reason, message string, details ...string) *errorutil.WebError {
+	return errorutil.NewWebErrorWithDetails(code, reason, message, details...).(*errorutil.WebError)
+}
+
+// bindingError converts a request binding failure into an InvalidItemError
+// with one detail per failing field.
+func bindingError(item string, err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return customErrors.NewInvalidItemError(item, err.Error())
+	}
+	details := make([]string, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		details = append(details, fmt.Sprintf("field %s failed on the '%s' rule", fe.Field(), fe.Tag()))
+	}
+	return customErrors.NewInvalidItemError(item, details...)
+}
@@ -0,0 +1,115 @@
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	errorutil "github.com/enaldo1709/budget-manager/helpers/errorutil/errors"
+)
+
+func TestToWebError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantBody errorutil.WebErrorBody
+	}{
+		{
+			name:     "given an item not found error, then get a 404",
+			err:      customErrors.NewItemNotFoundError("expense"),
+			wantCode: http.StatusNotFound,
+			wantBody: errorutil.WebErrorBody{
+				Code: ItemNotFoundCode, Message: "expense not found", Details: []string{},
+			},
+		},
+		{
+			name:     "given an invalid item error, then get a 400 with details",
+			err:      customErrors.NewInvalidItemError("expense", "field Id must be a positive integer"),
+			wantCode: http.StatusBadRequest,
+			wantBody: errorutil.WebErrorBody{
+				Code:    InvalidItemCode,
+				Message: "expense is invalid,field Id must be a positive integer",
+				Details: []string{"field Id must be a positive integer"},
+			},
+		},
+		{
+			name:     "given an item already exists error, then get a 409",
+			err:      customErrors.NewItemAlreadyExistsError("expense"),
+			wantCode: http.StatusConflict,
+			wantBody: errorutil.WebErrorBody{
+				Code: ItemAlreadyExistsCode, Message: "expense already exists", Details: []string{},
+			},
+		},
+		{
+			name:     "given a concurrent modification error, then get a 412",
+			err:      customErrors.NewConcurrentModificationError("expense"),
+			wantCode: http.StatusPreconditionFailed,
+			wantBody: errorutil.WebErrorBody{
+				Code: ConcurrentModificationCode, Message: "expense was modified by another request", Details: []string{},
+			},
+		},
+		{
+			name:     "given a find item error, then get a 500",
+			err:      customErrors.NewFindItemError("expense"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: errorutil.WebErrorBody{
+				Code: FindItemErrorCode, Message: "error searching for expense", Details: []string{},
+			},
+		},
+		{
+			name:     "given a save item error, then get a 500",
+			err:      customErrors.NewSaveItemError("expense"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: errorutil.WebErrorBody{
+				Code: SaveItemErrorCode, Message: "error saving expense", Details: []string{},
+			},
+		},
+		{
+			name:     "given an update item error, then get a 500",
+			err:      customErrors.NewUpdateItemError("expense"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: errorutil.WebErrorBody{
+				Code: UpdateItemErrorCode, Message: "error updating expense", Details: []string{},
+			},
+		},
+		{
+			name:     "given a wrapped delete item error, then get a 500",
+			err:      fmt.Errorf("wrapped: %w", customErrors.NewDeleteItemError("expense")),
+			wantCode: http.StatusInternalServerError,
+			wantBody: errorutil.WebErrorBody{
+				Code: DeleteItemErrorCode, Message: "wrapped: error deleting expense", Details: []string{},
+			},
+		},
+		{
+			name:     "given an unknown error, then get a generic 500",
+			err:      errors.ErrUnsupported,
+			wantCode: http.StatusInternalServerError,
+			wantBody: errorutil.WebErrorBody{
+				Code: InternalErrorCode, Message: internalErrorMessage, Details: []string{},
+			},
+		},
+		{
+			name:     "given a web error, then keep it",
+			err:      errorutil.NewWebError(http.StatusTeapot, "tea"),
+			wantCode: http.StatusTeapot,
+			wantBody: errorutil.WebErrorBody{
+				Code: "I'M_A_TEAPOT", Message: "tea", Details: []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToWebError(tt.err)
+			if got.Code() != tt.wantCode {
+				t.Errorf("ToWebError().Code() = %d, want %d", got.Code(), tt.wantCode)
+			}
+			if !reflect.DeepEqual(got.Body(), tt.wantBody) {
+				t.Errorf("ToWebError().Body() = %v, want %v", got.Body(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package restapi
+
+import (
+	"strconv"
+	"strings"
+
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	etagHeader    = "ETag"
+	ifMatchHeader = "If-Match"
+)
+
+// setETag exposes the version of an item as a strong entity tag so clients
+// can send it back in If-Match when they update it.
+func setETag(ctx *gin.Context, version int) {
+	if version > 0 {
+		ctx.Header(etagHeader, strconv.Quote(strconv.Itoa(version)))
+	}
+}
+
+// ifMatchVersion reads the version sent in the If-Match header. It returns
+// false when the header is missing or is the "*" wildcard, leaving the
+// version of the body in charge.
+func ifMatchVersion(ctx *gin.Context) (int, bool, error) {
+	value := strings.TrimSpace(ctx.GetHeader(ifMatchHeader))
+	if value == "" || value == "*" {
+		return 0, false, nil
+	}
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, false, customErrors.NewInvalidItemError(ifMatchHeader, "must be a strong entity tag")
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, false, customErrors.NewInvalidItemError(ifMatchHeader, "must hold a positive version")
+	}
+	return version, true, nil
+}
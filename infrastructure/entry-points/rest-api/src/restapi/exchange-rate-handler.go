@@ -0,0 +1,89 @@
+package restapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exchangeRatesPath = "/exchange-rates"
+	ecbImportPath     = "/ecb"
+	baseParam         = "base"
+	quoteParam        = "quote"
+	dateParam         = "date"
+)
+
+type ExchangeRateHandler struct {
+	useCase usecase.ExchangeRateUseCase
+}
+
+func NewExchangeRateHandler(uc usecase.ExchangeRateUseCase) *ExchangeRateHandler {
+	return &ExchangeRateHandler{useCase: uc}
+}
+
+func (h *ExchangeRateHandler) Register(router gin.IRouter) {
+	group := router.Group(exchangeRatesPath)
+	group.GET("", h.FindEffective)
+	group.POST(csvImportPath, h.ImportCSV)
+	group.POST(ecbImportPath, h.ImportECB)
+}
+
+// FindEffective answers the rate of the base and quote query params in
+// effect on the date one, today when it is missing.
+func (h *ExchangeRateHandler) FindEffective(ctx *gin.Context) {
+	date := time.Now().UTC()
+	if ctx.Query(dateParam) != "" {
+		var err error
+		if date, err = queryDate(ctx, dateParam); err != nil {
+			abortWithError(ctx, err)
+			return
+		}
+	}
+	rate, err := h.useCase.FindEffective(ctx.Request.Context(), ctx.Query(baseParam), ctx.Query(quoteParam), date)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rate)
+}
+
+// ImportCSV loads the rates of the CSV file of the multipart "file" field,
+// which has a date, base, quote and rate column.
+func (h *ExchangeRateHandler) ImportCSV(ctx *gin.Context) {
+	h.importFile(ctx, "a CSV file is required in the file field", h.useCase.ImportCSV)
+}
+
+// ImportECB loads the rates of an ECB euro reference rates XML file, like
+// eurofxref-daily.xml or eurofxref-hist.xml, sent in the "file" field.
+func (h *ExchangeRateHandler) ImportECB(ctx *gin.Context) {
+	h.importFile(ctx, "an ECB XML file is required in the file field", h.useCase.ImportECB)
+}
+
+func (h *ExchangeRateHandler) importFile(ctx *gin.Context, missing string,
+	load func(ctx context.Context, r io.Reader) (*model.ExchangeRateImport, error)) {
+	header, err := ctx.FormFile(fileField)
+	if err != nil {
+		abortWithError(ctx, customErrors.NewInvalidItemError(usecase.ExchangeRateName, missing))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := load(ctx.Request.Context(), file)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,143 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestExchangeRateHandler_Import(t *testing.T) {
+	ecb := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" ` +
+		`xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">` +
+		`<Cube><Cube time="2023-04-03"><Cube currency="USD" rate="1.0867"/>` +
+		`<Cube currency="GBP" rate="0.87863"/></Cube></Cube></gesmes:Envelope>`
+	tests := []struct {
+		name       string
+		path       string
+		file       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a CSV file, then get the imported rates",
+			path:       "/exchange-rates/csv",
+			file:       "date,base,quote,rate\n2023-04-03,EUR,USD,1.0867\n",
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"imported":1}`,
+		},
+		{
+			name:       "given an ECB file, then get the imported rates",
+			path:       "/exchange-rates/ecb",
+			file:       ecb,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"imported":2}`,
+		},
+		{
+			name:       "given a CSV file with an invalid rate, then get bad request with its line",
+			path:       "/exchange-rates/csv",
+			file:       "date,base,quote,rate\n2023-04-03,EUR,USD,-1\n",
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ITEM","message":"exchange rate is invalid,` +
+				`line 2: rate \"-1\" must be a positive number with at most 8 decimal places",` +
+				`"details":["line 2: rate \"-1\" must be a positive number with at most 8 decimal places"]}`,
+		},
+		{
+			name:       "given a file that isn't ECB XML, then get bad request",
+			path:       "/exchange-rates/ecb",
+			file:       "date,base,quote,rate\n",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given no file, then get bad request",
+			path:       "/exchange-rates/csv",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(NewExchangeRateHandler(usecase.ExchangeRateUseCase{
+				Repository: &mocks.ExchangeRateRepositoryMock{
+					SaveFn: func(ctx context.Context, rates []model.ExchangeRate) error {
+						return nil
+					},
+				},
+			}).Register)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, newMultipartRequest(t, tt.path, nil, tt.file))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ExchangeRateHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("ExchangeRateHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestExchangeRateHandler_FindEffective(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a pair and a date, then get the rate in effect",
+			url:        "/exchange-rates?base=eur&quote=usd&date=2023-04-05",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"date":"2023-04-03T00:00:00Z","base":"EUR","quote":"USD","rate":1.0867}`,
+		},
+		{
+			name:       "given a pair without a known rate, then get not found",
+			url:        "/exchange-rates?base=EUR&quote=CHF&date=2023-04-05",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "given an invalid currency, then get bad request",
+			url:        "/exchange-rates?base=EURO&quote=USD",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given an invalid date, then get bad request",
+			url:        "/exchange-rates?base=EUR&quote=USD&date=05/04/2023",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(NewExchangeRateHandler(usecase.ExchangeRateUseCase{
+				Repository: &mocks.ExchangeRateRepositoryMock{
+					FindEffectiveFn: func(ctx context.Context, base, quote string,
+						date time.Time) (*model.ExchangeRate, error) {
+						if base != "EUR" || quote != "USD" {
+							return nil, customErrors.NewItemNotFoundError(usecase.ExchangeRateName)
+						}
+						return &model.ExchangeRate{
+							Date: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), Base: base, Quote: quote, Rate: 108670000,
+						}, nil
+					},
+				},
+			}).Register)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ExchangeRateHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("ExchangeRateHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	expensesPath = "/expenses"
+	trashPath    = "/trash"
+	restorePath  = "/restore"
+	historyPath  = "/history"
+)
+
+type ExpenseHandler struct {
+	useCase usecase.ExpenseUseCase
+}
+
+func NewExpenseHandler(uc usecase.ExpenseUseCase) *ExpenseHandler {
+	return &ExpenseHandler{useCase: uc}
+}
+
+func (h *ExpenseHandler) Register(router gin.IRouter) {
+	group := router.Group(expensesPath)
+	group.GET("", h.FindAll)
+	group.GET(trashPath, h.FindDeleted)
+	group.GET("/:"+idParam, h.FindByID)
+	group.POST("", h.Save)
+	group.PUT("/:"+idParam, h.Update)
+	group.DELETE("/:"+idParam, h.Delete)
+	group.POST("/:"+idParam+restorePath, h.Restore)
+	group.GET("/:"+idParam+historyPath, h.History)
+}
+
+// expensePageResponse adds to a page the link that fetches the next one.
+type expensePageResponse struct {
+	*model.ExpensePage
+	Next string `json:"next,omitempty"`
+}
+
+// FindAll lists expenses filtered by the from, to, minAmount, maxAmount,
+// categoryId and accountId query params, sorted by sort and direction, limit items at a
+// time. The next page is linked both in the body and in a Link header.
+func (h *ExpenseHandler) FindAll(ctx *gin.Context) {
+	query, err := expenseQuery(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	page, err := h.useCase.FindAll(ctx.Request.Context(), query)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	response := expensePageResponse{ExpensePage: page}
+	if page.NextCursor != "" {
+		response.Next = nextPageLink(ctx, page.NextCursor)
+		ctx.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", response.Next))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *ExpenseHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	expense, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	setETag(ctx, expense.Version)
+	ctx.JSON(http.StatusOK, expense)
+}
+
+func (h *ExpenseHandler) Save(ctx *gin.Context) {
+	expense := &model.Expense{}
+	if err := ctx.ShouldBindJSON(expense); err != nil {
+		abortWithError(ctx, bindingError(usecase.ExpenseName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), expense)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	setETag(ctx, saved.Version)
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+// Update replaces the expense if it is still at the version given in the
+// If-Match header, or in the body when the header is not sent. A stale
+// version gets a 412 Precondition Failed.
+func (h *ExpenseHandler) Update(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	expense := &model.Expense{}
+	if err := ctx.ShouldBindJSON(expense); err != nil {
+		abortWithError(ctx, bindingError(usecase.ExpenseName, err))
+		return
+	}
+	version, ok, err := ifMatchVersion(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if ok {
+		expense.Version = version
+	}
+	expense.Id = id
+	updated, err := h.useCase.Update(ctx.Request.Context(), expense)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	setETag(ctx, updated.Version)
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// Delete moves the expense to the trash.
+func (h *ExpenseHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// FindDeleted lists the expenses in the trash.
+func (h *ExpenseHandler) FindDeleted(ctx *gin.Context) {
+	expenses, err := h.useCase.FindDeleted(ctx.Request.Context())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, expenses)
+}
+
+// Restore takes the expense out of the trash.
+func (h *ExpenseHandler) Restore(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	expense, err := h.useCase.Restore(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	setETag(ctx, expense.Version)
+	ctx.JSON(http.StatusOK, expense)
+}
+
+// History lists the recorded changes of the expense, oldest first.
+func (h *ExpenseHandler) History(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	entries, err := h.useCase.History(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,451 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(register func(gin.IRouter)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ConfigureValidator()
+	router := gin.New()
+	router.Use(ErrorHandler(), ActorHandler())
+	register(router)
+	return router
+}
+
+func TestExpenseHandler(t *testing.T) {
+	created := time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		ifMatch    string
+		repository port.ExpenseRepository
+		wantStatus int
+		wantBody   string
+		wantETag   string
+	}{
+		{
+			name:   "given a GET request, then get all expenses",
+			method: http.MethodGet,
+			path:   "/expenses",
+			repository: &mocks.ExpenseRepositoryMock{
+				FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+					return []model.Expense{{Id: 1, Amount: 2530, Created: created}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"items":[{"id":1,"amount":25.30,"created":"2023-04-15T00:00:00Z"}]}`,
+		},
+		{
+			name:   "given a GET request with filters, then get a page with a link to the next one",
+			method: http.MethodGet,
+			path:   "/expenses?from=2023-04-01&to=2023-04-30&minAmount=10&categoryId=2&sort=amount&direction=asc&limit=1",
+			repository: &mocks.ExpenseRepositoryMock{
+				FindAllFn: func(ctx context.Context, q model.ExpenseQuery) ([]model.Expense, error) {
+					minAmount := model.Money(1000)
+					want := model.ExpenseQuery{
+						From:       time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+						To:         time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+						MinAmount:  &minAmount,
+						CategoryId: 2,
+						SortBy:     model.SortByAmount,
+						Direction:  model.SortAscending,
+						Limit:      2,
+					}
+					if !reflect.DeepEqual(q, want) {
+						t.Errorf("FindAll() query = %+v, want %+v", q, want)
+					}
+					return []model.Expense{
+						{Id: 1, Amount: 2530, Created: created, CategoryId: 2},
+						{Id: 4, Amount: 3000, Created: created, CategoryId: 2},
+					}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"items":[{"id":1,"amount":25.30,"created":"2023-04-15T00:00:00Z","categoryId":2}],` +
+				`"nextCursor":"eyJzIjoiYW1vdW50IiwidiI6IjI1LjMwIiwiaSI6MX0",` +
+				`"next":"/expenses?categoryId=2\u0026cursor=eyJzIjoiYW1vdW50IiwidiI6IjI1LjMwIiwiaSI6MX0` +
+				`\u0026direction=asc\u0026from=2023-04-01\u0026limit=1\u0026minAmount=10\u0026sort=amount\u0026to=2023-04-30"}`,
+		},
+		{
+			name:       "given a GET request with an invalid cursor, then get bad request",
+			method:     http.MethodGet,
+			path:       "/expenses?cursor=not-a-cursor",
+			repository: &mocks.ExpenseRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ITEM","message":"expense is invalid,query param cursor is not valid",` +
+				`"details":["query param cursor is not valid"]}`,
+		},
+		{
+			name:       "given a GET request with an unknown sort field, then get bad request",
+			method:     http.MethodGet,
+			path:       "/expenses?sort=category",
+			repository: &mocks.ExpenseRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a GET request with an id, then get the expense",
+			method: http.MethodGet,
+			path:   "/expenses/1",
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+					return &model.Expense{Id: i, Amount: 2530, Created: created, Version: 2}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"id":1,"amount":25.30,"created":"2023-04-15T00:00:00Z","version":2}`,
+			wantETag:   `"2"`,
+		},
+		{
+			name:   "given a GET request with an unknown id, then get not found",
+			method: http.MethodGet,
+			path:   "/expenses/1",
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code":"ITEM_NOT_FOUND","message":"expense not found","details":[]}`,
+		},
+		{
+			name:       "given a GET request with an invalid id, then get bad request",
+			method:     http.MethodGet,
+			path:       "/expenses/abc",
+			repository: &mocks.ExpenseRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a POST request, then save the expense",
+			method: http.MethodPost,
+			path:   "/expenses",
+			body:   `{"amount":100,"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+				SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					e.Id = 7
+					e.Version = 1
+					return e, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":7,"amount":100.00,"created":"2023-04-15T00:00:00Z","version":1}`,
+			wantETag:   `"1"`,
+		},
+		{
+			name:       "given a POST request without amount, then get bad request",
+			method:     http.MethodPost,
+			path:       "/expenses",
+			body:       `{"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.ExpenseRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ITEM","message":"expense is invalid,` +
+				`field Amount failed on the 'required' rule",` +
+				`"details":["field Amount failed on the 'required' rule"]}`,
+		},
+		{
+			name:   "given a POST request with split lines, then save them with the expense",
+			method: http.MethodPost,
+			path:   "/expenses",
+			body: `{"amount":100,"created":"2023-04-15T00:00:00Z",` +
+				`"splits":[{"amount":60,"note":"groceries"},{"amount":40}]}`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+				SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					e.Id = 7
+					e.Version = 1
+					return e, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody: `{"id":7,"amount":100.00,"created":"2023-04-15T00:00:00Z",` +
+				`"splits":[{"amount":60.00,"note":"groceries"},{"amount":40.00}],"version":1}`,
+			wantETag: `"1"`,
+		},
+		{
+			name:   "given a POST request with split lines not adding up to the amount, then get bad request",
+			method: http.MethodPost,
+			path:   "/expenses",
+			body: `{"amount":100,"created":"2023-04-15T00:00:00Z",` +
+				`"splits":[{"amount":60},{"amount":30}]}`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a POST request for an existing expense, then get conflict",
+			method: http.MethodPost,
+			path:   "/expenses",
+			body:   `{"id":1,"amount":100,"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "given a PUT request, then update the expense with the path id",
+			method: http.MethodPut,
+			path:   "/expenses/3",
+			body:   `{"id":9,"amount":50,"created":"2023-04-15T00:00:00Z","version":1}`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+					return &model.Expense{Id: i, Version: 1}, nil
+				},
+				UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					e.Version++
+					return e, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"id":3,"amount":50.00,"created":"2023-04-15T00:00:00Z","version":2}`,
+			wantETag:   `"2"`,
+		},
+		{
+			name:    "given a PUT request with If-Match, then update the version of the header",
+			method:  http.MethodPut,
+			path:    "/expenses/3",
+			body:    `{"amount":50,"created":"2023-04-15T00:00:00Z","version":1}`,
+			ifMatch: `"4"`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+					return &model.Expense{Id: i, Version: 1}, nil
+				},
+				UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					if e.Version != 4 {
+						t.Errorf("Update() version = %d, want %d", e.Version, 4)
+					}
+					e.Version++
+					return e, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"5"`,
+		},
+		{
+			name:       "given a PUT request without version, then get bad request",
+			method:     http.MethodPut,
+			path:       "/expenses/3",
+			body:       `{"amount":50,"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.ExpenseRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given a PUT request with an invalid If-Match, then get bad request",
+			method:     http.MethodPut,
+			path:       "/expenses/3",
+			body:       `{"amount":50,"created":"2023-04-15T00:00:00Z"}`,
+			ifMatch:    `W/"1"`,
+			repository: &mocks.ExpenseRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "given a PUT request with a stale version, then get precondition failed",
+			method:  http.MethodPut,
+			path:    "/expenses/3",
+			body:    `{"amount":50,"created":"2023-04-15T00:00:00Z"}`,
+			ifMatch: `"1"`,
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+					return &model.Expense{Id: i, Version: 1}, nil
+				},
+				UpdateFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+					return nil, customErrors.NewConcurrentModificationError("expense")
+				},
+			},
+			wantStatus: http.StatusPreconditionFailed,
+			wantBody: `{"code":"CONCURRENT_MODIFICATION",` +
+				`"message":"expense was modified by another request","details":[]}`,
+		},
+		{
+			name:   "given a DELETE request, then delete the expense",
+			method: http.MethodDelete,
+			path:   "/expenses/3",
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				DeleteFn: func(ctx context.Context, i int) error { return nil },
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "given a GET request for the trash, then get the deleted expenses",
+			method: http.MethodGet,
+			path:   "/expenses/trash",
+			repository: &mocks.ExpenseRepositoryMock{
+				FindDeletedFn: func(ctx context.Context) ([]model.Expense, error) {
+					deleted := time.Date(2023, 4, 20, 9, 0, 0, 0, time.UTC)
+					return []model.Expense{{Id: 3, Amount: 2530, Created: created, Version: 1, Deleted: &deleted}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":3,"amount":25.30,"created":"2023-04-15T00:00:00Z","version":1,` +
+				`"deleted":"2023-04-20T09:00:00Z"}]`,
+		},
+		{
+			name:   "given a POST restore request, then get the restored expense",
+			method: http.MethodPost,
+			path:   "/expenses/3/restore",
+			repository: &mocks.ExpenseRepositoryMock{
+				RestoreFn: func(ctx context.Context, i int) error { return nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+					return &model.Expense{Id: i, Amount: 2530, Created: created, Version: 1}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"id":3,"amount":25.30,"created":"2023-04-15T00:00:00Z","version":1}`,
+			wantETag:   `"1"`,
+		},
+		{
+			name:   "given a POST restore request for an expense out of the trash, then get not found",
+			method: http.MethodPost,
+			path:   "/expenses/3/restore",
+			repository: &mocks.ExpenseRepositoryMock{
+				RestoreFn: func(ctx context.Context, i int) error {
+					return customErrors.NewItemNotFoundError("deleted expense")
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "given a DELETE request, when the repository fails, then get internal error",
+			method: http.MethodDelete,
+			path:   "/expenses/3",
+			repository: &mocks.ExpenseRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				DeleteFn: func(ctx context.Context, i int) error { return errors.ErrUnsupported },
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(
+				NewExpenseHandler(usecase.ExpenseUseCase{Repository: tt.repository}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ExpenseHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("ExpenseHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ExpenseHandler ETag = %s, want %s", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+func TestExpenseHandler_Audit(t *testing.T) {
+	recorded := time.Date(2023, 4, 16, 9, 0, 0, 0, time.UTC)
+	var entries []model.AuditEntry
+	uc := usecase.ExpenseUseCase{
+		Repository: &mocks.ExpenseRepositoryMock{
+			ExistsFn: func(ctx context.Context, i int) (bool, error) { return i == 1, nil },
+			FindByIDFn: func(ctx context.Context, i int) (*model.Expense, error) {
+				return &model.Expense{Id: i, Amount: 2530, Version: 1}, nil
+			},
+			DeleteFn: func(ctx context.Context, i int) error { return nil },
+		},
+		Audit: &mocks.AuditRepositoryMock{
+			RecordFn: func(ctx context.Context, e *model.AuditEntry) error {
+				e.Id = len(entries) + 1
+				e.Recorded = recorded
+				entries = append(entries, *e)
+				return nil
+			},
+			FindByEntityFn: func(ctx context.Context, entity string, id int) ([]model.AuditEntry, error) {
+				return entries, nil
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		actor      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a DELETE request with an actor, then record the change under it",
+			method:     http.MethodDelete,
+			path:       "/expenses/1",
+			actor:      "ana",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "given a GET history request, then get the recorded changes",
+			method:     http.MethodGet,
+			path:       "/expenses/1/history",
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":1,"entity":"expense","entityId":1,"action":"delete","actor":"unverified:ana",` +
+				`"before":{"id":1,"amount":25.30,"created":"0001-01-01T00:00:00Z","version":1},` +
+				`"recorded":"2023-04-16T09:00:00Z"}]`,
+		},
+		{
+			name:       "given a GET history request with an invalid id, then get bad request",
+			method:     http.MethodGet,
+			path:       "/expenses/abc/history",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given an actor with invalid characters, then get bad request",
+			method:     http.MethodDelete,
+			path:       "/expenses/1",
+			actor:      "<script>",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given an actor that is too long, then get bad request",
+			method:     http.MethodDelete,
+			path:       "/expenses/1",
+			actor:      strings.Repeat("a", 65),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	router := newTestRouter(NewExpenseHandler(uc).Register)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.actor != "" {
+				req.Header.Set("X-Actor", tt.actor)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ExpenseHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("ExpenseHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package restapi
+
+import (
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	importsPath   = "/imports"
+	csvImportPath = "/csv"
+	ofxImportPath = "/ofx"
+	fileField     = "file"
+)
+
+type ImportHandler struct {
+	useCase usecase.ImportUseCase
+}
+
+func NewImportHandler(uc usecase.ImportUseCase) *ImportHandler {
+	return &ImportHandler{useCase: uc}
+}
+
+func (h *ImportHandler) Register(router gin.IRouter) {
+	group := router.Group(importsPath)
+	group.POST(csvImportPath, h.ImportCSV)
+	group.POST(ofxImportPath, h.ImportOFX)
+}
+
+// csvImportForm holds the column mapping sent along with the file.
+type csvImportForm struct {
+	Delimiter        string `form:"delimiter"`
+	DateColumn       string `form:"dateColumn"`
+	DateFormat       string `form:"dateFormat"`
+	DecimalSeparator string `form:"decimalSeparator"`
+	Sign             string `form:"sign"`
+	AmountColumn     string `form:"amountColumn"`
+	DebitColumn      string `form:"debitColumn"`
+	CreditColumn     string `form:"creditColumn"`
+	CurrencyColumn   string `form:"currencyColumn"`
+	AccountId        int    `form:"accountId"`
+	CategoryId       int    `form:"categoryId"`
+	DryRun           bool   `form:"dryRun"`
+}
+
+// ImportCSV loads the CSV file of the multipart "file" field as expenses and
+// incomes, laid out as the other fields describe, under the optional account
+// and, for expenses, category. A dry run answers 200 with
+// a preview and saves nothing; a real import answers 201.
+func (h *ImportHandler) ImportCSV(ctx *gin.Context) {
+	form := csvImportForm{}
+	if err := ctx.ShouldBind(&form); err != nil {
+		abortWithError(ctx, bindingError(usecase.CSVMappingName, err))
+		return
+	}
+	mapping := model.CSVMapping{
+		DateColumn:       form.DateColumn,
+		DateFormat:       form.DateFormat,
+		DecimalSeparator: form.DecimalSeparator,
+		Sign:             model.SignConvention(form.Sign),
+		AmountColumn:     form.AmountColumn,
+		DebitColumn:      form.DebitColumn,
+		CreditColumn:     form.CreditColumn,
+		CurrencyColumn:   form.CurrencyColumn,
+	}
+	if form.Delimiter != "" {
+		delimiter, size := utf8.DecodeRuneInString(form.Delimiter)
+		if size != len(form.Delimiter) {
+			abortWithError(ctx, customErrors.NewInvalidItemError(usecase.CSVMappingName,
+				"delimiter must be a single character"))
+			return
+		}
+		mapping.Delimiter = delimiter
+	}
+
+	header, err := ctx.FormFile(fileField)
+	if err != nil {
+		abortWithError(ctx, customErrors.NewInvalidItemError(usecase.ImportName,
+			"a CSV file is required in the file field"))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.useCase.ImportCSV(ctx.Request.Context(), file, mapping, form.target(), form.DryRun)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	respondImport(ctx, result)
+}
+
+// ofxImportForm holds the options sent along with an OFX file.
+type ofxImportForm struct {
+	AccountId  int  `form:"accountId"`
+	CategoryId int  `form:"categoryId"`
+	DryRun     bool `form:"dryRun"`
+}
+
+// target returns the account and category the records go under.
+func (f csvImportForm) target() model.ImportTarget {
+	return model.ImportTarget{AccountId: f.AccountId, CategoryId: f.CategoryId}
+}
+
+// target returns the account and category the records go under.
+func (f ofxImportForm) target() model.ImportTarget {
+	return model.ImportTarget{AccountId: f.AccountId, CategoryId: f.CategoryId}
+}
+
+// ImportOFX loads the OFX or QFX statement of the multipart "file" field,
+// under the optional account and, for expenses, category. Transactions
+// imported before are skipped and counted in the response.
+func (h *ImportHandler) ImportOFX(ctx *gin.Context) {
+	form := ofxImportForm{}
+	if err := ctx.ShouldBind(&form); err != nil {
+		abortWithError(ctx, bindingError(usecase.ImportName, err))
+		return
+	}
+
+	header, err := ctx.FormFile(fileField)
+	if err != nil {
+		abortWithError(ctx, customErrors.NewInvalidItemError(usecase.ImportName,
+			"an OFX file is required in the file field"))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.useCase.ImportOFX(ctx.Request.Context(), file, form.target(), form.DryRun)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	respondImport(ctx, result)
+}
+
+// respondImport answers 200 to a dry run and 201 to a real import.
+func respondImport(ctx *gin.Context, result *model.ImportResult) {
+	status := http.StatusCreated
+	if result.DryRun {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, result)
+}
@@ -0,0 +1,230 @@
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+// newMultipartRequest builds a POST to path with fields and, unless file is
+// empty, a file part.
+func newMultipartRequest(t *testing.T, path string, fields map[string]string, file string) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	if file != "" {
+		part, err := writer.CreateFormFile("file", "statement.csv")
+		if err != nil {
+			t.Fatalf("error creating file part: %v", err)
+		}
+		part.Write([]byte(file))
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImportHandler_ImportCSV(t *testing.T) {
+	file := "fecha;valor\n01/04/2023;-25,30\n02/04/2023;100\n"
+	mapping := map[string]string{
+		"delimiter": ";", "dateColumn": "fecha", "dateFormat": "DD/MM/YYYY",
+		"decimalSeparator": ",", "amountColumn": "valor",
+	}
+	with := func(fields map[string]string, extra ...string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range fields {
+			merged[k] = v
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			merged[extra[i]] = extra[i+1]
+		}
+		return merged
+	}
+	tests := []struct {
+		name       string
+		fields     map[string]string
+		file       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a dry run, then get a preview",
+			fields:     with(mapping, "dryRun", "true"),
+			file:       file,
+			wantStatus: http.StatusOK,
+			wantBody: `{"dryRun":true,"created":2,"skipped":0,"expenses":1,"incomes":1,"transactions":[` +
+				`{"line":2,"kind":"expense","amount":25.30,"currency":"USD","created":"2023-04-01T00:00:00Z"},` +
+				`{"line":3,"kind":"income","amount":100.00,"currency":"USD","created":"2023-04-02T00:00:00Z"}]}`,
+		},
+		{
+			name:       "given an import, then get the created records",
+			fields:     mapping,
+			file:       file,
+			wantStatus: http.StatusCreated,
+			wantBody: `{"dryRun":false,"created":2,"skipped":0,"expenses":1,"incomes":1,"transactions":[` +
+				`{"line":2,"kind":"expense","amount":25.30,"currency":"USD","created":"2023-04-01T00:00:00Z","id":4},` +
+				`{"line":3,"kind":"income","amount":100.00,"currency":"USD","created":"2023-04-02T00:00:00Z","id":9}]}`,
+		},
+		{
+			name:       "given a currency column, then take the currency of every row",
+			fields:     with(mapping, "currencyColumn", "moneda", "dryRun", "true"),
+			file:       "fecha;valor;moneda\n01/04/2023;-25,30;eur\n",
+			wantStatus: http.StatusOK,
+			wantBody: `{"dryRun":true,"created":1,"skipped":0,"expenses":1,"incomes":0,"transactions":[` +
+				`{"line":2,"kind":"expense","amount":25.30,"currency":"EUR","created":"2023-04-01T00:00:00Z"}]}`,
+		},
+		{
+			name:       "given an invalid row, then get bad request with its line",
+			fields:     mapping,
+			file:       "fecha;valor\n2023-04-01;-25,30\n",
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ITEM","message":"import is invalid,` +
+				`line 2: date \"2023-04-01\" does not match the format DD/MM/YYYY",` +
+				`"details":["line 2: date \"2023-04-01\" does not match the format DD/MM/YYYY"]}`,
+		},
+		{
+			name:       "given an unknown account, then get bad request",
+			fields:     with(mapping, "accountId", "3"),
+			file:       file,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code":"INVALID_ITEM","message":"import is invalid,account 3 does not exist","details":["account 3 does not exist"]}`,
+		},
+		{
+			name:       "given an account id that isn't a number, then get bad request",
+			fields:     with(mapping, "accountId", "cash"),
+			file:       file,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given a delimiter of several characters, then get bad request",
+			fields:     with(mapping, "delimiter", ";;"),
+			file:       file,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given no file, then get bad request",
+			fields:     mapping,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(NewImportHandler(usecase.ImportUseCase{
+				Currency: "USD",
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						e.Id = 4
+						return e, nil
+					},
+				},
+				Incomes: &mocks.IncomeRepositoryMock{
+					SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+						i.Id = 9
+						return i, nil
+					},
+				},
+				Accounts: &mocks.AccountRepositoryMock{
+					ExistsFn: func(ctx context.Context, id int) (bool, error) { return false, nil },
+				},
+			}).Register)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, newMultipartRequest(t, "/imports/csv", tt.fields, tt.file))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ImportHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("ImportHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestImportHandler_ImportOFX(t *testing.T) {
+	file := "OFXHEADER:100\nDATA:OFXSGML\n\n<OFX><BANKACCTFROM><ACCTID>1234</BANKACCTFROM>" +
+		"<STMTTRN><DTPOSTED>20230401<TRNAMT>-25.30<FITID>A-001</STMTTRN>" +
+		"<STMTTRN><DTPOSTED>20230402<TRNAMT>100<FITID>A-002</STMTTRN></OFX>"
+	tests := []struct {
+		name       string
+		fields     map[string]string
+		file       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a dry run, then get a preview skipping the known transactions",
+			fields:     map[string]string{"dryRun": "true"},
+			file:       file,
+			wantStatus: http.StatusOK,
+			wantBody: `{"dryRun":true,"created":1,"skipped":1,"expenses":1,"incomes":0,"transactions":[` +
+				`{"line":1,"kind":"expense","amount":25.30,"created":"2023-04-01T00:00:00Z",` +
+				`"account":"1234","externalId":"A-001"},` +
+				`{"line":2,"kind":"income","amount":100.00,"created":"2023-04-02T00:00:00Z",` +
+				`"account":"1234","externalId":"A-002","duplicate":true}]}`,
+		},
+		{
+			name:       "given an import, then get the created records",
+			file:       file,
+			wantStatus: http.StatusCreated,
+			wantBody: `{"dryRun":false,"created":1,"skipped":1,"expenses":1,"incomes":0,"transactions":[` +
+				`{"line":1,"kind":"expense","amount":25.30,"created":"2023-04-01T00:00:00Z",` +
+				`"account":"1234","externalId":"A-001","id":4},` +
+				`{"line":2,"kind":"income","amount":100.00,"created":"2023-04-02T00:00:00Z",` +
+				`"account":"1234","externalId":"A-002","duplicate":true}]}`,
+		},
+		{
+			name:       "given a file that isn't OFX, then get bad request",
+			file:       "date,amount\n2023-04-01,-1\n",
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ITEM","message":"import is invalid,file is not an OFX statement",` +
+				`"details":["file is not an OFX statement"]}`,
+		},
+		{
+			name:       "given no file, then get bad request",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(NewImportHandler(usecase.ImportUseCase{
+				Expenses: &mocks.ExpenseRepositoryMock{
+					SaveFn: func(ctx context.Context, e *model.Expense) (*model.Expense, error) {
+						e.Id = 4
+						return e, nil
+					},
+				},
+				Imports: &mocks.ImportedTransactionRepositoryMock{
+					ExistsFn: func(ctx context.Context, account, id string) (bool, error) {
+						return id == "A-002", nil
+					},
+					SaveFn: func(ctx context.Context, it *model.ImportedTransaction) error {
+						return nil
+					},
+				},
+			}).Register)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, newMultipartRequest(t, "/imports/ofx", tt.fields, tt.file))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ImportHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("ImportHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
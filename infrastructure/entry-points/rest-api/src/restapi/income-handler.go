@@ -0,0 +1,98 @@
+package restapi
+
+import (
+	"net/http"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const incomesPath = "/incomes"
+
+type IncomeHandler struct {
+	useCase usecase.IncomeUseCase
+}
+
+func NewIncomeHandler(uc usecase.IncomeUseCase) *IncomeHandler {
+	return &IncomeHandler{useCase: uc}
+}
+
+func (h *IncomeHandler) Register(router gin.IRouter) {
+	group := router.Group(incomesPath)
+	group.GET("", h.FindAll)
+	group.GET("/:"+idParam, h.FindByID)
+	group.POST("", h.Save)
+	group.PUT("/:"+idParam, h.Update)
+	group.DELETE("/:"+idParam, h.Delete)
+}
+
+func (h *IncomeHandler) FindAll(ctx *gin.Context) {
+	incomes, err := h.useCase.FindAll(ctx.Request.Context())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, incomes)
+}
+
+func (h *IncomeHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	income, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, income)
+}
+
+func (h *IncomeHandler) Save(ctx *gin.Context) {
+	income := &model.Income{}
+	if err := ctx.ShouldBindJSON(income); err != nil {
+		abortWithError(ctx, bindingError(usecase.IncomeName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), income)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (h *IncomeHandler) Update(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	income := &model.Income{}
+	if err := ctx.ShouldBindJSON(income); err != nil {
+		abortWithError(ctx, bindingError(usecase.IncomeName, err))
+		return
+	}
+	income.Id = id
+	updated, err := h.useCase.Update(ctx.Request.Context(), income)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *IncomeHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,109 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestIncomeHandler(t *testing.T) {
+	created := time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repository port.IncomeRepository
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "given a GET request, then get all incomes",
+			method: http.MethodGet,
+			path:   "/incomes",
+			repository: &mocks.IncomeRepositoryMock{
+				FindAllFn: func(ctx context.Context) ([]model.Income, error) {
+					return []model.Income{{Id: 1, Amount: 120000, Created: created}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"id":1,"amount":1200.00,"created":"2023-04-15T00:00:00Z"}]`,
+		},
+		{
+			name:   "given a GET request with an unknown id, then get not found",
+			method: http.MethodGet,
+			path:   "/incomes/1",
+			repository: &mocks.IncomeRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code":"ITEM_NOT_FOUND","message":"income not found","details":[]}`,
+		},
+		{
+			name:   "given a POST request, then save the income",
+			method: http.MethodPost,
+			path:   "/incomes",
+			body:   `{"amount":1200,"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.IncomeRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return false, nil },
+				SaveFn: func(ctx context.Context, i *model.Income) (*model.Income, error) {
+					i.Id = 4
+					return i, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":4,"amount":1200.00,"created":"2023-04-15T00:00:00Z"}`,
+		},
+		{
+			name:   "given a PUT request, then update the income with the path id",
+			method: http.MethodPut,
+			path:   "/incomes/2",
+			body:   `{"amount":900,"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.IncomeRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				FindByIDFn: func(ctx context.Context, i int) (*model.Income, error) {
+					return &model.Income{Id: i}, nil
+				},
+				UpdateFn: func(ctx context.Context, i *model.Income) (*model.Income, error) { return i, nil },
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"id":2,"amount":900.00,"created":"2023-04-15T00:00:00Z"}`,
+		},
+		{
+			name:   "given a DELETE request, then delete the income",
+			method: http.MethodDelete,
+			path:   "/incomes/2",
+			repository: &mocks.IncomeRepositoryMock{
+				ExistsFn: func(ctx context.Context, i int) (bool, error) { return true, nil },
+				DeleteFn: func(ctx context.Context, i int) error { return nil },
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(
+				NewIncomeHandler(usecase.IncomeUseCase{Repository: tt.repository}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("IncomeHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("IncomeHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
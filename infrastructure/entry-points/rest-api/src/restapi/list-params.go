@@ -0,0 +1,105 @@
+package restapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	minAmountParam  = "minAmount"
+	maxAmountParam  = "maxAmount"
+	categoryIdParam = "categoryId"
+	accountIdParam  = "accountId"
+	sortParam       = "sort"
+	directionParam  = "direction"
+	limitParam      = "limit"
+	cursorParam     = "cursor"
+)
+
+// expenseQuery reads the listing query params of the request. Dates are
+// days, and to is inclusive like in the balance endpoint.
+func expenseQuery(ctx *gin.Context) (model.ExpenseQuery, error) {
+	query := model.ExpenseQuery{
+		SortBy:    model.ExpenseSortField(ctx.Query(sortParam)),
+		Direction: model.SortDirection(ctx.Query(directionParam)),
+	}
+	details := []string{}
+
+	if ctx.Query(fromParam) != "" {
+		from, err := queryDate(ctx, fromParam)
+		if err != nil {
+			details = append(details, fmt.Sprintf("query param %s must be a date in YYYY-MM-DD format", fromParam))
+		}
+		query.From = from
+	}
+	if ctx.Query(toParam) != "" {
+		to, err := queryDate(ctx, toParam)
+		if err != nil {
+			details = append(details, fmt.Sprintf("query param %s must be a date in YYYY-MM-DD format", toParam))
+		}
+		query.To = to.AddDate(0, 0, 1)
+	}
+	amounts := []struct {
+		param string
+		dst   **model.Money
+	}{
+		{minAmountParam, &query.MinAmount},
+		{maxAmountParam, &query.MaxAmount},
+	}
+	for _, amount := range amounts {
+		param, dst := amount.param, amount.dst
+		if raw := ctx.Query(param); raw != "" {
+			amount, err := model.ParseMoney(raw)
+			if err != nil {
+				details = append(details, fmt.Sprintf("query param %s must be an amount", param))
+				continue
+			}
+			*dst = &amount
+		}
+	}
+	integers := []struct {
+		param string
+		dst   *int
+	}{
+		{categoryIdParam, &query.CategoryId},
+		{accountIdParam, &query.AccountId},
+		{limitParam, &query.Limit},
+	}
+	for _, integer := range integers {
+		param, dst := integer.param, integer.dst
+		if raw := ctx.Query(param); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				details = append(details, fmt.Sprintf("query param %s must be an integer", param))
+				continue
+			}
+			*dst = value
+		}
+	}
+	if raw := ctx.Query(cursorParam); raw != "" {
+		cursor, err := model.DecodeExpenseCursor(raw)
+		if err != nil {
+			details = append(details, fmt.Sprintf("query param %s is not valid", cursorParam))
+		}
+		query.After = cursor
+	}
+
+	if len(details) > 0 {
+		return query, customErrors.NewInvalidItemError(usecase.ExpenseName, details...)
+	}
+	return query, nil
+}
+
+// nextPageLink returns the request URL with its cursor replaced by cursor.
+func nextPageLink(ctx *gin.Context, cursor string) string {
+	next := *ctx.Request.URL
+	values := next.Query()
+	values.Set(cursorParam, cursor)
+	next.RawQuery = values.Encode()
+	return next.RequestURI()
+}
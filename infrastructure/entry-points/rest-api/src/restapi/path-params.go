@@ -0,0 +1,18 @@
+package restapi
+
+import (
+	"strconv"
+
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const idParam = "id"
+
+func pathID(ctx *gin.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.Param(idParam))
+	if err != nil || id < 0 {
+		return 0, customErrors.NewInvalidItemError(idParam, "must be a positive integer")
+	}
+	return id, nil
+}
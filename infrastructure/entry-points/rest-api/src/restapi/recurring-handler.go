@@ -0,0 +1,217 @@
+package restapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	recurringRulesPath = "/recurring-rules"
+	occurrencesPath    = "/occurrences"
+	skipPath           = "/skip"
+	occurrenceDate     = "date"
+	// upcomingDays is how far the occurrences are listed when the request
+	// has no to query param.
+	upcomingDays = 31
+)
+
+type RecurringHandler struct {
+	useCase usecase.RecurringUseCase
+}
+
+func NewRecurringHandler(uc usecase.RecurringUseCase) *RecurringHandler {
+	return &RecurringHandler{useCase: uc}
+}
+
+func (h *RecurringHandler) Register(router gin.IRouter) {
+	group := router.Group(recurringRulesPath)
+	group.GET("", h.FindAll)
+	group.GET(occurrencesPath, h.Upcoming)
+	group.GET("/:"+idParam, h.FindByID)
+	group.GET("/:"+idParam+occurrencesPath, h.Occurrences)
+	group.POST("", h.Save)
+	group.POST("/:"+idParam+occurrencesPath+"/:"+occurrenceDate+skipPath, h.Skip)
+	group.PUT("/:"+idParam, h.Update)
+	group.PUT("/:"+idParam+occurrencesPath+"/:"+occurrenceDate, h.Override)
+	group.DELETE("/:"+idParam, h.Delete)
+}
+
+func (h *RecurringHandler) FindAll(ctx *gin.Context) {
+	rules, err := h.useCase.FindAll(ctx.Request.Context())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rules)
+}
+
+func (h *RecurringHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	rule, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rule)
+}
+
+func (h *RecurringHandler) Save(ctx *gin.Context) {
+	rule := &model.RecurringRule{}
+	if err := ctx.ShouldBindJSON(rule); err != nil {
+		abortWithError(ctx, bindingError(usecase.RecurringRuleName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), rule)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (h *RecurringHandler) Update(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	rule := &model.RecurringRule{}
+	if err := ctx.ShouldBindJSON(rule); err != nil {
+		abortWithError(ctx, bindingError(usecase.RecurringRuleName, err))
+		return
+	}
+	rule.Id = id
+	updated, err := h.useCase.Update(ctx.Request.Context(), rule)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *RecurringHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Upcoming lists the occurrences of every rule between the from and to
+// query params, from today through the next 31 days by default.
+func (h *RecurringHandler) Upcoming(ctx *gin.Context) {
+	from, to, err := occurrenceQuery(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	occurrences, err := h.useCase.Upcoming(ctx.Request.Context(), from, to)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, occurrences)
+}
+
+// Occurrences lists the occurrences of one rule, with the same query params
+// as Upcoming.
+func (h *RecurringHandler) Occurrences(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	from, to, err := occurrenceQuery(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	occurrences, err := h.useCase.Occurrences(ctx.Request.Context(), id, from, to)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, occurrences)
+}
+
+func (h *RecurringHandler) Skip(ctx *gin.Context) {
+	id, date, err := occurrencePath(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	occurrence, err := h.useCase.Skip(ctx.Request.Context(), id, date)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, occurrence)
+}
+
+// Override sets the amount the occurrence will be created with, from the
+// amount field of the body.
+func (h *RecurringHandler) Override(ctx *gin.Context) {
+	id, date, err := occurrencePath(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	body := struct {
+		Amount model.Money `json:"amount" validate:"required,number"`
+	}{}
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		abortWithError(ctx, bindingError(usecase.OccurrenceName, err))
+		return
+	}
+	occurrence, err := h.useCase.Override(ctx.Request.Context(), id, date, body.Amount)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, occurrence)
+}
+
+func occurrenceQuery(ctx *gin.Context) (time.Time, time.Time, error) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if ctx.Query(fromParam) != "" {
+		var err error
+		if from, err = queryDate(ctx, fromParam); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	to := from.AddDate(0, 0, upcomingDays)
+	if ctx.Query(toParam) != "" {
+		var err error
+		if to, err = queryDate(ctx, toParam); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return from, to, nil
+}
+
+func occurrencePath(ctx *gin.Context) (int, time.Time, error) {
+	id, err := pathID(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	date, err := time.Parse(dateLayout, ctx.Param(occurrenceDate))
+	if err != nil {
+		return 0, time.Time{}, customErrors.NewInvalidItemError(occurrenceDate,
+			"path param date must be a date in YYYY-MM-DD format")
+	}
+	return id, date, nil
+}
@@ -0,0 +1,174 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestRecurringHandler(t *testing.T) {
+	rent := model.RecurringRule{
+		Id: 1, Kind: model.KindExpense, Amount: 120000, Frequency: model.FrequencyMonthly, Interval: 1,
+		DayOfMonth: 31, Start: time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+	}
+	findRent := func(_ context.Context, id int) (*model.RecurringRule, error) {
+		rule := rent
+		return &rule, nil
+	}
+	exists := func(context.Context, int) (bool, error) { return true, nil }
+	noOccurrences := func(context.Context, int, time.Time, time.Time) ([]model.RecurringOccurrence, error) {
+		return []model.RecurringOccurrence{}, nil
+	}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repository port.RecurringRuleRepository
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "given a POST request, then save the rule",
+			method: http.MethodPost,
+			path:   "/recurring-rules",
+			body:   `{"kind":"expense","amount":1200,"frequency":"monthly","dayOfMonth":31,"start":"2023-01-31T00:00:00Z"}`,
+			repository: &mocks.RecurringRuleRepositoryMock{
+				SaveFn: func(_ context.Context, rule *model.RecurringRule) (*model.RecurringRule, error) {
+					rule.Id = 1
+					return rule, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody: `{"id":1,"kind":"expense","amount":1200.00,"frequency":"MONTHLY","interval":1,` +
+				`"dayOfMonth":31,"start":"2023-01-31T00:00:00Z"}`,
+		},
+		{
+			name:       "given a POST request with an unknown frequency, then get bad request",
+			method:     http.MethodPost,
+			path:       "/recurring-rules",
+			body:       `{"kind":"expense","amount":1200,"frequency":"HOURLY","start":"2023-01-31T00:00:00Z"}`,
+			repository: &mocks.RecurringRuleRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a GET request for the occurrences of a rule, then get them by date",
+			method: http.MethodGet,
+			path:   "/recurring-rules/1/occurrences?from=2023-02-01&to=2023-03-31",
+			repository: &mocks.RecurringRuleRepositoryMock{
+				ExistsFn:   exists,
+				FindByIDFn: findRent,
+				FindOccurrencesFn: func(context.Context, int, time.Time, time.Time) ([]model.RecurringOccurrence, error) {
+					return []model.RecurringOccurrence{{
+						RuleId: 1, Date: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+						Status: model.OccurrenceSkipped, Amount: 120000,
+					}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"ruleId":1,"date":"2023-02-28T00:00:00Z","status":"skipped","amount":1200.00},` +
+				`{"ruleId":1,"date":"2023-03-31T00:00:00Z","status":"pending","amount":1200.00}]`,
+		},
+		{
+			name:       "given a GET request for occurrences with an invalid date, then get bad request",
+			method:     http.MethodGet,
+			path:       "/recurring-rules/occurrences?from=february",
+			repository: &mocks.RecurringRuleRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a GET request for upcoming occurrences, then get those of every rule",
+			method: http.MethodGet,
+			path:   "/recurring-rules/occurrences?from=2023-03-01&to=2023-03-31",
+			repository: &mocks.RecurringRuleRepositoryMock{
+				FindAllFn: func(context.Context) ([]model.RecurringRule, error) {
+					return []model.RecurringRule{rent}, nil
+				},
+				FindOccurrencesFn: noOccurrences,
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"ruleId":1,"date":"2023-03-31T00:00:00Z","status":"pending","amount":1200.00}]`,
+		},
+		{
+			name:   "given a skip request, then skip the occurrence",
+			method: http.MethodPost,
+			path:   "/recurring-rules/1/occurrences/2023-03-31/skip",
+			repository: &mocks.RecurringRuleRepositoryMock{
+				ExistsFn:          exists,
+				FindByIDFn:        findRent,
+				FindOccurrencesFn: noOccurrences,
+				SaveOccurrenceFn:  func(context.Context, *model.RecurringOccurrence) (bool, error) { return true, nil },
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ruleId":1,"date":"2023-03-31T00:00:00Z","status":"skipped","amount":1200.00}`,
+		},
+		{
+			name:   "given a skip request for a day the rule doesn't fall on, then get bad request",
+			method: http.MethodPost,
+			path:   "/recurring-rules/1/occurrences/2023-03-30/skip",
+			repository: &mocks.RecurringRuleRepositoryMock{
+				ExistsFn:          exists,
+				FindByIDFn:        findRent,
+				FindOccurrencesFn: noOccurrences,
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given an override request, then change the amount of the occurrence",
+			method: http.MethodPut,
+			path:   "/recurring-rules/1/occurrences/2023-03-31",
+			body:   `{"amount":1250.5}`,
+			repository: &mocks.RecurringRuleRepositoryMock{
+				ExistsFn:          exists,
+				FindByIDFn:        findRent,
+				FindOccurrencesFn: noOccurrences,
+				SaveOccurrenceFn:  func(context.Context, *model.RecurringOccurrence) (bool, error) { return true, nil },
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ruleId":1,"date":"2023-03-31T00:00:00Z","status":"overridden","amount":1250.50}`,
+		},
+		{
+			name:       "given an override request with an invalid date, then get bad request",
+			method:     http.MethodPut,
+			path:       "/recurring-rules/1/occurrences/31-03-2023",
+			body:       `{"amount":1250.5}`,
+			repository: &mocks.RecurringRuleRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a DELETE request, then delete the rule",
+			method: http.MethodDelete,
+			path:   "/recurring-rules/1",
+			repository: &mocks.RecurringRuleRepositoryMock{
+				ExistsFn: func(context.Context, int) (bool, error) { return true, nil },
+				DeleteFn: func(context.Context, int) error { return nil },
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(NewRecurringHandler(usecase.RecurringUseCase{Repository: tt.repository}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("RecurringHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("RecurringHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	customErrors "github.com/enaldo1709/budget-manager/domain/model/src/model/errors"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const transfersPath = "/transfers"
+
+type TransferHandler struct {
+	useCase usecase.TransferUseCase
+}
+
+func NewTransferHandler(uc usecase.TransferUseCase) *TransferHandler {
+	return &TransferHandler{useCase: uc}
+}
+
+func (h *TransferHandler) Register(router gin.IRouter) {
+	group := router.Group(transfersPath)
+	group.GET("", h.FindAll)
+	group.GET("/:"+idParam, h.FindByID)
+	group.POST("", h.Save)
+	group.DELETE("/:"+idParam, h.Delete)
+}
+
+// FindAll lists the transfers from or to the account of the accountId query
+// param, or every transfer without it, oldest first.
+func (h *TransferHandler) FindAll(ctx *gin.Context) {
+	accountId := 0
+	if raw := ctx.Query(accountIdParam); raw != "" {
+		var err error
+		if accountId, err = strconv.Atoi(raw); err != nil {
+			abortWithError(ctx, customErrors.NewInvalidItemError(usecase.TransferName,
+				fmt.Sprintf("query param %s must be an integer", accountIdParam)))
+			return
+		}
+	}
+	transfers, err := h.useCase.FindAll(ctx.Request.Context(), accountId)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+func (h *TransferHandler) FindByID(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	transfer, err := h.useCase.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+func (h *TransferHandler) Save(ctx *gin.Context) {
+	transfer := &model.Transfer{}
+	if err := ctx.ShouldBindJSON(transfer); err != nil {
+		abortWithError(ctx, bindingError(usecase.TransferName, err))
+		return
+	}
+	saved, err := h.useCase.Save(ctx.Request.Context(), transfer)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (h *TransferHandler) Delete(ctx *gin.Context) {
+	id, err := pathID(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
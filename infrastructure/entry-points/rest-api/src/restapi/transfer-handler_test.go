@@ -0,0 +1,108 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enaldo1709/budget-manager/domain/model/src/model"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port"
+	"github.com/enaldo1709/budget-manager/domain/model/src/model/port/mocks"
+	"github.com/enaldo1709/budget-manager/domain/usecase/src/usecase"
+)
+
+func TestTransferHandler(t *testing.T) {
+	created := time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC)
+	accounts := &mocks.AccountRepositoryMock{
+		ExistsFn: func(_ context.Context, id int) (bool, error) { return id <= 2, nil },
+		FindByIDFn: func(_ context.Context, id int) (*model.Account, error) {
+			return &model.Account{Id: id, Name: "Checking", Type: model.AccountBank, Currency: "USD"}, nil
+		},
+	}
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repository port.TransferRepository
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "given a GET request with an account, then get its transfers",
+			method: http.MethodGet,
+			path:   "/transfers?accountId=1",
+			repository: &mocks.TransferRepositoryMock{
+				FindAllFn: func(_ context.Context, accountId int) ([]model.Transfer, error) {
+					if accountId != 1 {
+						t.Errorf("TransferHandler account = %d, want 1", accountId)
+					}
+					return []model.Transfer{{Id: 1, FromAccountId: 1, ToAccountId: 2, Amount: 10000, Currency: "USD", Created: created}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"id":1,"fromAccountId":1,"toAccountId":2,"amount":100.00,"currency":"USD","created":"2023-04-15T00:00:00Z"}]`,
+		},
+		{
+			name:       "given a GET request with an invalid account, then get bad request",
+			method:     http.MethodGet,
+			path:       "/transfers?accountId=checking",
+			repository: &mocks.TransferRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a POST request, then save the transfer",
+			method: http.MethodPost,
+			path:   "/transfers",
+			body:   `{"fromAccountId":1,"toAccountId":2,"amount":100,"created":"2023-04-15T00:00:00Z","description":"savings"}`,
+			repository: &mocks.TransferRepositoryMock{
+				SaveFn: func(_ context.Context, t *model.Transfer) (*model.Transfer, error) {
+					t.Id = 3
+					return t, nil
+				},
+			},
+			wantStatus: http.StatusCreated,
+			wantBody: `{"id":3,"fromAccountId":1,"toAccountId":2,"amount":100.00,"currency":"USD",` +
+				`"created":"2023-04-15T00:00:00Z","description":"savings"}`,
+		},
+		{
+			name:       "given a POST request to an unknown account, then get bad request",
+			method:     http.MethodPost,
+			path:       "/transfers",
+			body:       `{"fromAccountId":1,"toAccountId":9,"amount":100,"created":"2023-04-15T00:00:00Z"}`,
+			repository: &mocks.TransferRepositoryMock{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "given a DELETE request, then delete the transfer",
+			method: http.MethodDelete,
+			path:   "/transfers/3",
+			repository: &mocks.TransferRepositoryMock{
+				ExistsFn: func(context.Context, int) (bool, error) { return true, nil },
+				DeleteFn: func(context.Context, int) error { return nil },
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(
+				NewTransferHandler(usecase.TransferUseCase{Repository: tt.repository, Accounts: accounts}).Register)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("TransferHandler status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("TransferHandler body = %s, want %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
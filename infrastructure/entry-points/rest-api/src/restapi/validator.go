@@ -0,0 +1,34 @@
+package restapi
+
+import (
+	"log"
+	"reflect"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const validateTagName = "validate"
+
+// ConfigureValidator makes gin binding honour the `validate` tags declared on
+// the domain models instead of gin's default `binding` tag.
+func ConfigureValidator() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.SetTagName(validateTagName)
+	if err := v.RegisterValidation("integer", isInteger); err != nil {
+		log.Println("error: error registering integer validation... ", err)
+	}
+}
+
+func isInteger(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
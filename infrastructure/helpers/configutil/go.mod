@@ -2,11 +2,12 @@ module github.com/enaldo1709/budget-manager/infrastructure/helpers/configutil
 
 go 1.21.1
 
+require github.com/gookit/config/v2 v2.2.3
+
 require (
 	github.com/fatih/color v1.15.0 // indirect
 	github.com/goccy/go-yaml v1.11.2 // indirect
 	github.com/gookit/color v1.5.4 // indirect
-	github.com/gookit/config/v2 v2.2.3 // indirect
 	github.com/gookit/goutil v0.6.12 // indirect
 	github.com/imdario/mergo v0.3.16 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
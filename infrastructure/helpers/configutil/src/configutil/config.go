@@ -11,15 +11,23 @@ import (
 	"github.com/gookit/config/v2/yamlv3"
 )
 
-func LoadConfig() {
+const propertiesTagName = "yaml"
+
+func LoadConfig() error {
 	profiles := getActiveProfiles()
 	files := []string{"config/application.yaml"}
 	for _, prof := range profiles {
 		files = append(files, fmt.Sprintf("config/application-%s.yaml", prof))
 	}
-	configv2.WithOptions(configv2.ParseEnv)
+	configv2.WithOptions(configv2.ParseEnv, configv2.ParseTime, configv2.WithTagName(propertiesTagName))
 	configv2.AddDriver(yamlv3.Driver)
-	configv2.LoadFiles(files...)
+	return configv2.LoadFiles(files...)
+}
+
+// BindProperties fills dst with the configuration section found at key,
+// matching fields through their `yaml` tags.
+func BindProperties(key string, dst any) error {
+	return configv2.BindStruct(key, dst)
 }
 
 func getActiveProfiles() []string {